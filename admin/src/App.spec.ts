@@ -32,6 +32,10 @@ vi.mock('./pages/sources/SourceManager.vue', () => ({
   default: { name: 'SourceManager', template: '<div>SourceManager</div>' },
 }))
 
+vi.mock('./pages/users/UserManager.vue', () => ({
+  default: { name: 'UserManager', template: '<div>UserManager</div>' },
+}))
+
 describe('App nav', () => {
   it('does not expose news-related tabs', () => {
     const wrapper = mount(App)
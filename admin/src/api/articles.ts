@@ -33,6 +33,58 @@ export async function createSummaryJob(articleID: number, sourceID: number): Pro
 }
 
 export async function listPublishedArticles(): Promise<ArticleItem[]> {
-  const data = await request<{ items: ArticleItem[] }>('/api/articles')
+  const data = await request<{ items: ArticleItem[] }>('/api/articles?limit=50')
   return data.items || []
 }
+
+export type ArticleSnapshot = {
+  title: string
+  summary: string
+  content: string
+  cover_url: string
+  video_url: string
+  status: string
+}
+
+export type ArticleRevision = {
+  id: number
+  article_id: number
+  editor_id?: number
+  action: 'original' | 'published' | 'released' | 'edited' | 'offlined' | 'reverted'
+  snapshot: ArticleSnapshot
+  created_at: string
+}
+
+export type RevisionChange = {
+  field: keyof ArticleSnapshot
+  before: string
+  after: string
+}
+
+export type RevisionDiff = {
+  revision: ArticleRevision
+  against?: ArticleRevision
+  changes: RevisionChange[]
+}
+
+export type ArticleEdit = Partial<Pick<ArticleSnapshot, 'title' | 'summary' | 'content' | 'cover_url' | 'video_url'>>
+
+export async function editArticle(id: number, edit: ArticleEdit): Promise<ArticleRevision> {
+  return request<ArticleRevision>(`/admin/articles/${id}`, {
+    method: 'PUT',
+    body: JSON.stringify(edit),
+  })
+}
+
+export async function listArticleRevisions(id: number): Promise<ArticleRevision[]> {
+  const data = await request<{ items: ArticleRevision[] }>(`/admin/articles/${id}/revisions`)
+  return data.items || []
+}
+
+export async function diffArticleRevision(id: number, revisionID: number): Promise<RevisionDiff> {
+  return request<RevisionDiff>(`/admin/articles/${id}/revisions/${revisionID}/diff`)
+}
+
+export async function revertArticleRevision(id: number, revisionID: number): Promise<ArticleRevision> {
+  return request<ArticleRevision>(`/admin/articles/${id}/revisions/${revisionID}/revert`, { method: 'POST' })
+}
@@ -1,15 +1,198 @@
 import { request } from './request'
 
+export type EntityLink = {
+  entity_type: 'fighter' | 'event'
+  entity_id: number
+  name?: string
+  matched_text?: string
+  status: 'suggested' | 'confirmed' | 'rejected'
+}
+
+export type TranslationDraft = {
+  job_id: number
+  status: 'pending' | 'running' | 'done' | 'failed' | 'manual_required'
+  title?: string
+  body?: string
+  error?: string
+}
+
+export type ReviewStatus = 'pending' | 'approved' | 'rejected'
+
+export type ReviewNote = {
+  id: number
+  reviewer_id?: number
+  body: string
+  created_at: string
+}
+
+export type PendingEdit = Partial<Pick<PendingItem, 'title' | 'summary' | 'cover_url' | 'video_url'>>
+
+// ApproveSchedule holds an approved article back; it goes out at the later of the two times.
+export type ApproveSchedule = {
+  publish_at?: string
+  embargo_until?: string
+}
+
+// BulkSelection picks items by id, or every pending item from a source or publish-time range.
+export type BulkSelection = {
+  ids?: number[]
+  source_id?: number
+  from?: string
+  to?: string
+}
+
+export type BulkResult = {
+  id: number
+  ok: boolean
+  error?: string
+}
+
+export type BulkResponse = {
+  items: BulkResult[]
+  succeeded: number
+  failed: number
+}
+
+export type PendingQuery = {
+  status?: ReviewStatus
+  source_id?: number
+}
+
 export type PendingItem = {
   id: number
+  source_id?: number
   title: string
+  summary?: string
+  content?: string
+  author?: string
+  source_url?: string
+  cover_url?: string
+  video_url?: string
+  published_at?: string
+  status?: ReviewStatus
+  reject_reason?: string
+  reviewer_id?: number
+  reviewed_at?: string
+  notes?: ReviewNote[]
+  version?: number
+  claimed_by?: number
+  claimed_until?: string
+  publish_at?: string
+  embargo_until?: string
+  duplicate_of?: number
+  alternatives?: PendingItem[]
+  entities?: EntityLink[]
+  tags?: string[]
+  translation?: TranslationDraft
 }
 
-export async function listPending(): Promise<PendingItem[]> {
-  const data = await request<{ items: PendingItem[] }>('/admin/review/pending')
+export async function listPending(query?: PendingQuery): Promise<PendingItem[]> {
+  const params = new URLSearchParams()
+  if (query?.status) {
+    params.set('status', query.status)
+  }
+  if (query?.source_id) {
+    params.set('source_id', String(query.source_id))
+  }
+  const qs = params.toString()
+  const data = await request<{ items: PendingItem[] }>(`/admin/review/pending${qs ? `?${qs}` : ''}`)
   return data.items || []
 }
 
-export async function approvePending(id: number): Promise<void> {
-  await request(`/admin/review/${id}/approve?reviewer_id=9001`, { method: 'POST' })
+// versionQuery sends the version the editor loaded so the server can reject stale writes.
+function versionQuery(version?: number): string {
+  return version ? `?version=${version}` : ''
+}
+
+export async function editPending(id: number, edit: PendingEdit, version?: number): Promise<PendingItem> {
+  return request<PendingItem>(`/admin/review/${id}${versionQuery(version)}`, {
+    method: 'PUT',
+    body: JSON.stringify(edit),
+  })
+}
+
+export async function rejectPending(id: number, reason: string, version?: number): Promise<void> {
+  await request(`/admin/review/${id}/reject${versionQuery(version)}`, {
+    method: 'POST',
+    body: JSON.stringify({ reason }),
+  })
+}
+
+export async function reopenPending(id: number, version?: number): Promise<void> {
+  await request(`/admin/review/${id}/reopen${versionQuery(version)}`, { method: 'POST' })
+}
+
+export async function addReviewNote(id: number, body: string): Promise<ReviewNote> {
+  return request<ReviewNote>(`/admin/review/${id}/notes`, {
+    method: 'POST',
+    body: JSON.stringify({ body }),
+  })
+}
+
+export async function approvePending(id: number, version?: number, schedule?: ApproveSchedule): Promise<void> {
+  const hasSchedule = Boolean(schedule?.publish_at || schedule?.embargo_until)
+  await request(`/admin/review/${id}/approve${versionQuery(version)}`, {
+    method: 'POST',
+    ...(hasSchedule ? { body: JSON.stringify(schedule) } : {}),
+  })
+}
+
+export async function listScheduled(): Promise<PendingItem[]> {
+  const data = await request<{ items: PendingItem[] }>('/admin/review/scheduled')
+  return data.items || []
+}
+
+export async function bulkApprove(selection: BulkSelection): Promise<BulkResponse> {
+  return request<BulkResponse>('/admin/review/bulk/approve', {
+    method: 'POST',
+    body: JSON.stringify(selection),
+  })
+}
+
+export async function bulkReject(selection: BulkSelection, reason: string): Promise<BulkResponse> {
+  return request<BulkResponse>('/admin/review/bulk/reject', {
+    method: 'POST',
+    body: JSON.stringify({ ...selection, reason }),
+  })
+}
+
+export async function bulkReassign(selection: BulkSelection, assigneeID: number): Promise<BulkResponse> {
+  return request<BulkResponse>('/admin/review/bulk/reassign', {
+    method: 'POST',
+    body: JSON.stringify({ ...selection, assignee_id: assigneeID }),
+  })
+}
+
+export async function claimPending(id: number): Promise<PendingItem> {
+  return request<PendingItem>(`/admin/review/${id}/claim`, { method: 'POST' })
+}
+
+export async function releasePending(id: number): Promise<void> {
+  await request(`/admin/review/${id}/claim`, { method: 'DELETE' })
+}
+
+export async function setPendingEntity(
+  id: number,
+  link: Pick<EntityLink, 'entity_type' | 'entity_id' | 'status'>,
+): Promise<EntityLink> {
+  return request<EntityLink>(`/admin/review/${id}/entities`, {
+    method: 'POST',
+    body: JSON.stringify(link),
+  })
+}
+
+export async function setPendingTags(id: number, tags: string[]): Promise<string[]> {
+  const data = await request<{ tags: string[] }>(`/admin/review/${id}/tags`, {
+    method: 'PUT',
+    body: JSON.stringify({ tags }),
+  })
+  return data.tags || []
+}
+
+export async function requestTranslation(id: number): Promise<TranslationDraft> {
+  const job = await request<{ id: number; status: TranslationDraft['status']; error_msg?: string }>(
+    `/admin/review/${id}/translate`,
+    { method: 'POST' },
+  )
+  return { job_id: job.id, status: job.status, error: job.error_msg }
 }
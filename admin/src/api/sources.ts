@@ -15,12 +15,26 @@ export type SourceItem = {
   rights_display: boolean
   rights_playback: boolean
   rights_ai_summary: boolean
+  rights_translation: boolean
   rights_expires_at?: string
   rights_proof_url?: string
+  crawl_include_pattern?: string
+  crawl_exclude_pattern?: string
+  crawl_max_depth?: number
+  crawl_max_items?: number
+  fetch_interval_sec?: number
+  fetch_fail_count?: number
+  next_fetch_at?: string
+  last_fetch_at?: string
+  last_fetch_status?: string
+  last_fetch_error?: string
   deleted_at?: string
 }
 
-export type SourcePayload = Omit<SourceItem, 'id' | 'deleted_at'>
+export type SourcePayload = Omit<
+  SourceItem,
+  'id' | 'deleted_at' | 'fetch_fail_count' | 'next_fetch_at' | 'last_fetch_at' | 'last_fetch_status' | 'last_fetch_error'
+>
 
 export type SourceListQuery = {
   include_deleted?: boolean
@@ -0,0 +1,17 @@
+import { request } from './request'
+
+export type TagKind = 'category' | 'org' | 'tag'
+
+export type Tag = {
+  id: number
+  slug: string
+  name: string
+  kind: TagKind
+  keywords: string[]
+  source_ids: number[]
+}
+
+export async function listTags(): Promise<Tag[]> {
+  const data = await request<{ items: Tag[] }>('/admin/tags')
+  return data.items || []
+}
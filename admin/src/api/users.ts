@@ -0,0 +1,43 @@
+import { request } from './request'
+
+export type AdminUserItem = {
+  id: number
+  username: string
+  status: 'active' | 'disabled'
+  password_changed_at?: string
+  last_login_at?: string
+  created_at: string
+}
+
+export async function listUsers(): Promise<AdminUserItem[]> {
+  const data = await request<{ items: AdminUserItem[] }>('/admin/users')
+  return data.items || []
+}
+
+export async function createUser(username: string, password: string): Promise<AdminUserItem> {
+  return request<AdminUserItem>('/admin/users', {
+    method: 'POST',
+    body: JSON.stringify({ username, password }),
+  })
+}
+
+export async function setUserStatus(userID: number, status: 'active' | 'disabled'): Promise<AdminUserItem> {
+  return request<AdminUserItem>(`/admin/users/${userID}/status`, {
+    method: 'PUT',
+    body: JSON.stringify({ status }),
+  })
+}
+
+export async function resetUserPassword(userID: number, password: string): Promise<void> {
+  await request(`/admin/users/${userID}/password`, {
+    method: 'PUT',
+    body: JSON.stringify({ password }),
+  })
+}
+
+export async function changeOwnPassword(currentPassword: string, newPassword: string): Promise<void> {
+  await request('/admin/auth/password', {
+    method: 'PUT',
+    body: JSON.stringify({ current_password: currentPassword, new_password: newPassword }),
+  })
+}
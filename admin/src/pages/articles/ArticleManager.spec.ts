@@ -2,11 +2,22 @@ import { flushPromises, mount } from '@vue/test-utils'
 import { beforeEach, describe, expect, it, vi } from 'vitest'
 
 import ArticleManager from './ArticleManager.vue'
-import { createManualArticle, listPublishedArticles } from '../../api/articles'
+import {
+  createManualArticle,
+  diffArticleRevision,
+  editArticle,
+  listArticleRevisions,
+  listPublishedArticles,
+  revertArticleRevision,
+} from '../../api/articles'
 
 vi.mock('../../api/articles', () => ({
   createManualArticle: vi.fn(),
   listPublishedArticles: vi.fn(),
+  editArticle: vi.fn(),
+  listArticleRevisions: vi.fn(),
+  diffArticleRevision: vi.fn(),
+  revertArticleRevision: vi.fn(),
 }))
 
 describe('ArticleManager', () => {
@@ -61,4 +72,50 @@ describe('ArticleManager', () => {
     expect(wrapper.text()).toContain('UFC 315 预热')
     expect(wrapper.text()).not.toContain('UFC 314 战卡公布')
   })
+  it('edits a published article, diffs revisions and rolls back', async () => {
+    const snapshot = { title: 'UFC 317 前瞻', summary: '摘要', content: '正文', cover_url: '', video_url: '', status: 'published' }
+    const original = { id: 1, article_id: 9, editor_id: 3, action: 'published' as const, snapshot, created_at: '2026-10-01' }
+    const edited = {
+      id: 2,
+      article_id: 9,
+      editor_id: 4,
+      action: 'edited' as const,
+      snapshot: { ...snapshot, title: 'UFC 317 主赛改期' },
+      created_at: '2026-10-02',
+    }
+    vi.mocked(listPublishedArticles).mockResolvedValue([
+      { id: 9, source_id: 6, title: 'UFC 317 前瞻', summary: '摘要', source_url: 'https://www.ufc.com', can_play: false },
+    ])
+    vi.mocked(listArticleRevisions).mockResolvedValue([edited, original])
+    vi.mocked(editArticle).mockResolvedValue(edited)
+    vi.mocked(diffArticleRevision).mockResolvedValue({
+      revision: edited,
+      against: original,
+      changes: [{ field: 'title', before: 'UFC 317 前瞻', after: 'UFC 317 主赛改期' }],
+    })
+    vi.mocked(revertArticleRevision).mockResolvedValue({ ...original, id: 3, action: 'reverted', editor_id: 4 })
+
+    const wrapper = mount(ArticleManager)
+    await flushPromises()
+
+    await wrapper.get('[data-test="history-9"]').trigger('click')
+    await flushPromises()
+    expect(listArticleRevisions).toHaveBeenCalledWith(9)
+    expect(wrapper.get('[data-test="revision-2"]').text()).toContain('编辑 · #4')
+    expect(wrapper.find('[data-test="revert-2"]').exists()).toBe(false)
+
+    await wrapper.get('[data-test="edit-title"]').setValue('UFC 317 主赛改期')
+    await wrapper.get('[data-test="save-edit"]').trigger('click')
+    await flushPromises()
+    expect(editArticle).toHaveBeenCalledWith(9, { title: 'UFC 317 主赛改期', summary: '摘要' })
+
+    await wrapper.get('[data-test="diff-2"]').trigger('click')
+    await flushPromises()
+    expect(wrapper.get('[data-test="diff"]').text()).toContain('UFC 317 主赛改期')
+
+    await wrapper.get('[data-test="revert-1"]').trigger('click')
+    await flushPromises()
+    expect(revertArticleRevision).toHaveBeenCalledWith(9, 1)
+    expect(wrapper.text()).toContain('已回滚到版本 #1')
+  })
 })
@@ -2,22 +2,64 @@ import { flushPromises, mount } from '@vue/test-utils'
 import { beforeEach, describe, expect, it, vi } from 'vitest'
 
 import ReviewQueue from './ReviewQueue.vue'
-import { approvePending, listPending } from '../../api/review'
+import {
+  addReviewNote,
+  approvePending,
+  bulkApprove,
+  bulkReassign,
+  claimPending,
+  editPending,
+  listPending,
+  listScheduled,
+  rejectPending,
+  releasePending,
+  reopenPending,
+  requestTranslation,
+  setPendingEntity,
+  setPendingTags,
+} from '../../api/review'
+import { listTags } from '../../api/tags'
 
 vi.mock('../../api/review', () => ({
   listPending: vi.fn(),
+  listScheduled: vi.fn(),
   approvePending: vi.fn(),
+  bulkApprove: vi.fn(),
+  bulkReject: vi.fn(),
+  bulkReassign: vi.fn(),
+  setPendingEntity: vi.fn(),
+  setPendingTags: vi.fn(),
+  requestTranslation: vi.fn(),
+  rejectPending: vi.fn(),
+  reopenPending: vi.fn(),
+  editPending: vi.fn(),
+  addReviewNote: vi.fn(),
+  claimPending: vi.fn(),
+  releasePending: vi.fn(),
+}))
+
+vi.mock('../../api/tags', () => ({
+  listTags: vi.fn(),
 }))
 
 describe('ReviewQueue', () => {
   beforeEach(() => {
     vi.clearAllMocks()
+    vi.mocked(listTags).mockResolvedValue([])
   })
 
   it('supports review workspace flow', async () => {
     vi.mocked(listPending).mockResolvedValue([
       { id: 1, title: 'UFC 314 战卡更新' },
-      { id: 2, title: 'ONE 172 赛程变更' },
+      {
+        id: 2,
+        title: 'ONE 172 赛程变更',
+        summary: '主赛对阵调整',
+        content: '第一段正文\n\n第二段正文',
+        author: '编辑部',
+        version: 3,
+        alternatives: [{ id: 3, title: 'ONE 172 赛程调整', source_url: 'https://example.com/one-172' }],
+      },
     ])
     vi.mocked(approvePending).mockResolvedValue()
 
@@ -31,10 +73,237 @@ describe('ReviewQueue', () => {
     await wrapper.get('[data-test="apply-filter"]').trigger('click')
     expect(wrapper.text()).toContain('ONE 172 赛程变更')
     expect(wrapper.text()).not.toContain('UFC 314 战卡更新')
+    expect(wrapper.text()).toContain('主赛对阵调整')
+    expect(wrapper.text()).toContain('编辑部')
+    expect(wrapper.get('[data-test="content-2"]').text()).toContain('第二段正文')
+    expect(wrapper.get('[data-test="alternatives-2"]').text()).toContain('另有 1 个来源的相似报道')
+    expect(wrapper.get('[data-test="alternatives-2"]').text()).toContain('ONE 172 赛程调整')
 
     await wrapper.get('[data-test="approve-2"]').trigger('click')
-    expect(approvePending).toHaveBeenCalledWith(2)
+    expect(approvePending).toHaveBeenCalledWith(2, 3, {})
     await flushPromises()
     expect(wrapper.text()).toContain('已通过待审核内容 #2')
   })
+
+  it('lets editors confirm or remove detected entity links', async () => {
+    vi.mocked(listPending).mockResolvedValue([
+      {
+        id: 5,
+        title: '张伟丽宣布夏季复出',
+        entities: [{ entity_type: 'fighter', entity_id: 7, name: '张伟丽', status: 'suggested' }],
+      },
+    ])
+    vi.mocked(setPendingEntity).mockResolvedValue({ entity_type: 'fighter', entity_id: 7, status: 'confirmed' })
+
+    const wrapper = mount(ReviewQueue)
+    await flushPromises()
+
+    expect(wrapper.get('[data-test="entities-5"]').text()).toContain('选手 · 张伟丽')
+    await wrapper.get('[data-test="confirm-5-fighter-7"]').trigger('click')
+    expect(setPendingEntity).toHaveBeenCalledWith(5, { entity_type: 'fighter', entity_id: 7, status: 'confirmed' })
+    await flushPromises()
+    expect(wrapper.find('[data-test="confirm-5-fighter-7"]').exists()).toBe(false)
+  })
+
+  it('lets editors toggle suggested tags before approval', async () => {
+    vi.mocked(listPending).mockResolvedValue([{ id: 6, title: 'UFC 315 专访', tags: ['ufc'] }])
+    vi.mocked(listTags).mockResolvedValue([
+      { id: 1, slug: 'interviews', name: '专访', kind: 'category', keywords: [], source_ids: [] },
+      { id: 2, slug: 'ufc', name: 'UFC', kind: 'org', keywords: [], source_ids: [] },
+    ])
+    vi.mocked(setPendingTags).mockResolvedValue(['ufc', 'interviews'])
+
+    const wrapper = mount(ReviewQueue)
+    await flushPromises()
+
+    expect(wrapper.get('[data-test="tag-6-ufc"]').classes()).toContain('active')
+    expect(wrapper.get('[data-test="tag-6-interviews"]').classes()).not.toContain('active')
+    await wrapper.get('[data-test="tag-6-interviews"]').trigger('click')
+    expect(setPendingTags).toHaveBeenCalledWith(6, ['ufc', 'interviews'])
+    await flushPromises()
+    expect(wrapper.get('[data-test="tag-6-interviews"]').classes()).toContain('active')
+  })
+
+  it('shows the translation draft and queues a new translation', async () => {
+    vi.mocked(listPending).mockResolvedValue([
+      {
+        id: 8,
+        title: 'Pereira defends title',
+        translation: { job_id: 3, status: 'done', title: '佩雷拉卫冕成功', body: '第一段译文' },
+      },
+    ])
+    vi.mocked(requestTranslation).mockResolvedValue({ job_id: 4, status: 'pending' })
+
+    const wrapper = mount(ReviewQueue)
+    await flushPromises()
+
+    expect(wrapper.get('[data-test="translation-8"]').text()).toContain('佩雷拉卫冕成功')
+    expect(wrapper.get('[data-test="translation-8"]').text()).toContain('通过后发布译文')
+
+    await wrapper.get('[data-test="translate-8"]').trigger('click')
+    expect(requestTranslation).toHaveBeenCalledWith(8)
+    await flushPromises()
+    expect(wrapper.get('[data-test="translation-8"]').text()).toContain('翻译中')
+  })
+
+  it('rejects with a reason, edits before approval and records notes', async () => {
+    vi.mocked(listPending).mockResolvedValue([
+      { id: 11, title: 'UFC 315 前瞻', summary: '旧摘要', status: 'pending', version: 1 },
+      { id: 12, title: '标题党资讯', status: 'pending', version: 4 },
+    ])
+    vi.mocked(editPending).mockResolvedValue({ id: 11, title: 'UFC 315 全卡前瞻', summary: '新摘要', status: 'pending', version: 2 })
+    vi.mocked(addReviewNote).mockResolvedValue({ id: 1, reviewer_id: 9001, body: '等官方确认', created_at: '2026-10-19T10:00:00Z' })
+    vi.mocked(rejectPending).mockResolvedValue()
+
+    const wrapper = mount(ReviewQueue)
+    await flushPromises()
+    expect(listPending).toHaveBeenCalledWith({ status: 'pending', source_id: undefined })
+
+    await wrapper.get('[data-test="reject-12"]').trigger('click')
+    expect(rejectPending).not.toHaveBeenCalled()
+    expect(wrapper.text()).toContain('请填写拒绝原因')
+    await wrapper.get('[data-test="reason-12"]').setValue('标题党')
+    await wrapper.get('[data-test="reject-12"]').trigger('click')
+    expect(rejectPending).toHaveBeenCalledWith(12, '标题党', 4)
+    await flushPromises()
+    expect(wrapper.text()).not.toContain('标题党资讯')
+
+    await wrapper.get('[data-test="edit-11"]').trigger('click')
+    await wrapper.get('[data-test="edit-title"]').setValue('UFC 315 全卡前瞻')
+    await wrapper.get('[data-test="edit-summary"]').setValue('新摘要')
+    await wrapper.get('[data-test="save-edit"]').trigger('click')
+    expect(editPending).toHaveBeenCalledWith(
+      11,
+      { title: 'UFC 315 全卡前瞻', summary: '新摘要', cover_url: '', video_url: '' },
+      1,
+    )
+    await flushPromises()
+    expect(wrapper.text()).toContain('UFC 315 全卡前瞻')
+
+    await wrapper.get('[data-test="note-input-11"]').setValue('等官方确认')
+    await wrapper.get('[data-test="add-note-11"]').trigger('click')
+    await flushPromises()
+    expect(addReviewNote).toHaveBeenCalledWith(11, '等官方确认')
+    expect(wrapper.get('[data-test="notes-11"]').text()).toContain('等官方确认')
+  })
+
+  it('filters by status and source and reopens rejected items', async () => {
+    vi.mocked(listPending).mockResolvedValue([])
+    vi.mocked(reopenPending).mockResolvedValue()
+
+    const wrapper = mount(ReviewQueue)
+    await flushPromises()
+
+    vi.mocked(listPending).mockResolvedValue([
+      { id: 21, title: '被拒资讯', status: 'rejected', reject_reason: '重复报道', version: 2 },
+    ])
+    await wrapper.get('[data-test="status-filter"]').setValue('rejected')
+    await wrapper.get('[data-test="source-filter"]').setValue('3')
+    await wrapper.get('[data-test="apply-filter"]').trigger('click')
+    await flushPromises()
+    expect(listPending).toHaveBeenLastCalledWith({ status: 'rejected', source_id: 3 })
+    expect(wrapper.get('[data-test="reject-reason-21"]').text()).toContain('重复报道')
+    expect(wrapper.find('[data-test="approve-21"]').exists()).toBe(false)
+
+    await wrapper.get('[data-test="reopen-21"]').trigger('click')
+    expect(reopenPending).toHaveBeenCalledWith(21, 2)
+    await flushPromises()
+    expect(wrapper.text()).toContain('已重新打开 #21')
+  })
+
+  it('claims and releases items and reports conflicts', async () => {
+    vi.mocked(listPending).mockResolvedValue([{ id: 31, title: 'PFL 决赛前瞻', status: 'pending', version: 1 }])
+    vi.mocked(claimPending).mockResolvedValue({
+      id: 31,
+      title: 'PFL 决赛前瞻',
+      status: 'pending',
+      version: 1,
+      claimed_by: 7,
+      claimed_until: '2026-10-19T10:10:00Z',
+    })
+    vi.mocked(releasePending).mockResolvedValue()
+    vi.mocked(approvePending).mockRejectedValue(new Error('pending article has changed, reload and retry'))
+
+    const wrapper = mount(ReviewQueue)
+    await flushPromises()
+
+    await wrapper.get('[data-test="claim-31-toggle"]').trigger('click')
+    await flushPromises()
+    expect(claimPending).toHaveBeenCalledWith(31)
+    expect(wrapper.get('[data-test="claim-31"]').text()).toContain('#7 处理中')
+
+    await wrapper.get('[data-test="approve-31"]').trigger('click')
+    await flushPromises()
+    expect(wrapper.text()).toContain('pending article has changed')
+    expect(wrapper.text()).toContain('PFL 决赛前瞻')
+
+    await wrapper.get('[data-test="claim-31-toggle"]').trigger('click')
+    await flushPromises()
+    expect(releasePending).toHaveBeenCalledWith(31)
+    expect(wrapper.find('[data-test="claim-31"]').exists()).toBe(false)
+  })
+  it('schedules approvals and lists scheduled articles', async () => {
+    vi.mocked(listPending).mockResolvedValue([{ id: 41, title: 'UFC 310 官方海报', status: 'pending', version: 1 }])
+    vi.mocked(approvePending).mockResolvedValue()
+
+    const wrapper = mount(ReviewQueue)
+    await flushPromises()
+
+    await wrapper.get('[data-test="embargo-41"]').setValue('2026-11-01T08:00')
+    await wrapper.get('[data-test="approve-41"]').trigger('click')
+    expect(approvePending).toHaveBeenCalledWith(41, 1, {
+      publish_at: undefined,
+      embargo_until: new Date('2026-11-01T08:00').toISOString(),
+    })
+    await flushPromises()
+    expect(wrapper.text()).toContain('已通过 #41，将按时发布')
+
+    vi.mocked(listScheduled).mockResolvedValue([
+      {
+        id: 90,
+        title: 'UFC 310 官方海报',
+        publish_at: '2026-11-01T00:00:00Z',
+        embargo_until: '2026-11-01T00:00:00Z',
+      },
+    ])
+    await wrapper.get('[data-test="status-filter"]').setValue('scheduled')
+    await wrapper.get('[data-test="apply-filter"]').trigger('click')
+    await flushPromises()
+    expect(listScheduled).toHaveBeenCalled()
+    expect(wrapper.get('[data-test="scheduled-90"]').text()).toContain('定时发布 · 2026-11-01T00:00:00Z')
+    expect(wrapper.find('[data-test="approve-90"]').exists()).toBe(false)
+  })
+  it('runs bulk actions on selected items and keeps failures selected', async () => {
+    vi.mocked(listPending).mockResolvedValue([
+      { id: 51, title: 'Bellator 速报', status: 'pending' },
+      { id: 52, title: 'Bellator 赛果', status: 'pending' },
+    ])
+    vi.mocked(bulkApprove).mockResolvedValue({
+      items: [
+        { id: 51, ok: true },
+        { id: 52, ok: false, error: 'pending article is claimed by another reviewer' },
+      ],
+      succeeded: 1,
+      failed: 1,
+    })
+    vi.mocked(bulkReassign).mockResolvedValue({ items: [{ id: 52, ok: true }], succeeded: 1, failed: 0 })
+
+    const wrapper = mount(ReviewQueue)
+    await flushPromises()
+    expect(wrapper.find('[data-test="bulk-bar"]').exists()).toBe(false)
+
+    await wrapper.get('[data-test="select-51"]').setValue(true)
+    await wrapper.get('[data-test="select-52"]').setValue(true)
+    await wrapper.get('[data-test="bulk-approve"]').trigger('click')
+    await flushPromises()
+    expect(bulkApprove).toHaveBeenCalledWith({ ids: [51, 52] })
+    expect(wrapper.text()).toContain('批量通过：成功 1 条，失败 1 条')
+    expect(wrapper.text()).toContain('#52 pending article is claimed by another reviewer')
+    expect(wrapper.get('[data-test="bulk-bar"]').text()).toContain('已选 1 条')
+
+    await wrapper.get('[data-test="bulk-assignee"]').setValue('9')
+    await wrapper.get('[data-test="bulk-reassign"]').trigger('click')
+    await flushPromises()
+    expect(bulkReassign).toHaveBeenCalledWith({ ids: [52] }, 9)
+  })
 })
@@ -37,6 +37,7 @@ const ACTIVE_SOURCE: SourceItem = {
   rights_display: true,
   rights_playback: false,
   rights_ai_summary: true,
+  rights_translation: false,
   rights_expires_at: '',
   rights_proof_url: '',
   deleted_at: '',
@@ -55,6 +56,7 @@ const DELETED_SOURCE: SourceItem = {
   rights_display: true,
   rights_playback: false,
   rights_ai_summary: true,
+  rights_translation: false,
   rights_expires_at: '',
   rights_proof_url: '',
   deleted_at: '2026-02-17T10:00:00Z',
@@ -0,0 +1,64 @@
+import { flushPromises, mount } from '@vue/test-utils'
+import { beforeEach, describe, expect, it, vi } from 'vitest'
+
+import UserManager from './UserManager.vue'
+import { changeOwnPassword, createUser, listUsers, resetUserPassword, setUserStatus } from '../../api/users'
+
+vi.mock('../../api/users', () => ({
+  listUsers: vi.fn(),
+  createUser: vi.fn(),
+  setUserStatus: vi.fn(),
+  resetUserPassword: vi.fn(),
+  changeOwnPassword: vi.fn(),
+}))
+
+const owner = { id: 1, username: 'admin', status: 'active' as const, created_at: '2026-03-01T00:00:00Z' }
+const editor = { id: 2, username: 'editor', status: 'active' as const, created_at: '2026-03-02T00:00:00Z' }
+
+describe('UserManager', () => {
+  beforeEach(() => {
+    vi.clearAllMocks()
+  })
+
+  it('creates, disables and resets admin accounts', async () => {
+    vi.mocked(listUsers).mockResolvedValue([owner])
+    vi.mocked(createUser).mockResolvedValue(editor)
+    vi.mocked(setUserStatus).mockResolvedValue({ ...editor, status: 'disabled' })
+    vi.mocked(resetUserPassword).mockResolvedValue()
+
+    const wrapper = mount(UserManager)
+    await flushPromises()
+    expect(wrapper.text()).toContain('admin')
+
+    await wrapper.get('[data-test="new-username"]').setValue('editor')
+    await wrapper.get('[data-test="new-password"]').setValue('editor-pass')
+    await wrapper.get('[data-test="create"]').trigger('click')
+    await flushPromises()
+    expect(createUser).toHaveBeenCalledWith('editor', 'editor-pass')
+
+    await wrapper.get('[data-test="toggle-2"]').trigger('click')
+    await flushPromises()
+    expect(setUserStatus).toHaveBeenCalledWith(2, 'disabled')
+    expect(wrapper.get('[data-test="user-2"]').text()).toContain('停用')
+
+    await wrapper.get('[data-test="reset-input-2"]').setValue('reset-pass')
+    await wrapper.get('[data-test="reset-2"]').trigger('click')
+    await flushPromises()
+    expect(resetUserPassword).toHaveBeenCalledWith(2, 'reset-pass')
+  })
+
+  it('asks to log in again after changing the own password', async () => {
+    vi.mocked(listUsers).mockResolvedValue([owner])
+    vi.mocked(changeOwnPassword).mockResolvedValue()
+
+    const wrapper = mount(UserManager)
+    await flushPromises()
+    await wrapper.get('[data-test="current-password"]').setValue('admin123456')
+    await wrapper.get('[data-test="next-password"]').setValue('next-pass-1')
+    await wrapper.get('[data-test="change-password"]').trigger('click')
+    await flushPromises()
+
+    expect(changeOwnPassword).toHaveBeenCalledWith('admin123456', 'next-pass-1')
+    expect(wrapper.emitted('password-changed')).toHaveLength(1)
+  })
+})
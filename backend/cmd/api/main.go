@@ -8,17 +8,25 @@ import (
 	"github.com/bajiaozhi/w-mma/backend/internal/auth"
 	"github.com/bajiaozhi/w-mma/backend/internal/bootstrap"
 	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/featured"
+	"github.com/bajiaozhi/w-mma/backend/internal/fetch"
 	"github.com/bajiaozhi/w-mma/backend/internal/fighter"
 	apihttp "github.com/bajiaozhi/w-mma/backend/internal/http"
 	"github.com/bajiaozhi/w-mma/backend/internal/ingest"
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+	"github.com/bajiaozhi/w-mma/backend/internal/live"
 	"github.com/bajiaozhi/w-mma/backend/internal/media"
 	"github.com/bajiaozhi/w-mma/backend/internal/queue"
 	"github.com/bajiaozhi/w-mma/backend/internal/repository/cache"
 	mysqlrepo "github.com/bajiaozhi/w-mma/backend/internal/repository/mysql"
 	"github.com/bajiaozhi/w-mma/backend/internal/review"
+	"github.com/bajiaozhi/w-mma/backend/internal/revision"
+	"github.com/bajiaozhi/w-mma/backend/internal/search"
 	"github.com/bajiaozhi/w-mma/backend/internal/source"
 	"github.com/bajiaozhi/w-mma/backend/internal/summary"
 	"github.com/bajiaozhi/w-mma/backend/internal/takedown"
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
+	"github.com/bajiaozhi/w-mma/backend/internal/translate"
 	"github.com/bajiaozhi/w-mma/backend/internal/ufc"
 )
 
@@ -45,6 +53,7 @@ func main() {
 	articleRepo := mysqlrepo.NewArticleRepository(db)
 	articleCache := cache.NewArticleCache(redisClient, 120*time.Second)
 	reviewSvc := review.NewService(articleRepo, articleCache)
+	reviewSvc.SetEntityDetector(linking.NewDetector(mysqlrepo.NewLinkDirectory(db)))
 
 	eventRepo := mysqlrepo.NewEventRepository(db)
 	eventCache := cache.NewEventCache(redisClient)
@@ -56,11 +65,19 @@ func main() {
 	sourceRepo := mysqlrepo.NewSourceRepository(db)
 	sourceSvc := source.NewService(sourceRepo)
 	ufcSyncRepo := mysqlrepo.NewUFCSyncRepository(db)
+	crawlClient := fetch.NewPolicy(fetch.Config{
+		UserAgent:       cfg.CrawlerUserAgent,
+		HostConcurrency: cfg.CrawlerPerHost,
+	}).Client(20 * time.Second)
 	imageMirror := ufc.NewLocalImageMirror(ufc.LocalImageMirrorConfig{
 		StorageDir: cfg.MediaCacheDir,
 		PublicBase: cfg.PublicBaseURL,
+		Client:     crawlClient,
 	})
-	ufcSyncSvc := ufc.NewService(sourceSvc, ufcSyncRepo, ufc.NewHTTPClient(nil), ufc.WithImageMirror(imageMirror))
+	ufcSyncSvc := ufc.NewService(sourceSvc, ufcSyncRepo, ufc.NewHTTPClient(crawlClient),
+		ufc.WithImageMirror(imageMirror),
+		ufc.WithFetchClient(crawlClient),
+	)
 	mediaRepo := mysqlrepo.NewMediaRepository(db)
 	mediaSvc := media.NewService(mediaRepo)
 	summaryRepo := mysqlrepo.NewSummaryJobRepository(db)
@@ -71,30 +88,54 @@ func main() {
 	})
 	takedownRepo := mysqlrepo.NewTakedownRepository(db)
 	takedownSvc := takedown.NewService(takedownRepo, articleRepo, articleCache)
+	revisionSvc := revision.NewService(mysqlrepo.NewArticleRevisionRepository(db), articleCache)
+	translationSvc := translate.NewService(mysqlrepo.NewTranslationJobRepository(db), sourceSvc, translate.Config{
+		Provider: cfg.TranslateProvider,
+		APIBase:  cfg.TranslateAPIBase,
+		APIKey:   cfg.TranslateAPIKey,
+		Model:    cfg.TranslateModel,
+	})
 
-	stream := queue.NewStreamQueue(redisClient, ingest.FetchStreamName, "worker", "api")
+	stream := queue.NewStreamQueue(redisClient, ingest.FetchStreamName, "worker", "api", queue.WithMaxLen(cfg.IngestStreamMax))
 	if err := stream.EnsureGroup(context.Background()); err != nil {
 		log.Fatal(err)
 	}
 	publisher := ingest.NewStreamPublisher(stream)
-	authRepo := auth.NewStaticUserRepository(cfg.AdminUsername, cfg.AdminPasswordHash)
+	authRepo := mysqlrepo.NewAdminUserRepository(db)
+	created, err := auth.BootstrapUser(context.Background(), authRepo, cfg.AdminUsername, cfg.AdminPasswordHash)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if created {
+		log.Printf("created initial admin user %q", cfg.AdminUsername)
+	}
 	authSvc := auth.NewService(authRepo, cfg.AdminJWTSecret)
 
 	srv := apihttp.NewServerWithDependencies(apihttp.Dependencies{
-		ReviewService:   reviewSvc,
-		PendingCreator:  articleRepo,
-		PublishedRepo:   articleRepo,
-		EventService:    eventSvc,
-		FighterService:  fighterSvc,
-		IngestPublisher: publisher,
-		AuthService:     authSvc,
-		SourceService:   sourceSvc,
-		MediaService:    mediaSvc,
-		SummaryService:  summarySvc,
-		TakedownService: takedownSvc,
-		UFCSyncService:  ufcSyncSvc,
-		AdminJWTSecret:  cfg.AdminJWTSecret,
-		MediaCacheDir:   cfg.MediaCacheDir,
+		ReviewService:      reviewSvc,
+		PendingCreator:     articleRepo,
+		PublishedRepo:      articleRepo,
+		EntityArticles:     articleRepo,
+		FeedCache:          articleCache,
+		TagService:         taxonomy.NewService(mysqlrepo.NewTagRepository(db)),
+		SearchService:      search.NewService(mysqlrepo.NewSearchRepository(db)),
+		FeaturedService:    featured.NewService(mysqlrepo.NewFeaturedSlotRepository(db), articleRepo, eventSvc, cache.NewHomeCache(redisClient)),
+		EventService:       eventSvc,
+		FighterService:     fighterSvc,
+		IngestPublisher:    publisher,
+		DeadLetters:        ingest.NewStreamDeadLetters(stream),
+		AuthService:        authSvc,
+		SourceService:      sourceSvc,
+		MediaService:       mediaSvc,
+		SummaryService:     summarySvc,
+		TakedownService:    takedownSvc,
+		TranslationService: translationSvc,
+		RevisionService:    revisionSvc,
+		UFCSyncService:     ufcSyncSvc,
+		LiveControl:        cache.NewLiveControlStore(redisClient),
+		LiveScoring:        live.NewManualScoring(eventRepo, eventCache),
+		AdminJWTSecret:     cfg.AdminJWTSecret,
+		MediaCacheDir:      cfg.MediaCacheDir,
 	})
 
 	if err := srv.Run(":8080"); err != nil {
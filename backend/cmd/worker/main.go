@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"os/signal"
@@ -9,39 +10,99 @@ import (
 	"time"
 
 	"github.com/bajiaozhi/w-mma/backend/internal/bootstrap"
+	"github.com/bajiaozhi/w-mma/backend/internal/fetch"
 	"github.com/bajiaozhi/w-mma/backend/internal/ingest"
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
 	"github.com/bajiaozhi/w-mma/backend/internal/live"
 	"github.com/bajiaozhi/w-mma/backend/internal/queue"
 	"github.com/bajiaozhi/w-mma/backend/internal/repository/cache"
 	mysqlrepo "github.com/bajiaozhi/w-mma/backend/internal/repository/mysql"
 	"github.com/bajiaozhi/w-mma/backend/internal/review"
 	"github.com/bajiaozhi/w-mma/backend/internal/source"
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
+	"github.com/bajiaozhi/w-mma/backend/internal/translate"
 	"github.com/bajiaozhi/w-mma/backend/internal/ufc"
 )
 
 type reviewPendingCreator interface {
 	CreatePending(ctx context.Context, item review.PendingArticle) (review.PendingArticle, error)
+	HasSeen(ctx context.Context, sourceID int64, guid string, link string) (bool, error)
+	FindNearDuplicate(ctx context.Context, simhash uint64, maxDistance int) (int64, bool, error)
+	WasQueued(ctx context.Context, sourceID int64, link string, since time.Time) (bool, error)
+	MarkQueued(ctx context.Context, sourceID int64, link string, at time.Time) error
+	PruneQueued(ctx context.Context, sourceID int64, before time.Time) error
 }
 
 type reviewPendingAdapter struct {
-	repo reviewPendingCreator
+	repo         reviewPendingCreator
+	translations *translate.Service
 }
 
 func (a *reviewPendingAdapter) SavePending(ctx context.Context, rec ingest.PendingRecord) error {
-	_, err := a.repo.CreatePending(ctx, review.PendingArticle{
-		Title:     rec.Title,
-		Summary:   rec.Summary,
-		SourceURL: rec.SourceURL,
+	item, err := a.repo.CreatePending(ctx, review.PendingArticle{
+		SourceID:    rec.SourceID,
+		GUID:        rec.GUID,
+		Title:       rec.Title,
+		Summary:     rec.Summary,
+		Content:     rec.Body,
+		Author:      rec.Author,
+		SourceURL:   rec.SourceURL,
+		CoverURL:    rec.CoverURL,
+		PublishedAt: rec.PublishedAt,
+		SimHash:     rec.SimHash,
+		DuplicateOf: rec.DuplicateOf,
+		Entities:    rec.Entities,
+		Tags:        rec.Tags,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	a.enqueueTranslation(ctx, item)
+	return nil
+}
+
+// enqueueTranslation asks for a Chinese draft of foreign-language articles. Failing to queue
+// the job must not lose the article, so errors are only logged.
+func (a *reviewPendingAdapter) enqueueTranslation(ctx context.Context, item review.PendingArticle) {
+	if a.translations == nil || !translate.NeedsTranslation(item.Title, item.Content) {
+		return
+	}
+	_, err := a.translations.Enqueue(ctx, translate.Article{
+		PendingID: item.ID,
+		SourceID:  item.SourceID,
+		Text:      translate.Text{Title: item.Title, Body: item.Content},
+	})
+	if err != nil && !errors.Is(err, translate.ErrNotAllowed) {
+		log.Printf("enqueue translation for pending %d: %v", item.ID, err)
+	}
+}
+
+func (a *reviewPendingAdapter) FindNearDuplicate(ctx context.Context, simhash uint64, maxDistance int) (int64, bool, error) {
+	return a.repo.FindNearDuplicate(ctx, simhash, maxDistance)
+}
+
+func (a *reviewPendingAdapter) HasSeen(ctx context.Context, sourceID int64, guid string, link string) (bool, error) {
+	return a.repo.HasSeen(ctx, sourceID, guid, link)
+}
+
+func (a *reviewPendingAdapter) WasQueued(ctx context.Context, sourceID int64, link string, since time.Time) (bool, error) {
+	return a.repo.WasQueued(ctx, sourceID, link, since)
+}
+
+func (a *reviewPendingAdapter) MarkQueued(ctx context.Context, sourceID int64, link string, at time.Time) error {
+	return a.repo.MarkQueued(ctx, sourceID, link, at)
+}
+
+func (a *reviewPendingAdapter) PruneQueued(ctx context.Context, sourceID int64, before time.Time) error {
+	return a.repo.PruneQueued(ctx, sourceID, before)
 }
 
 type ufcLiveRepoAdapter struct {
 	repo *mysqlrepo.EventRepository
 }
 
-func (a *ufcLiveRepoAdapter) ListTrackableEvents(ctx context.Context) ([]live.UFCTrackableEvent, error) {
-	rows, err := a.repo.ListUFCLiveTrackableEvents(ctx)
+func (a *ufcLiveRepoAdapter) ListTrackableEvents(ctx context.Context, orgs []string) ([]live.UFCTrackableEvent, error) {
+	rows, err := a.repo.ListLiveTrackableEvents(ctx, orgs)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +110,7 @@ func (a *ufcLiveRepoAdapter) ListTrackableEvents(ctx context.Context) ([]live.UF
 	for _, row := range rows {
 		items = append(items, live.UFCTrackableEvent{
 			ID:          row.ID,
+			Org:         row.Org,
 			Status:      row.Status,
 			StartsAt:    row.StartsAt,
 			ExternalURL: row.ExternalURL,
@@ -74,6 +136,9 @@ func (a *ufcLiveRepoAdapter) ListBoutSnapshots(ctx context.Context, eventID int6
 			Round:         row.Round,
 			TimeSec:       row.TimeSec,
 			Result:        row.Result,
+			LiveState:     row.LiveState,
+			CurrentRound:  row.CurrentRound,
+			ResultLocked:  row.ResultLocked,
 		})
 	}
 	return items, nil
@@ -87,6 +152,10 @@ func (a *ufcLiveRepoAdapter) UpsertBoutResult(ctx context.Context, eventID int64
 	return a.repo.UpsertUFCLiveBoutResult(ctx, eventID, boutID, winnerID, method, round, timeSec, result)
 }
 
+func (a *ufcLiveRepoAdapter) UpdateBoutLiveState(ctx context.Context, eventID int64, boutID int64, state string, currentRound int) error {
+	return a.repo.UpdateBoutLiveState(ctx, eventID, boutID, state, currentRound)
+}
+
 func main() {
 	cfg, err := bootstrap.LoadConfigFromEnv()
 	if err != nil {
@@ -110,43 +179,70 @@ func main() {
 	articleRepo := mysqlrepo.NewArticleRepository(db)
 	eventRepo := mysqlrepo.NewEventRepository(db)
 	eventCache := cache.NewEventCache(redisClient)
+	articleCache := cache.NewArticleCache(redisClient, 120*time.Second)
 	sourceRepo := mysqlrepo.NewSourceRepository(db)
 	sourceSvc := source.NewService(sourceRepo)
 	ufcSyncRepo := mysqlrepo.NewUFCSyncRepository(db)
+	crawlClient := fetch.NewPolicy(fetch.Config{
+		UserAgent:       cfg.CrawlerUserAgent,
+		HostConcurrency: cfg.CrawlerPerHost,
+	}).Client(20 * time.Second)
 	imageMirror := ufc.NewLocalImageMirror(ufc.LocalImageMirrorConfig{
 		StorageDir: cfg.MediaCacheDir,
 		PublicBase: cfg.PublicBaseURL,
+		Client:     crawlClient,
 	})
-	ufcSyncSvc := ufc.NewService(sourceSvc, ufcSyncRepo, ufc.NewHTTPClient(nil), ufc.WithImageMirror(imageMirror))
-	worker := ingest.NewQueuelessWorker(&reviewPendingAdapter{repo: articleRepo}, ingest.NewDefaultParserRegistry(nil))
-
-	stream := queue.NewStreamQueue(redisClient, ingest.FetchStreamName, "worker", "worker-1")
+	ufcSyncSvc := ufc.NewService(sourceSvc, ufcSyncRepo, ufc.NewHTTPClient(crawlClient),
+		ufc.WithImageMirror(imageMirror),
+		ufc.WithFetchClient(crawlClient),
+	)
+	stream := queue.NewStreamQueue(redisClient, ingest.FetchStreamName, "worker", "worker",
+		queue.WithBatchSize(cfg.IngestBatchSize),
+		queue.WithMaxLen(cfg.IngestStreamMax),
+	)
 	if err := stream.EnsureGroup(context.Background()); err != nil {
 		log.Fatal(err)
 	}
-	consumer := ingest.NewStreamConsumer(stream)
+	fetchPublisher := ingest.NewStreamPublisher(stream)
+	runRepo := mysqlrepo.NewIngestRunRepository(db)
+	translateConfig := translate.Config{
+		Provider: cfg.TranslateProvider,
+		APIBase:  cfg.TranslateAPIBase,
+		APIKey:   cfg.TranslateAPIKey,
+		Model:    cfg.TranslateModel,
+	}
+	translationRepo := mysqlrepo.NewTranslationJobRepository(db)
+	translationSvc := translate.NewService(translationRepo, sourceSvc, translateConfig)
+	worker := ingest.NewQueuelessWorker(
+		&reviewPendingAdapter{repo: articleRepo, translations: translationSvc},
+		ingest.NewDefaultParserRegistry(crawlClient),
+		ingest.WithFetchPublisher(fetchPublisher),
+		ingest.WithSourceReader(sourceSvc),
+		ingest.WithRunRecorder(runRepo),
+		ingest.WithEntityDetector(linking.NewDetector(mysqlrepo.NewLinkDirectory(db))),
+		ingest.WithTagSuggester(taxonomy.NewSuggester(mysqlrepo.NewTagRepository(db))),
+	)
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "worker"
+	}
+	pool := ingest.NewConsumerPool(stream, cfg.IngestConsumers, hostname)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 	go ufc.StartScheduler(ctx, ufcSyncSvc, 12*time.Hour)
+	go ingest.StartScheduler(ctx, ingest.NewScheduler(runRepo, fetchPublisher), ingest.DefaultScheduleTick)
+	go review.StartScheduledPublisher(ctx, review.NewService(articleRepo, articleCache), review.DefaultReleaseTick)
+	go translate.StartWorker(ctx, translate.NewWorker(translationRepo, translate.NewTranslator(translateConfig, nil)), translate.DefaultWorkerTick)
 	ufcLiveMonitor := live.NewUFCLiveMonitor(
 		&ufcLiveRepoAdapter{repo: eventRepo},
-		ufc.NewHTTPClient(nil),
+		live.NewDefaultProviderRegistry(ufc.NewHTTPClient(crawlClient)),
 		eventCache,
-		live.UFCLiveMonitorConfig{},
+		live.UFCLiveMonitorConfig{Control: cache.NewLiveControlStore(redisClient)},
 	)
 	go ufcLiveMonitor.Run(ctx)
 
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			if err := consumer.ConsumeOnce(ctx, func(job ingest.FetchJob) error {
-				return worker.HandleJob(ctx, job)
-			}); err != nil {
-				log.Printf("consume ingest stream failed: %v", err)
-			}
-		}
-	}
+	log.Printf("ingest consumers started: %v", pool.ConsumerNames())
+	pool.Run(ctx, worker.HandleJob)
+	log.Printf("ingest consumers drained")
 }
@@ -3,6 +3,7 @@ package auth
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -38,4 +39,119 @@ func RegisterAdminAuthRoutes(r *gin.Engine, svc *Service) {
 	r.POST("/admin/auth/logout", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"ok": true})
 	})
+
+	r.PUT("/admin/auth/password", func(c *gin.Context) {
+		var req struct {
+			CurrentPassword string `json:"current_password"`
+			NewPassword     string `json:"new_password"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		err := svc.ChangePassword(c.Request.Context(), c.GetInt64("admin_user_id"), req.CurrentPassword, req.NewPassword)
+		if errors.Is(err, ErrInvalidCredentials) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "current password is wrong"})
+			return
+		}
+		if err != nil {
+			writeUserError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+}
+
+// RegisterAdminUserRoutes lets admins manage each other's accounts.
+func RegisterAdminUserRoutes(r *gin.Engine, svc *Service) {
+	r.GET("/admin/users", func(c *gin.Context) {
+		items, err := svc.ListUsers(c.Request.Context())
+		if err != nil {
+			writeUserError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": items})
+	})
+
+	r.POST("/admin/users", func(c *gin.Context) {
+		var req loginRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, err := svc.CreateUser(c.Request.Context(), req.Username, req.Password)
+		if err != nil {
+			writeUserError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, user)
+	})
+
+	r.PUT("/admin/users/:id/status", func(c *gin.Context) {
+		userID, ok := userIDParam(c)
+		if !ok {
+			return
+		}
+		var req struct {
+			Status string `json:"status"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		user, err := svc.SetUserStatus(c.Request.Context(), c.GetInt64("admin_user_id"), userID, req.Status)
+		if err != nil {
+			writeUserError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, user)
+	})
+
+	r.PUT("/admin/users/:id/password", func(c *gin.Context) {
+		userID, ok := userIDParam(c)
+		if !ok {
+			return
+		}
+		var req struct {
+			Password string `json:"password"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := svc.ResetPassword(c.Request.Context(), userID, req.Password); err != nil {
+			writeUserError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+}
+
+func userIDParam(c *gin.Context) (int64, bool) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || userID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return 0, false
+	}
+	return userID, true
+}
+
+func writeUserError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidUsername), errors.Is(err, ErrInvalidPassword),
+		errors.Is(err, ErrInvalidStatus), errors.Is(err, ErrDisableSelf):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrDuplicateUser):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrUsersNotManaged):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
 }
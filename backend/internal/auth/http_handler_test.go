@@ -2,10 +2,12 @@ package auth
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
@@ -64,3 +66,106 @@ func TestLogin_ReturnsJWTAndMiddlewareProtectsAdminRoute(t *testing.T) {
 		t.Fatalf("expected 200 with token, got %d", okResp.Code)
 	}
 }
+
+func TestAdminUserRoutes_ManageUsersAndRevokeSessions(t *testing.T) {
+	repo := NewInMemoryUserRepository()
+	hash, err := bcrypt.GenerateFromPassword([]byte("owner-pass"), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatalf("generate hash: %v", err)
+	}
+	if _, err := BootstrapUser(context.Background(), repo, "owner", string(hash)); err != nil {
+		t.Fatalf("bootstrap failed: %v", err)
+	}
+	svc := NewService(repo, "test-secret")
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	guard := RequireAdminAuth("test-secret", svc)
+	r.Use(func(c *gin.Context) {
+		if c.Request.URL.Path == "/admin/auth/login" {
+			c.Next()
+			return
+		}
+		guard(c)
+	})
+	RegisterAdminAuthRoutes(r, svc)
+	RegisterAdminUserRoutes(r, svc)
+	r.GET("/admin/protected", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	send := func(method string, url string, token string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+	login := func(username string, password string) string {
+		w := send(http.MethodPost, "/admin/auth/login", "", `{"username":"`+username+`","password":"`+password+`"}`)
+		var payload struct {
+			Token string `json:"token"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &payload)
+		if payload.Token == "" {
+			t.Fatalf("login %s failed: %d %s", username, w.Code, w.Body.String())
+		}
+		return payload.Token
+	}
+
+	ownerToken := login("owner", "owner-pass")
+	if w := send(http.MethodPost, "/admin/users", ownerToken, `{"username":"editor","password":"editor-pass"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/admin/users", ownerToken, `{"username":"editor","password":"editor-pass"}`); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a duplicate, got %d", w.Code)
+	}
+	if w := send(http.MethodPut, "/admin/users/1/status", ownerToken, `{"status":"disabled"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for disabling yourself, got %d", w.Code)
+	}
+
+	editorToken := login("editor", "editor-pass")
+	if w := send(http.MethodGet, "/admin/protected", editorToken, ""); w.Code != http.StatusOK {
+		t.Fatalf("expected the editor let in, got %d", w.Code)
+	}
+	if w := send(http.MethodPut, "/admin/users/2/status", ownerToken, `{"status":"disabled"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodGet, "/admin/protected", editorToken, ""); w.Code != http.StatusForbidden {
+		t.Fatalf("expected the disabled editor's token refused, got %d", w.Code)
+	}
+
+	if w := send(http.MethodPut, "/admin/users/2/password", ownerToken, `{"password":"x"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a short password, got %d", w.Code)
+	}
+	if w := send(http.MethodPut, "/admin/users/9/password", ownerToken, `{"password":"long-enough"}`); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+
+	if w := send(http.MethodPut, "/admin/auth/password", ownerToken, `{"current_password":"wrong-pass","new_password":"next-pass-1"}`); w.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a wrong current password, got %d", w.Code)
+	}
+	// Tokens carry whole seconds, so let the current one age before the change.
+	time.Sleep(time.Second)
+	if w := send(http.MethodPut, "/admin/auth/password", ownerToken, `{"current_password":"owner-pass","new_password":"next-pass-1"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodGet, "/admin/protected", ownerToken, ""); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected the token issued before the change revoked, got %d", w.Code)
+	}
+	ownerToken = login("owner", "next-pass-1")
+
+	w := send(http.MethodGet, "/admin/users", ownerToken, "")
+	var list struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list.Items) != 2 {
+		t.Fatalf("expected two users, got %s", w.Body.String())
+	}
+	if _, leaked := list.Items[0]["password_hash"]; leaked || list.Items[1]["status"] != StatusDisabled {
+		t.Fatalf("unexpected user list %+v", list.Items)
+	}
+}
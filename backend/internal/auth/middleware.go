@@ -1,10 +1,12 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -15,8 +17,19 @@ type AccessClaims struct {
 	jwt.RegisteredClaims
 }
 
-func RequireAdminAuth(jwtSecret string) gin.HandlerFunc {
+// SessionChecker decides whether a validly signed token's user may still act.
+type SessionChecker interface {
+	CheckSession(ctx context.Context, userID int64, issuedAt time.Time) error
+}
+
+// RequireAdminAuth accepts requests with a valid admin token. With a session checker, tokens
+// of disabled users and tokens issued before a password change are refused as well.
+func RequireAdminAuth(jwtSecret string, sessions ...SessionChecker) gin.HandlerFunc {
 	secret := []byte(jwtSecret)
+	var checker SessionChecker
+	if len(sessions) > 0 {
+		checker = sessions[0]
+	}
 
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -59,6 +72,25 @@ func RequireAdminAuth(jwtSecret string) gin.HandlerFunc {
 			return
 		}
 
+		if checker != nil {
+			var issuedAt time.Time
+			if claims.IssuedAt != nil {
+				issuedAt = claims.IssuedAt.Time
+			}
+			if err := checker.CheckSession(c.Request.Context(), userID, issuedAt); err != nil {
+				switch {
+				case errors.Is(err, ErrUserDisabled):
+					c.JSON(http.StatusForbidden, gin.H{"error": "user disabled"})
+				case errors.Is(err, ErrSessionRevoked):
+					c.JSON(http.StatusUnauthorized, gin.H{"error": "session revoked"})
+				default:
+					c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				}
+				c.Abort()
+				return
+			}
+		}
+
 		c.Set("admin_user_id", userID)
 		c.Set("admin_username", claims.Username)
 		c.Next()
@@ -13,13 +13,24 @@ import (
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrUserDisabled       = errors.New("user disabled")
+	// ErrSessionRevoked rejects tokens issued before the user's password last changed.
+	ErrSessionRevoked = errors.New("session revoked")
+)
+
+const (
+	StatusActive   = "active"
+	StatusDisabled = "disabled"
 )
 
 type AdminUser struct {
-	ID           int64
-	Username     string
-	PasswordHash string
-	Status       string
+	ID           int64  `json:"id"`
+	Username     string `json:"username"`
+	PasswordHash string `json:"-"`
+	Status       string `json:"status"`
+	// PasswordChangedAt is when the password was last set; tokens issued earlier are refused.
+	PasswordChangedAt *time.Time `json:"password_changed_at,omitempty"`
+	LastLoginAt       *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
 }
 
 type UserRepository interface {
@@ -48,13 +59,13 @@ func (s *Service) Login(ctx context.Context, username string, password string) (
 	if err != nil {
 		return "", ErrInvalidCredentials
 	}
-	if user.Status != "" && user.Status != "active" {
-		return "", ErrUserDisabled
-	}
-
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
 		return "", ErrInvalidCredentials
 	}
+	// Only someone holding the password learns the account is disabled.
+	if user.Status != "" && user.Status != StatusActive {
+		return "", ErrUserDisabled
+	}
 
 	now := s.now()
 	claims := AccessClaims{
@@ -76,6 +87,30 @@ func (s *Service) Login(ctx context.Context, username string, password string) (
 	return tokenString, nil
 }
 
+// CheckSession confirms a token's user may still act: it exists, is active, and has not
+// changed password since the token was issued. Repositories that only look users up by name
+// cannot tell, so every session passes.
+func (s *Service) CheckSession(ctx context.Context, userID int64, issuedAt time.Time) error {
+	store, ok := s.repo.(UserStore)
+	if !ok {
+		return nil
+	}
+	user, err := store.Get(ctx, userID)
+	if errors.Is(err, ErrUserNotFound) {
+		return ErrSessionRevoked
+	}
+	if err != nil {
+		return err
+	}
+	if user.Status != StatusActive {
+		return ErrUserDisabled
+	}
+	if user.PasswordChangedAt != nil && issuedAt.Before(*user.PasswordChangedAt) {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
 type StaticUserRepository struct {
 	user AdminUser
 }
@@ -86,7 +121,7 @@ func NewStaticUserRepository(username string, passwordHash string) *StaticUserRe
 			ID:           1,
 			Username:     username,
 			PasswordHash: passwordHash,
-			Status:       "active",
+			Status:       StatusActive,
 		},
 	}
 }
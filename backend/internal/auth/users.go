@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const minPasswordRunes = 8
+
+var (
+	ErrUserNotFound    = errors.New("admin user not found")
+	ErrDuplicateUser   = errors.New("admin username already exists")
+	ErrInvalidUsername = errors.New("username must be 3-64 letters, digits, dots, dashes or underscores")
+	ErrInvalidPassword = errors.New("password must be at least 8 characters")
+	ErrInvalidStatus   = errors.New("status must be active or disabled")
+	// ErrDisableSelf keeps an admin from locking themselves, and possibly everyone, out.
+	ErrDisableSelf = errors.New("cannot disable your own account")
+	// ErrUsersNotManaged is returned when the repository only serves a fixed account.
+	ErrUsersNotManaged = errors.New("admin users are not managed by this repository")
+)
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{3,64}$`)
+
+// UserStore is a UserRepository admins can manage from the console.
+type UserStore interface {
+	UserRepository
+	// List returns every user ordered by id.
+	List(ctx context.Context) ([]AdminUser, error)
+	// Get returns one user, or ErrUserNotFound.
+	Get(ctx context.Context, userID int64) (AdminUser, error)
+	// Create stores a user, or returns ErrDuplicateUser.
+	Create(ctx context.Context, user AdminUser) (AdminUser, error)
+	// SetStatus changes a user's status, or returns ErrUserNotFound.
+	SetStatus(ctx context.Context, userID int64, status string) error
+	// SetPassword replaces a user's password hash, or returns ErrUserNotFound.
+	SetPassword(ctx context.Context, userID int64, passwordHash string, changedAt time.Time) error
+	Count(ctx context.Context) (int64, error)
+}
+
+// BootstrapUser creates the first admin from the configured account when the store has no
+// users yet, and reports whether it did. Later changes to the configured account are ignored.
+func BootstrapUser(ctx context.Context, store UserStore, username string, passwordHash string) (bool, error) {
+	username = strings.TrimSpace(username)
+	if username == "" || passwordHash == "" {
+		return false, nil
+	}
+	count, err := store.Count(ctx)
+	if err != nil || count > 0 {
+		return false, err
+	}
+	_, err = store.Create(ctx, AdminUser{Username: username, PasswordHash: passwordHash, Status: StatusActive})
+	if errors.Is(err, ErrDuplicateUser) {
+		// Another instance bootstrapped the same account first.
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *Service) users() (UserStore, error) {
+	store, ok := s.repo.(UserStore)
+	if !ok {
+		return nil, ErrUsersNotManaged
+	}
+	return store, nil
+}
+
+func (s *Service) ListUsers(ctx context.Context) ([]AdminUser, error) {
+	store, err := s.users()
+	if err != nil {
+		return nil, err
+	}
+	return store.List(ctx)
+}
+
+func (s *Service) CreateUser(ctx context.Context, username string, password string) (AdminUser, error) {
+	store, err := s.users()
+	if err != nil {
+		return AdminUser{}, err
+	}
+	username = strings.TrimSpace(username)
+	if !usernamePattern.MatchString(username) {
+		return AdminUser{}, ErrInvalidUsername
+	}
+	hash, err := hashPassword(password)
+	if err != nil {
+		return AdminUser{}, err
+	}
+	return store.Create(ctx, AdminUser{Username: username, PasswordHash: hash, Status: StatusActive})
+}
+
+// SetUserStatus enables or disables an account. Disabled users cannot log in, and their
+// existing tokens stop working on the next request.
+func (s *Service) SetUserStatus(ctx context.Context, actorID int64, userID int64, status string) (AdminUser, error) {
+	store, err := s.users()
+	if err != nil {
+		return AdminUser{}, err
+	}
+	if status != StatusActive && status != StatusDisabled {
+		return AdminUser{}, ErrInvalidStatus
+	}
+	if status == StatusDisabled && actorID == userID {
+		return AdminUser{}, ErrDisableSelf
+	}
+	if err := store.SetStatus(ctx, userID, status); err != nil {
+		return AdminUser{}, err
+	}
+	return store.Get(ctx, userID)
+}
+
+// ChangePassword sets a user's own password after checking the current one. Other sessions
+// of the user end; the caller logs in again.
+func (s *Service) ChangePassword(ctx context.Context, userID int64, current string, next string) error {
+	store, err := s.users()
+	if err != nil {
+		return err
+	}
+	user, err := store.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(current)); err != nil {
+		return ErrInvalidCredentials
+	}
+	return s.setPassword(ctx, store, userID, next)
+}
+
+// ResetPassword lets an admin set someone else's password, ending that user's sessions.
+func (s *Service) ResetPassword(ctx context.Context, userID int64, password string) error {
+	store, err := s.users()
+	if err != nil {
+		return err
+	}
+	return s.setPassword(ctx, store, userID, password)
+}
+
+func (s *Service) setPassword(ctx context.Context, store UserStore, userID int64, password string) error {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	// Tokens carry whole seconds, so the change time is truncated to keep a token issued
+	// right after the change valid.
+	return store.SetPassword(ctx, userID, hash, s.now().UTC().Truncate(time.Second))
+}
+
+func hashPassword(password string) (string, error) {
+	if utf8.RuneCountInString(password) < minPasswordRunes {
+		return "", ErrInvalidPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+type InMemoryUserRepository struct {
+	mu     sync.Mutex
+	users  map[int64]AdminUser
+	nextID int64
+	now    func() time.Time
+}
+
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{users: map[int64]AdminUser{}, nextID: 1, now: time.Now}
+}
+
+func (r *InMemoryUserRepository) FindByUsername(_ context.Context, username string) (AdminUser, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return AdminUser{}, ErrUserNotFound
+}
+
+func (r *InMemoryUserRepository) TouchLastLogin(_ context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	now := r.now().UTC()
+	user.LastLoginAt = &now
+	r.users[userID] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) List(context.Context) ([]AdminUser, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := make([]AdminUser, 0, len(r.users))
+	for _, user := range r.users {
+		items = append(items, user)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items, nil
+}
+
+func (r *InMemoryUserRepository) Get(_ context.Context, userID int64) (AdminUser, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok {
+		return AdminUser{}, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *InMemoryUserRepository) Create(_ context.Context, user AdminUser) (AdminUser, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if strings.EqualFold(existing.Username, user.Username) {
+			return AdminUser{}, ErrDuplicateUser
+		}
+	}
+	user.ID = r.nextID
+	user.CreatedAt = r.now().UTC()
+	r.nextID++
+	r.users[user.ID] = user
+	return user, nil
+}
+
+func (r *InMemoryUserRepository) SetStatus(_ context.Context, userID int64, status string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	user.Status = status
+	r.users[userID] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) SetPassword(_ context.Context, userID int64, passwordHash string, changedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[userID]
+	if !ok {
+		return ErrUserNotFound
+	}
+	user.PasswordHash = passwordHash
+	user.PasswordChangedAt = &changedAt
+	r.users[userID] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) Count(context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return int64(len(r.users)), nil
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newUserFixture(t *testing.T) (*Service, *InMemoryUserRepository, AdminUser) {
+	t.Helper()
+	repo := NewInMemoryUserRepository()
+	svc := NewService(repo, "test-secret")
+	owner, err := svc.CreateUser(context.Background(), "owner", "owner-pass")
+	if err != nil {
+		t.Fatalf("create owner failed: %v", err)
+	}
+	return svc, repo, owner
+}
+
+func TestBootstrapUser_OnlyWhenStoreIsEmpty(t *testing.T) {
+	ctx := context.Background()
+	repo := NewInMemoryUserRepository()
+
+	created, err := BootstrapUser(ctx, repo, "admin", "hash-1")
+	if err != nil || !created {
+		t.Fatalf("expected the first admin created, got %v %v", created, err)
+	}
+	created, err = BootstrapUser(ctx, repo, "root", "hash-2")
+	if err != nil || created {
+		t.Fatalf("expected bootstrap skipped once users exist, got %v %v", created, err)
+	}
+	if count, _ := repo.Count(ctx); count != 1 {
+		t.Fatalf("expected one user, got %d", count)
+	}
+}
+
+func TestService_CreateAndDisableUsers(t *testing.T) {
+	svc, _, owner := newUserFixture(t)
+	ctx := context.Background()
+
+	if _, err := svc.CreateUser(ctx, "a b", "long-enough"); !errors.Is(err, ErrInvalidUsername) {
+		t.Fatalf("expected invalid username, got %v", err)
+	}
+	if _, err := svc.CreateUser(ctx, "editor", "short"); !errors.Is(err, ErrInvalidPassword) {
+		t.Fatalf("expected invalid password, got %v", err)
+	}
+	if _, err := svc.CreateUser(ctx, "Owner", "long-enough"); !errors.Is(err, ErrDuplicateUser) {
+		t.Fatalf("expected duplicate username, got %v", err)
+	}
+	editor, err := svc.CreateUser(ctx, "editor", "editor-pass")
+	if err != nil {
+		t.Fatalf("create editor failed: %v", err)
+	}
+
+	if _, err := svc.SetUserStatus(ctx, owner.ID, owner.ID, StatusDisabled); !errors.Is(err, ErrDisableSelf) {
+		t.Fatalf("expected self-disable rejected, got %v", err)
+	}
+	if _, err := svc.SetUserStatus(ctx, owner.ID, editor.ID, "banned"); !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("expected invalid status, got %v", err)
+	}
+	if _, err := svc.SetUserStatus(ctx, owner.ID, 99, StatusDisabled); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected missing user, got %v", err)
+	}
+	disabled, err := svc.SetUserStatus(ctx, owner.ID, editor.ID, StatusDisabled)
+	if err != nil || disabled.Status != StatusDisabled {
+		t.Fatalf("expected editor disabled, got %+v %v", disabled, err)
+	}
+	if _, err := svc.Login(ctx, "editor", "editor-pass"); !errors.Is(err, ErrUserDisabled) {
+		t.Fatalf("expected disabled login refused, got %v", err)
+	}
+	if _, err := svc.Login(ctx, "editor", "wrong-pass"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected wrong password on a disabled account to look like any other, got %v", err)
+	}
+	if err := svc.CheckSession(ctx, editor.ID, time.Now()); !errors.Is(err, ErrUserDisabled) {
+		t.Fatalf("expected disabled session refused, got %v", err)
+	}
+
+	users, err := svc.ListUsers(ctx)
+	if err != nil || len(users) != 2 || users[0].Username != "owner" || users[1].Username != "editor" {
+		t.Fatalf("expected users by id, got %+v %v", users, err)
+	}
+}
+
+func TestService_PasswordChangesRevokeEarlierSessions(t *testing.T) {
+	svc, _, owner := newUserFixture(t)
+	ctx := context.Background()
+	loginAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return loginAt }
+
+	if _, err := svc.Login(ctx, "owner", "owner-pass"); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if err := svc.CheckSession(ctx, owner.ID, loginAt); err != nil {
+		t.Fatalf("expected fresh session accepted, got %v", err)
+	}
+
+	svc.now = func() time.Time { return loginAt.Add(time.Minute) }
+	if err := svc.ChangePassword(ctx, owner.ID, "wrong-pass", "next-pass-1"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected wrong current password rejected, got %v", err)
+	}
+	if err := svc.ChangePassword(ctx, owner.ID, "owner-pass", "next-pass-1"); err != nil {
+		t.Fatalf("change password failed: %v", err)
+	}
+	if err := svc.CheckSession(ctx, owner.ID, loginAt); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("expected the earlier session revoked, got %v", err)
+	}
+	if err := svc.CheckSession(ctx, owner.ID, loginAt.Add(time.Minute)); err != nil {
+		t.Fatalf("expected a session issued after the change accepted, got %v", err)
+	}
+	if _, err := svc.Login(ctx, "owner", "owner-pass"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("expected the old password refused, got %v", err)
+	}
+
+	if err := svc.ResetPassword(ctx, owner.ID, "reset-pass-2"); err != nil {
+		t.Fatalf("reset password failed: %v", err)
+	}
+	if _, err := svc.Login(ctx, "owner", "reset-pass-2"); err != nil {
+		t.Fatalf("expected login with the reset password, got %v", err)
+	}
+	if err := svc.ResetPassword(ctx, 99, "reset-pass-2"); !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("expected missing user, got %v", err)
+	}
+	if err := svc.CheckSession(ctx, 99, loginAt); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("expected a deleted user's session revoked, got %v", err)
+	}
+}
+
+func TestService_StaticRepositoryDoesNotManageUsers(t *testing.T) {
+	svc := NewService(NewStaticUserRepository("admin", "hash"), "test-secret")
+	ctx := context.Background()
+
+	if _, err := svc.ListUsers(ctx); !errors.Is(err, ErrUsersNotManaged) {
+		t.Fatalf("expected users not managed, got %v", err)
+	}
+	if err := svc.CheckSession(ctx, 1, time.Now()); err != nil {
+		t.Fatalf("expected static sessions accepted, got %v", err)
+	}
+}
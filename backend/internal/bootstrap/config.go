@@ -20,6 +20,15 @@ type Config struct {
 	SummaryProvider   string
 	SummaryAPIBase    string
 	SummaryAPIKey     string
+	TranslateProvider string
+	TranslateAPIBase  string
+	TranslateAPIKey   string
+	TranslateModel    string
+	IngestConsumers   int
+	IngestBatchSize   int
+	IngestStreamMax   int64
+	CrawlerUserAgent  string
+	CrawlerPerHost    int
 }
 
 const (
@@ -30,6 +39,14 @@ const (
 	defaultMediaCacheDir     = ".worktrees/media-cache"
 	defaultSummaryProvider   = "openai"
 	defaultSummaryAPIBase    = "https://api.openai.com/v1"
+	defaultTranslateProvider = "openai"
+	defaultTranslateAPIBase  = "https://api.openai.com/v1"
+	defaultTranslateModel    = "gpt-4o-mini"
+	defaultIngestConsumers   = 4
+	defaultIngestBatchSize   = 10
+	defaultIngestStreamMax   = 100000
+	defaultCrawlerUserAgent  = "Mozilla/5.0 (compatible; w-mma-bot/1.0)"
+	defaultCrawlerPerHost    = 2
 )
 
 func LoadConfigFromEnv() (Config, error) {
@@ -53,6 +70,24 @@ func LoadConfigFromEnv() (Config, error) {
 		redisDB = value
 	}
 
+	ingestConsumers, err := getenvIntOrDefault("INGEST_CONSUMERS", defaultIngestConsumers)
+	if err != nil {
+		return Config{}, err
+	}
+	ingestBatchSize, err := getenvIntOrDefault("INGEST_BATCH_SIZE", defaultIngestBatchSize)
+	if err != nil {
+		return Config{}, err
+	}
+	ingestStreamMax, err := getenvIntOrDefault("INGEST_STREAM_MAXLEN", defaultIngestStreamMax)
+	if err != nil {
+		return Config{}, err
+	}
+
+	crawlerPerHost, err := getenvIntOrDefault("CRAWLER_HOST_CONCURRENCY", defaultCrawlerPerHost)
+	if err != nil {
+		return Config{}, err
+	}
+
 	return Config{
 		MySQLDSN:          dsn,
 		RedisAddr:         redisAddr,
@@ -66,6 +101,15 @@ func LoadConfigFromEnv() (Config, error) {
 		SummaryProvider:   getenvOrDefault("SUMMARY_PROVIDER", defaultSummaryProvider),
 		SummaryAPIBase:    getenvOrDefault("SUMMARY_API_BASE", defaultSummaryAPIBase),
 		SummaryAPIKey:     os.Getenv("SUMMARY_API_KEY"),
+		TranslateProvider: getenvOrDefault("TRANSLATE_PROVIDER", defaultTranslateProvider),
+		TranslateAPIBase:  getenvOrDefault("TRANSLATE_API_BASE", defaultTranslateAPIBase),
+		TranslateAPIKey:   os.Getenv("TRANSLATE_API_KEY"),
+		TranslateModel:    getenvOrDefault("TRANSLATE_MODEL", defaultTranslateModel),
+		IngestConsumers:   ingestConsumers,
+		IngestBatchSize:   ingestBatchSize,
+		IngestStreamMax:   int64(ingestStreamMax),
+		CrawlerUserAgent:  getenvOrDefault("CRAWLER_USER_AGENT", defaultCrawlerUserAgent),
+		CrawlerPerHost:    crawlerPerHost,
 	}, nil
 }
 
@@ -76,3 +120,15 @@ func getenvOrDefault(key string, fallback string) string {
 	}
 	return value
 }
+
+func getenvIntOrDefault(key string, fallback int) (int, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value <= 0 {
+		return 0, errors.New(key + " must be a positive number")
+	}
+	return value, nil
+}
@@ -70,6 +70,10 @@ func TestLoadConfig_UsesAdminDefaultsWhenUnset(t *testing.T) {
 	t.Setenv("SUMMARY_PROVIDER", "")
 	t.Setenv("SUMMARY_API_BASE", "")
 	t.Setenv("SUMMARY_API_KEY", "")
+	t.Setenv("TRANSLATE_PROVIDER", "")
+	t.Setenv("TRANSLATE_API_BASE", "")
+	t.Setenv("TRANSLATE_API_KEY", "")
+	t.Setenv("TRANSLATE_MODEL", "")
 
 	cfg, err := LoadConfigFromEnv()
 	if err != nil {
@@ -94,6 +98,45 @@ func TestLoadConfig_UsesAdminDefaultsWhenUnset(t *testing.T) {
 	if cfg.SummaryAPIKey != "" {
 		t.Fatalf("expected empty summary api key")
 	}
+	if cfg.TranslateProvider != defaultTranslateProvider || cfg.TranslateModel != defaultTranslateModel {
+		t.Fatalf("expected default translation provider and model, got %q %q", cfg.TranslateProvider, cfg.TranslateModel)
+	}
+	if cfg.TranslateAPIKey != "" {
+		t.Fatalf("expected empty translation api key")
+	}
+}
+
+func TestLoadConfig_IngestAndCrawlerSettings(t *testing.T) {
+	t.Setenv("MYSQL_DSN", "root:root@tcp(localhost:3306)/bajiaozhi")
+	t.Setenv("REDIS_ADDR", "localhost:6379")
+	t.Setenv("INGEST_CONSUMERS", "")
+	t.Setenv("INGEST_BATCH_SIZE", "25")
+	t.Setenv("INGEST_STREAM_MAXLEN", "")
+	t.Setenv("CRAWLER_USER_AGENT", "")
+	t.Setenv("CRAWLER_HOST_CONCURRENCY", "")
+
+	cfg, err := LoadConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.IngestConsumers != defaultIngestConsumers {
+		t.Fatalf("expected default consumer count, got %d", cfg.IngestConsumers)
+	}
+	if cfg.IngestBatchSize != 25 {
+		t.Fatalf("unexpected batch size: %d", cfg.IngestBatchSize)
+	}
+	if cfg.IngestStreamMax != defaultIngestStreamMax {
+		t.Fatalf("expected default stream maxlen, got %d", cfg.IngestStreamMax)
+	}
+
+	if cfg.CrawlerUserAgent != defaultCrawlerUserAgent || cfg.CrawlerPerHost != defaultCrawlerPerHost {
+		t.Fatalf("expected crawler defaults, got %q %d", cfg.CrawlerUserAgent, cfg.CrawlerPerHost)
+	}
+
+	t.Setenv("INGEST_CONSUMERS", "0")
+	if _, err := LoadConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for non-positive consumer count")
+	}
 }
 
 func TestMain(m *testing.M) {
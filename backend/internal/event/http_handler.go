@@ -1,6 +1,7 @@
 package event
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -69,4 +70,52 @@ func RegisterAdminEventRoutes(r *gin.Engine, svc *Service) {
 
 		c.JSON(http.StatusOK, gin.H{"ok": true})
 	})
+
+	r.PUT("/admin/events/:id/bouts/:bout_id/result", func(c *gin.Context) {
+		eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+			return
+		}
+		boutID, err := strconv.ParseInt(c.Param("bout_id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid bout id"})
+			return
+		}
+
+		var req struct {
+			WinnerID int64  `json:"winner_id"`
+			Method   string `json:"method"`
+			Round    int    `json:"round"`
+			TimeSec  int    `json:"time_sec"`
+			Result   string `json:"result"`
+			Note     string `json:"note"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := svc.CorrectBoutResult(c.Request.Context(), eventID, boutID, BoutResultInput{
+			WinnerID: req.WinnerID,
+			Method:   req.Method,
+			Round:    req.Round,
+			TimeSec:  req.TimeSec,
+			Result:   req.Result,
+			Note:     req.Note,
+		}, c.GetInt64("admin_user_id")); err != nil {
+			if errors.Is(err, ErrBoutNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			if errors.Is(err, ErrInvalidWinner) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
 }
@@ -3,6 +3,7 @@ package event
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -23,3 +24,38 @@ func TestGetEventCard_ReturnsBouts(t *testing.T) {
 		t.Fatalf("expected 200, got %d", w.Code)
 	}
 }
+
+func TestCorrectBoutResult_RecordsHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := NewInMemoryRepository()
+	r := gin.New()
+	RegisterAdminEventRoutes(r, NewService(repo))
+
+	body := `{"winner_id":0,"method":"No Contest","result":"NC","note":"overturned after failed test"}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/admin/events/10/bouts/1001/result", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d body=%s", w.Code, w.Body.String())
+	}
+
+	history := repo.ResultHistory(1001)
+	if len(history) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(history))
+	}
+	if history[0].Source != ResultSourceCorrection || history[0].PreviousResult != "pending" {
+		t.Fatalf("unexpected history entry: %+v", history[0])
+	}
+	if _, locked := repo.ResultLockedBy(1001); !locked {
+		t.Fatal("expected the corrected bout locked against scraper writes")
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPut, "/admin/events/10/bouts/9999/result", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown bout, got %d", w.Code)
+	}
+}
@@ -3,7 +3,14 @@ package event
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
+	"time"
+)
+
+var (
+	ErrBoutNotFound  = errors.New("bout not found")
+	ErrInvalidWinner = errors.New("winner must be one of the bout fighters")
 )
 
 // Bout is one matchup in an event card.
@@ -20,6 +27,46 @@ type Bout struct {
 	Method        string `json:"method,omitempty"`
 	Round         int    `json:"round,omitempty"`
 	TimeSec       int    `json:"time_sec,omitempty"`
+	LiveState     string `json:"live_state,omitempty"`
+	CurrentRound  int    `json:"current_round,omitempty"`
+}
+
+const (
+	BoutStateUpcoming   = "upcoming"
+	BoutStateWalkouts   = "walkouts"
+	BoutStateInProgress = "in_progress"
+	BoutStateFinished   = "finished"
+)
+
+const (
+	ResultSourceScraper    = "scraper"
+	ResultSourceManual     = "manual"
+	ResultSourceCorrection = "correction"
+)
+
+// BoutResultInput is one result write for a bout.
+type BoutResultInput struct {
+	WinnerID int64
+	Method   string
+	Round    int
+	TimeSec  int
+	Result   string
+	Note     string
+}
+
+// BoutResultChange is one entry of a bout result history.
+type BoutResultChange struct {
+	ID               int64     `json:"id"`
+	WinnerID         int64     `json:"winner_id,omitempty"`
+	Method           string    `json:"method,omitempty"`
+	Round            int       `json:"round,omitempty"`
+	TimeSec          int       `json:"time_sec,omitempty"`
+	Result           string    `json:"result,omitempty"`
+	PreviousWinnerID int64     `json:"previous_winner_id,omitempty"`
+	PreviousResult   string    `json:"previous_result,omitempty"`
+	Source           string    `json:"source"`
+	Note             string    `json:"note,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
 }
 
 type FighterSnapshot struct {
@@ -42,6 +89,10 @@ type BoutDetail struct {
 	Method      string          `json:"method,omitempty"`
 	Round       int             `json:"round,omitempty"`
 	TimeSec     int             `json:"time_sec,omitempty"`
+	// LiveState is one of upcoming, walkouts, in_progress or finished.
+	LiveState     string             `json:"live_state,omitempty"`
+	CurrentRound  int                `json:"current_round,omitempty"`
+	ResultHistory []BoutResultChange `json:"result_history,omitempty"`
 }
 
 // Card is event detail with all bouts.
@@ -55,6 +106,8 @@ type Card struct {
 	Bouts         []Bout       `json:"bouts"`
 	MainCard      []BoutDetail `json:"main_card"`
 	Prelims       []BoutDetail `json:"prelims"`
+	// CurrentBoutID points at the bout in walkouts or in progress, if any.
+	CurrentBoutID int64 `json:"current_bout_id,omitempty"`
 }
 
 // EventSummary is list item for schedule page.
@@ -77,6 +130,10 @@ type Repository interface {
 	GetEventCard(ctx context.Context, eventID int64) (Card, error)
 	ListEvents(ctx context.Context) ([]EventSummary, error)
 	UpdateEvent(ctx context.Context, eventID int64, input UpdateEventInput) error
+	RecordBoutResult(ctx context.Context, eventID int64, boutID int64, input BoutResultInput, source string) error
+	// RecordCorrectedBoutResult writes an editor's correction and locks the bout against
+	// scraper writes, like manual live scoring.
+	RecordCorrectedBoutResult(ctx context.Context, eventID int64, boutID int64, input BoutResultInput, editorID int64) error
 }
 
 type Service struct {
@@ -148,11 +205,31 @@ func (s *Service) UpdateEvent(ctx context.Context, eventID int64, input UpdateEv
 	return nil
 }
 
+// CorrectBoutResult overwrites a bout result after the fact and keeps the change in history.
+// The bout is locked so the next scrape does not revert the correction.
+func (s *Service) CorrectBoutResult(ctx context.Context, eventID int64, boutID int64, input BoutResultInput, editorID int64) error {
+	input.Method = strings.TrimSpace(input.Method)
+	input.Result = strings.TrimSpace(input.Result)
+	input.Note = strings.TrimSpace(input.Note)
+	if err := s.repo.RecordCorrectedBoutResult(ctx, eventID, boutID, input, editorID); err != nil {
+		return err
+	}
+
+	if s.cache != nil {
+		_ = s.cache.InvalidateEvent(ctx, eventID)
+		_ = s.cache.InvalidateEvents(ctx)
+	}
+	return nil
+}
+
 type InMemoryRepository struct {
 	mu sync.Mutex
 
-	cards  map[int64]Card
-	events []EventSummary
+	cards   map[int64]Card
+	events  []EventSummary
+	history map[int64][]BoutResultChange
+	// lockedBy maps result-locked bouts to the editor who locked them.
+	lockedBy map[int64]int64
 }
 
 func NewInMemoryRepository() *InMemoryRepository {
@@ -249,6 +326,112 @@ func (r *InMemoryRepository) UpsertBoutResult(_ context.Context, eventID int64,
 	return errors.New("bout not found")
 }
 
+// RecordBoutResult updates one bout result in-memory and appends the change to its history.
+func (r *InMemoryRepository) RecordBoutResult(_ context.Context, eventID int64, boutID int64, input BoutResultInput, source string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	card, ok := r.cards[eventID]
+	if !ok {
+		return errors.New("event not found")
+	}
+
+	for i := range card.Bouts {
+		bout := &card.Bouts[i]
+		if bout.ID != boutID {
+			continue
+		}
+		if input.WinnerID != 0 && input.WinnerID != bout.RedFighterID && input.WinnerID != bout.BlueFighterID {
+			return ErrInvalidWinner
+		}
+		if bout.WinnerID == input.WinnerID && bout.Method == input.Method &&
+			bout.Round == input.Round && bout.TimeSec == input.TimeSec && bout.Result == input.Result {
+			return nil
+		}
+		if r.history == nil {
+			r.history = map[int64][]BoutResultChange{}
+		}
+		r.history[boutID] = append(r.history[boutID], BoutResultChange{
+			ID:               int64(len(r.history[boutID]) + 1),
+			WinnerID:         input.WinnerID,
+			Method:           input.Method,
+			Round:            input.Round,
+			TimeSec:          input.TimeSec,
+			Result:           input.Result,
+			PreviousWinnerID: bout.WinnerID,
+			PreviousResult:   bout.Result,
+			Source:           source,
+			Note:             input.Note,
+			CreatedAt:        time.Now().UTC(),
+		})
+		bout.WinnerID = input.WinnerID
+		bout.Method = input.Method
+		bout.Round = input.Round
+		bout.TimeSec = input.TimeSec
+		bout.Result = input.Result
+		r.cards[eventID] = card
+		return nil
+	}
+
+	return ErrBoutNotFound
+}
+
+// RecordCorrectedBoutResult records an editor's correction in-memory and locks the bout.
+func (r *InMemoryRepository) RecordCorrectedBoutResult(ctx context.Context, eventID int64, boutID int64, input BoutResultInput, editorID int64) error {
+	if err := r.RecordBoutResult(ctx, eventID, boutID, input, ResultSourceCorrection); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lockedBy == nil {
+		r.lockedBy = map[int64]int64{}
+	}
+	r.lockedBy[boutID] = editorID
+	return nil
+}
+
+// ResultLockedBy reports whether a bout's result is locked, and by which editor.
+func (r *InMemoryRepository) ResultLockedBy(boutID int64) (int64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	editorID, ok := r.lockedBy[boutID]
+	return editorID, ok
+}
+
+// ResultHistory returns the in-memory result history of one bout.
+func (r *InMemoryRepository) ResultHistory(boutID int64) []BoutResultChange {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := make([]BoutResultChange, len(r.history[boutID]))
+	copy(items, r.history[boutID])
+	return items
+}
+
+// UpdateBoutLiveState updates one bout live state in-memory for live-update flow.
+func (r *InMemoryRepository) UpdateBoutLiveState(_ context.Context, eventID int64, boutID int64, state string, currentRound int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	card, ok := r.cards[eventID]
+	if !ok {
+		return errors.New("event not found")
+	}
+
+	for i := range card.Bouts {
+		if card.Bouts[i].ID != boutID {
+			continue
+		}
+		card.Bouts[i].LiveState = state
+		card.Bouts[i].CurrentRound = currentRound
+		r.cards[eventID] = card
+		return nil
+	}
+
+	return errors.New("bout not found")
+}
+
 func normalizeCard(card Card) Card {
 	if card.Bouts == nil {
 		card.Bouts = []Bout{}
@@ -259,5 +442,20 @@ func normalizeCard(card Card) Card {
 	if card.Prelims == nil {
 		card.Prelims = []BoutDetail{}
 	}
+	card.CurrentBoutID = currentBoutID(card.Bouts)
 	return card
 }
+
+func currentBoutID(bouts []Bout) int64 {
+	for _, bout := range bouts {
+		if bout.LiveState == BoutStateInProgress {
+			return bout.ID
+		}
+	}
+	for _, bout := range bouts {
+		if bout.LiveState == BoutStateWalkouts {
+			return bout.ID
+		}
+	}
+	return 0
+}
@@ -24,6 +24,14 @@ func (r *fakeEventRepo) UpdateEvent(context.Context, int64, UpdateEventInput) er
 	return nil
 }
 
+func (r *fakeEventRepo) RecordBoutResult(context.Context, int64, int64, BoutResultInput, string) error {
+	return nil
+}
+
+func (r *fakeEventRepo) RecordCorrectedBoutResult(context.Context, int64, int64, BoutResultInput, int64) error {
+	return nil
+}
+
 type fakeEventCacheMiss struct {
 	setCardCalled bool
 	setListCalled bool
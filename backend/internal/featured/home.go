@@ -0,0 +1,238 @@
+package featured
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
+)
+
+const (
+	// DefaultHomeTTL bounds how stale a cached home screen gets; a slot starting or ending
+	// sooner shortens it.
+	DefaultHomeTTL  = 60 * time.Second
+	HomeEventsLimit = 3
+	HomeLatestLimit = 10
+)
+
+// Home is everything the miniapp home screen shows, in one response.
+type Home struct {
+	Carousel       []Feature            `json:"carousel"`
+	Pinned         []HomeArticle        `json:"pinned"`
+	HighlightEvent *event.EventSummary  `json:"highlight_event,omitempty"`
+	NextEvents     []event.EventSummary `json:"next_events"`
+	Latest         []HomeArticle        `json:"latest"`
+}
+
+// Feature is one carousel entry with the slot's overrides applied.
+type Feature struct {
+	SlotID     int64  `json:"slot_id"`
+	TargetType string `json:"target_type"`
+	TargetID   int64  `json:"target_id"`
+	Title      string `json:"title"`
+	ImageURL   string `json:"image_url,omitempty"`
+}
+
+// HomeArticle is an article card. Videos are left to the article page, which applies the
+// playback policy.
+type HomeArticle struct {
+	ID          int64      `json:"id"`
+	SourceID    int64      `json:"source_id"`
+	Title       string     `json:"title"`
+	Summary     string     `json:"summary"`
+	SourceURL   string     `json:"source_url"`
+	CoverURL    string     `json:"cover_url,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+}
+
+type HomeCache interface {
+	GetHome(ctx context.Context) (Home, bool, error)
+	SetHome(ctx context.Context, home Home, ttl time.Duration) error
+	InvalidateHome(ctx context.Context) error
+}
+
+// Home builds the home screen from the slots active now, the next events and the latest
+// news. Slots whose article went offline or whose event is gone are skipped.
+func (s *Service) Home(ctx context.Context) (Home, error) {
+	if s.cache != nil {
+		if home, ok, err := s.cache.GetHome(ctx); err == nil && ok {
+			return home, nil
+		}
+	}
+
+	now := s.now().UTC()
+	slots, err := s.repo.List(ctx)
+	if err != nil {
+		return Home{}, err
+	}
+	events, err := s.events.ListEvents(ctx)
+	if err != nil {
+		return Home{}, err
+	}
+
+	home := Home{Carousel: []Feature{}, Pinned: []HomeArticle{}}
+	pinnedIDs := map[int64]bool{}
+	for _, slot := range slots {
+		if !slot.Active(now) {
+			continue
+		}
+		switch slot.Kind {
+		case KindCarousel:
+			feature, ok, err := s.feature(ctx, slot, events)
+			if err != nil {
+				return Home{}, err
+			}
+			if ok {
+				home.Carousel = append(home.Carousel, feature)
+			}
+		case KindPinned:
+			article, ok, err := s.publishedArticle(ctx, slot.TargetID)
+			if err != nil {
+				return Home{}, err
+			}
+			if ok && !pinnedIDs[article.ID] {
+				pinnedIDs[article.ID] = true
+				home.Pinned = append(home.Pinned, homeArticle(article))
+			}
+		case KindEvent:
+			if home.HighlightEvent != nil {
+				continue
+			}
+			if item, ok := findEvent(events, slot.TargetID); ok {
+				home.HighlightEvent = &item
+			}
+		}
+	}
+
+	var highlightID int64
+	if home.HighlightEvent != nil {
+		highlightID = home.HighlightEvent.ID
+	}
+	home.NextEvents = nextEvents(events, now, highlightID)
+
+	latest, err := s.articles.ListFeed(ctx, review.FeedQuery{Limit: HomeLatestLimit + len(pinnedIDs)})
+	if err != nil {
+		return Home{}, err
+	}
+	home.Latest = make([]HomeArticle, 0, HomeLatestLimit)
+	for _, article := range latest {
+		if pinnedIDs[article.ID] || len(home.Latest) == HomeLatestLimit {
+			continue
+		}
+		home.Latest = append(home.Latest, homeArticle(article))
+	}
+
+	if s.cache != nil {
+		_ = s.cache.SetHome(ctx, home, homeTTL(slots, now))
+	}
+	return home, nil
+}
+
+func (s *Service) feature(ctx context.Context, slot Slot, events []event.EventSummary) (Feature, bool, error) {
+	feature := Feature{SlotID: slot.ID, TargetType: slot.TargetType, TargetID: slot.TargetID}
+	switch slot.TargetType {
+	case TargetArticle:
+		article, ok, err := s.publishedArticle(ctx, slot.TargetID)
+		if err != nil || !ok {
+			return Feature{}, false, err
+		}
+		feature.Title, feature.ImageURL = article.Title, article.CoverURL
+	case TargetEvent:
+		item, ok := findEvent(events, slot.TargetID)
+		if !ok {
+			return Feature{}, false, nil
+		}
+		feature.Title, feature.ImageURL = item.Name, item.PosterURL
+	}
+	if slot.Title != "" {
+		feature.Title = slot.Title
+	}
+	if slot.ImageURL != "" {
+		feature.ImageURL = slot.ImageURL
+	}
+	return feature, true, nil
+}
+
+func (s *Service) publishedArticle(ctx context.Context, articleID int64) (review.PendingArticle, bool, error) {
+	article, err := s.articles.GetPublished(ctx, articleID)
+	if errors.Is(err, review.ErrArticleNotFound) {
+		return review.PendingArticle{}, false, nil
+	}
+	if err != nil {
+		return review.PendingArticle{}, false, err
+	}
+	return article, true, nil
+}
+
+func homeArticle(article review.PendingArticle) HomeArticle {
+	return HomeArticle{
+		ID:          article.ID,
+		SourceID:    article.SourceID,
+		Title:       article.Title,
+		Summary:     article.Summary,
+		SourceURL:   article.SourceURL,
+		CoverURL:    article.CoverURL,
+		PublishedAt: article.PublishedAt,
+		Tags:        article.Tags,
+	}
+}
+
+// nextEvents returns live events, then scheduled ones that have not started, soonest first.
+func nextEvents(events []event.EventSummary, now time.Time, skipID int64) []event.EventSummary {
+	type upcoming struct {
+		item     event.EventSummary
+		startsAt time.Time
+	}
+	candidates := make([]upcoming, 0)
+	for _, item := range events {
+		if item.ID == skipID {
+			continue
+		}
+		startsAt, err := time.Parse(time.RFC3339, item.StartsAt)
+		if err != nil {
+			continue
+		}
+		if item.Status == "live" || (item.Status == "scheduled" && !startsAt.Before(now)) {
+			candidates = append(candidates, upcoming{item: item, startsAt: startsAt})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		liveI, liveJ := candidates[i].item.Status == "live", candidates[j].item.Status == "live"
+		if liveI != liveJ {
+			return liveI
+		}
+		return candidates[i].startsAt.Before(candidates[j].startsAt)
+	})
+
+	items := make([]event.EventSummary, 0, HomeEventsLimit)
+	for _, candidate := range candidates {
+		if len(items) == HomeEventsLimit {
+			break
+		}
+		items = append(items, candidate.item)
+	}
+	return items
+}
+
+// homeTTL keeps a cached home screen no longer than the next slot start or end.
+func homeTTL(slots []Slot, now time.Time) time.Duration {
+	ttl := DefaultHomeTTL
+	for _, slot := range slots {
+		for _, bound := range []*time.Time{slot.StartsAt, slot.EndsAt} {
+			if bound == nil || !bound.After(now) {
+				continue
+			}
+			if until := bound.Sub(now); until < ttl {
+				ttl = until
+			}
+		}
+	}
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+	return ttl
+}
@@ -0,0 +1,98 @@
+package featured
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterHomeRoutes serves the miniapp home screen.
+func RegisterHomeRoutes(r *gin.Engine, svc *Service) {
+	r.GET("/api/home", func(c *gin.Context) {
+		home, err := svc.Home(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, home)
+	})
+}
+
+func RegisterAdminFeaturedRoutes(r *gin.Engine, svc *Service) {
+	r.GET("/admin/featured", func(c *gin.Context) {
+		items, err := svc.List(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": items})
+	})
+
+	r.POST("/admin/featured", func(c *gin.Context) {
+		var input SlotInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		slot, err := svc.Create(c.Request.Context(), input)
+		if err != nil {
+			writeSlotError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, slot)
+	})
+
+	r.PUT("/admin/featured/:id", func(c *gin.Context) {
+		slotID, ok := slotIDParam(c)
+		if !ok {
+			return
+		}
+		var input SlotInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		slot, err := svc.Update(c.Request.Context(), slotID, input)
+		if err != nil {
+			writeSlotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, slot)
+	})
+
+	r.DELETE("/admin/featured/:id", func(c *gin.Context) {
+		slotID, ok := slotIDParam(c)
+		if !ok {
+			return
+		}
+		if err := svc.Delete(c.Request.Context(), slotID); err != nil {
+			writeSlotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+}
+
+func slotIDParam(c *gin.Context) (int64, bool) {
+	slotID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || slotID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid slot id"})
+		return 0, false
+	}
+	return slotID, true
+}
+
+func writeSlotError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidSlot), errors.Is(err, ErrTargetNotFound):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrSlotNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
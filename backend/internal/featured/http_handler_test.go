@@ -0,0 +1,52 @@
+package featured
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestFeaturedHandlers_AdminSlotsAndHome(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc, _, _ := newHomeFixture()
+	r := gin.New()
+	RegisterHomeRoutes(r, svc)
+	RegisterAdminFeaturedRoutes(r, svc)
+
+	send := func(method string, url string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(http.MethodPost, "/admin/featured", `{"kind":"carousel","target_type":"article","target_id":3,"starts_at":"2026-03-01T00:00:00Z"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/admin/featured", `{"kind":"pinned","target_type":"event","target_id":11}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a pinned event, got %d", w.Code)
+	}
+	if w := send(http.MethodPut, "/admin/featured/1", `{"kind":"carousel","target_type":"article","target_id":3,"title":"头条"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodDelete, "/admin/featured/9", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+
+	w := send(http.MethodGet, "/api/home", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var home Home
+	if err := json.Unmarshal(w.Body.Bytes(), &home); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(home.Carousel) != 1 || home.Carousel[0].Title != "头条" || len(home.Latest) != 3 || len(home.NextEvents) != 3 {
+		t.Fatalf("unexpected home %+v", home)
+	}
+}
@@ -0,0 +1,278 @@
+package featured
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
+)
+
+const (
+	// KindCarousel slots fill the headline carousel with an article or an event.
+	KindCarousel = "carousel"
+	// KindPinned slots keep an article above the latest news.
+	KindPinned = "pinned"
+	// KindEvent slots pick the event highlighted on the home screen.
+	KindEvent = "event"
+
+	TargetArticle = "article"
+	TargetEvent   = "event"
+)
+
+var (
+	ErrInvalidSlot    = errors.New("slot needs a known kind, a target allowed for it and an end after its start")
+	ErrSlotNotFound   = errors.New("featured slot not found")
+	ErrTargetNotFound = errors.New("featured target is not a published article or a known event")
+)
+
+// Slot promotes one article or event on the home screen between StartsAt and EndsAt; a nil
+// bound leaves that side open. Lower positions come first.
+type Slot struct {
+	ID         int64      `json:"id"`
+	Kind       string     `json:"kind"`
+	TargetType string     `json:"target_type"`
+	TargetID   int64      `json:"target_id"`
+	Title      string     `json:"title,omitempty"`
+	ImageURL   string     `json:"image_url,omitempty"`
+	Position   int        `json:"position"`
+	StartsAt   *time.Time `json:"starts_at,omitempty"`
+	EndsAt     *time.Time `json:"ends_at,omitempty"`
+}
+
+// Active reports whether the slot is shown at now.
+func (s Slot) Active(now time.Time) bool {
+	return (s.StartsAt == nil || !now.Before(*s.StartsAt)) && (s.EndsAt == nil || now.Before(*s.EndsAt))
+}
+
+// SlotInput creates or replaces a slot. Title and ImageURL override the target's own.
+type SlotInput struct {
+	Kind       string     `json:"kind"`
+	TargetType string     `json:"target_type"`
+	TargetID   int64      `json:"target_id"`
+	Title      string     `json:"title"`
+	ImageURL   string     `json:"image_url"`
+	Position   int        `json:"position"`
+	StartsAt   *time.Time `json:"starts_at"`
+	EndsAt     *time.Time `json:"ends_at"`
+}
+
+type Repository interface {
+	// List returns every slot ordered by kind, position and id.
+	List(ctx context.Context) ([]Slot, error)
+	Create(ctx context.Context, slot Slot) (Slot, error)
+	// Update replaces a slot, or returns ErrSlotNotFound.
+	Update(ctx context.Context, slot Slot) (Slot, error)
+	// Delete removes a slot, or returns ErrSlotNotFound.
+	Delete(ctx context.Context, slotID int64) error
+}
+
+// ArticleReader reads the published articles slots point at and the latest news.
+type ArticleReader interface {
+	ListFeed(ctx context.Context, query review.FeedQuery) ([]review.PendingArticle, error)
+	GetPublished(ctx context.Context, articleID int64) (review.PendingArticle, error)
+}
+
+// EventLister lists the events slots point at and the schedule the home screen draws from.
+type EventLister interface {
+	ListEvents(ctx context.Context) ([]event.EventSummary, error)
+}
+
+type Service struct {
+	repo     Repository
+	articles ArticleReader
+	events   EventLister
+	cache    HomeCache
+	now      func() time.Time
+}
+
+func NewService(repo Repository, articles ArticleReader, events EventLister, cache ...HomeCache) *Service {
+	s := &Service{repo: repo, articles: articles, events: events, now: time.Now}
+	if len(cache) > 0 {
+		s.cache = cache[0]
+	}
+	return s
+}
+
+func (s *Service) List(ctx context.Context) ([]Slot, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *Service) Create(ctx context.Context, input SlotInput) (Slot, error) {
+	slot, err := s.slotFromInput(ctx, input)
+	if err != nil {
+		return Slot{}, err
+	}
+	slot, err = s.repo.Create(ctx, slot)
+	if err != nil {
+		return Slot{}, err
+	}
+	s.invalidateHome(ctx)
+	return slot, nil
+}
+
+func (s *Service) Update(ctx context.Context, slotID int64, input SlotInput) (Slot, error) {
+	slot, err := s.slotFromInput(ctx, input)
+	if err != nil {
+		return Slot{}, err
+	}
+	slot.ID = slotID
+	slot, err = s.repo.Update(ctx, slot)
+	if err != nil {
+		return Slot{}, err
+	}
+	s.invalidateHome(ctx)
+	return slot, nil
+}
+
+func (s *Service) Delete(ctx context.Context, slotID int64) error {
+	if err := s.repo.Delete(ctx, slotID); err != nil {
+		return err
+	}
+	s.invalidateHome(ctx)
+	return nil
+}
+
+func (s *Service) invalidateHome(ctx context.Context) {
+	if s.cache != nil {
+		_ = s.cache.InvalidateHome(ctx)
+	}
+}
+
+func (s *Service) slotFromInput(ctx context.Context, input SlotInput) (Slot, error) {
+	slot := Slot{
+		Kind:       strings.TrimSpace(input.Kind),
+		TargetType: strings.TrimSpace(input.TargetType),
+		TargetID:   input.TargetID,
+		Title:      strings.TrimSpace(input.Title),
+		ImageURL:   strings.TrimSpace(input.ImageURL),
+		Position:   input.Position,
+		StartsAt:   utcOrNil(input.StartsAt),
+		EndsAt:     utcOrNil(input.EndsAt),
+	}
+	if !validTarget(slot.Kind, slot.TargetType) || slot.TargetID <= 0 {
+		return Slot{}, ErrInvalidSlot
+	}
+	if slot.StartsAt != nil && slot.EndsAt != nil && !slot.EndsAt.After(*slot.StartsAt) {
+		return Slot{}, ErrInvalidSlot
+	}
+
+	switch slot.TargetType {
+	case TargetArticle:
+		if _, err := s.articles.GetPublished(ctx, slot.TargetID); err != nil {
+			if errors.Is(err, review.ErrArticleNotFound) {
+				return Slot{}, ErrTargetNotFound
+			}
+			return Slot{}, err
+		}
+	case TargetEvent:
+		events, err := s.events.ListEvents(ctx)
+		if err != nil {
+			return Slot{}, err
+		}
+		if _, ok := findEvent(events, slot.TargetID); !ok {
+			return Slot{}, ErrTargetNotFound
+		}
+	}
+	return slot, nil
+}
+
+func validTarget(kind string, targetType string) bool {
+	switch kind {
+	case KindCarousel:
+		return targetType == TargetArticle || targetType == TargetEvent
+	case KindPinned:
+		return targetType == TargetArticle
+	case KindEvent:
+		return targetType == TargetEvent
+	default:
+		return false
+	}
+}
+
+func utcOrNil(value *time.Time) *time.Time {
+	if value == nil || value.IsZero() {
+		return nil
+	}
+	utc := value.UTC()
+	return &utc
+}
+
+func findEvent(events []event.EventSummary, eventID int64) (event.EventSummary, bool) {
+	for _, item := range events {
+		if item.ID == eventID {
+			return item, true
+		}
+	}
+	return event.EventSummary{}, false
+}
+
+func sortSlots(slots []Slot) {
+	sort.Slice(slots, func(i, j int) bool {
+		if slots[i].Kind != slots[j].Kind {
+			return slots[i].Kind < slots[j].Kind
+		}
+		if slots[i].Position != slots[j].Position {
+			return slots[i].Position < slots[j].Position
+		}
+		return slots[i].ID < slots[j].ID
+	})
+}
+
+type InMemoryRepository struct {
+	mu     sync.Mutex
+	slots  map[int64]Slot
+	nextID int64
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{slots: map[int64]Slot{}, nextID: 1}
+}
+
+func (r *InMemoryRepository) List(context.Context) ([]Slot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := make([]Slot, 0, len(r.slots))
+	for _, slot := range r.slots {
+		items = append(items, slot)
+	}
+	sortSlots(items)
+	return items, nil
+}
+
+func (r *InMemoryRepository) Create(_ context.Context, slot Slot) (Slot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	slot.ID = r.nextID
+	r.nextID++
+	r.slots[slot.ID] = slot
+	return slot, nil
+}
+
+func (r *InMemoryRepository) Update(_ context.Context, slot Slot) (Slot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.slots[slot.ID]; !ok {
+		return Slot{}, ErrSlotNotFound
+	}
+	r.slots[slot.ID] = slot
+	return slot, nil
+}
+
+func (r *InMemoryRepository) Delete(_ context.Context, slotID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.slots[slotID]; !ok {
+		return ErrSlotNotFound
+	}
+	delete(r.slots, slotID)
+	return nil
+}
@@ -0,0 +1,162 @@
+package featured
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
+)
+
+type fakeArticles struct {
+	items []review.PendingArticle
+}
+
+func (f *fakeArticles) ListFeed(_ context.Context, query review.FeedQuery) ([]review.PendingArticle, error) {
+	items := f.items
+	if query.Limit > 0 && len(items) > query.Limit {
+		items = items[:query.Limit]
+	}
+	return items, nil
+}
+
+func (f *fakeArticles) GetPublished(_ context.Context, articleID int64) (review.PendingArticle, error) {
+	for _, item := range f.items {
+		if item.ID == articleID {
+			return item, nil
+		}
+	}
+	return review.PendingArticle{}, review.ErrArticleNotFound
+}
+
+type fakeEvents struct {
+	items []event.EventSummary
+}
+
+func (f *fakeEvents) ListEvents(context.Context) ([]event.EventSummary, error) {
+	return f.items, nil
+}
+
+type fakeHomeCache struct {
+	home        *Home
+	ttl         time.Duration
+	invalidated int
+}
+
+func (f *fakeHomeCache) GetHome(context.Context) (Home, bool, error) {
+	if f.home == nil {
+		return Home{}, false, nil
+	}
+	return *f.home, true, nil
+}
+
+func (f *fakeHomeCache) SetHome(_ context.Context, home Home, ttl time.Duration) error {
+	f.home, f.ttl = &home, ttl
+	return nil
+}
+
+func (f *fakeHomeCache) InvalidateHome(context.Context) error {
+	f.home = nil
+	f.invalidated++
+	return nil
+}
+
+var homeNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newHomeFixture() (*Service, *fakeArticles, *fakeHomeCache) {
+	articles := &fakeArticles{items: []review.PendingArticle{
+		{ID: 3, Title: "Latest", CoverURL: "https://img/3.jpg"},
+		{ID: 2, Title: "Pinned story", VideoURL: "https://video/2.mp4"},
+		{ID: 1, Title: "Oldest"},
+	}}
+	events := &fakeEvents{items: []event.EventSummary{
+		{ID: 10, Name: "UFC 300", Status: "completed", StartsAt: "2026-02-01T20:00:00Z"},
+		{ID: 11, Name: "UFC 301", Status: "scheduled", StartsAt: "2026-03-20T20:00:00Z", PosterURL: "https://img/301.jpg"},
+		{ID: 12, Name: "ONE 170", Status: "scheduled", StartsAt: "2026-03-08T11:00:00Z"},
+		{ID: 13, Name: "PFL 1", Status: "live", StartsAt: "2026-03-01T10:00:00Z"},
+	}}
+	cache := &fakeHomeCache{}
+	svc := NewService(NewInMemoryRepository(), articles, events, cache)
+	svc.now = func() time.Time { return homeNow }
+	return svc, articles, cache
+}
+
+func TestService_HomeCombinesActiveSlotsEventsAndLatest(t *testing.T) {
+	svc, articles, cache := newHomeFixture()
+	ctx := context.Background()
+	ended := homeNow.Add(-time.Hour)
+	endsSoon := homeNow.Add(30 * time.Second)
+
+	for _, input := range []SlotInput{
+		{Kind: KindCarousel, TargetType: TargetEvent, TargetID: 11, Position: 2},
+		{Kind: KindCarousel, TargetType: TargetArticle, TargetID: 3, Title: "Headline", Position: 1, EndsAt: &endsSoon},
+		{Kind: KindCarousel, TargetType: TargetArticle, TargetID: 1, EndsAt: &ended},
+		{Kind: KindPinned, TargetType: TargetArticle, TargetID: 2},
+		{Kind: KindEvent, TargetType: TargetEvent, TargetID: 12},
+	} {
+		if _, err := svc.Create(ctx, input); err != nil {
+			t.Fatalf("create slot %+v failed: %v", input, err)
+		}
+	}
+
+	home, err := svc.Home(ctx)
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	if len(home.Carousel) != 2 || home.Carousel[0].Title != "Headline" || home.Carousel[0].ImageURL != "https://img/3.jpg" ||
+		home.Carousel[1].Title != "UFC 301" || home.Carousel[1].ImageURL != "https://img/301.jpg" {
+		t.Fatalf("expected active carousel slots by position with overrides, got %+v", home.Carousel)
+	}
+	if len(home.Pinned) != 1 || home.Pinned[0].ID != 2 {
+		t.Fatalf("expected the pinned article, got %+v", home.Pinned)
+	}
+	if home.HighlightEvent == nil || home.HighlightEvent.ID != 12 {
+		t.Fatalf("expected the highlighted event, got %+v", home.HighlightEvent)
+	}
+	if len(home.NextEvents) != 2 || home.NextEvents[0].ID != 13 || home.NextEvents[1].ID != 11 {
+		t.Fatalf("expected live then upcoming events without the highlight, got %+v", home.NextEvents)
+	}
+	if len(home.Latest) != 2 || home.Latest[0].ID != 3 || home.Latest[1].ID != 1 {
+		t.Fatalf("expected latest news without the pinned article, got %+v", home.Latest)
+	}
+	if cache.home == nil || cache.ttl != 30*time.Second {
+		t.Fatalf("expected home cached until the next slot bound, got %v", cache.ttl)
+	}
+
+	articles.items = articles.items[:1]
+	if home, _ := svc.Home(ctx); len(home.Pinned) != 1 {
+		t.Fatalf("expected the cached home served, got %+v", home.Pinned)
+	}
+	if err := svc.Delete(ctx, 5); err != nil || cache.invalidated == 0 {
+		t.Fatalf("expected delete to invalidate the home cache, got %v", err)
+	}
+	home, _ = svc.Home(ctx)
+	if len(home.Pinned) != 0 || len(home.Carousel) != 2 || home.HighlightEvent != nil {
+		t.Fatalf("expected offline articles skipped, got %+v", home)
+	}
+}
+
+func TestService_CreateValidatesSlots(t *testing.T) {
+	svc, _, _ := newHomeFixture()
+	ctx := context.Background()
+	later := homeNow.Add(time.Hour)
+
+	for _, input := range []SlotInput{
+		{Kind: "banner", TargetType: TargetArticle, TargetID: 3},
+		{Kind: KindPinned, TargetType: TargetEvent, TargetID: 11},
+		{Kind: KindEvent, TargetType: TargetEvent},
+		{Kind: KindCarousel, TargetType: TargetArticle, TargetID: 3, StartsAt: &later, EndsAt: &homeNow},
+	} {
+		if _, err := svc.Create(ctx, input); !errors.Is(err, ErrInvalidSlot) {
+			t.Fatalf("expected invalid slot for %+v, got %v", input, err)
+		}
+	}
+	if _, err := svc.Create(ctx, SlotInput{Kind: KindPinned, TargetType: TargetArticle, TargetID: 99}); !errors.Is(err, ErrTargetNotFound) {
+		t.Fatalf("expected missing article rejected, got %v", err)
+	}
+	if _, err := svc.Update(ctx, 7, SlotInput{Kind: KindEvent, TargetType: TargetEvent, TargetID: 11}); !errors.Is(err, ErrSlotNotFound) {
+		t.Fatalf("expected missing slot, got %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+// Package fetch is the shared outbound HTTP layer for crawlers. Every request goes through a
+// Policy that obeys robots.txt, spaces requests per host and sends one User-Agent.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultUserAgent       = "Mozilla/5.0 (compatible; w-mma-bot/1.0)"
+	DefaultHostConcurrency = 2
+	DefaultRobotsTTL       = time.Hour
+
+	// robotsErrorTTL keeps a 5xx robots.txt answer (treated as "disallow all") short-lived.
+	robotsErrorTTL  = 10 * time.Minute
+	maxCrawlDelay   = time.Minute
+	robotsBodyLimit = 512 << 10
+	// maxRobotsRedirects is the number of robots.txt redirects RFC 9309 asks crawlers to follow.
+	maxRobotsRedirects = 5
+)
+
+var ErrBlockedByRobots = errors.New("blocked by robots.txt")
+
+type Config struct {
+	UserAgent string
+	// HostConcurrency caps in-flight requests per host.
+	HostConcurrency int
+	// MinDelay spaces requests to one host when robots.txt sets no Crawl-delay.
+	MinDelay  time.Duration
+	RobotsTTL time.Duration
+	Transport http.RoundTripper
+}
+
+// Policy is an http.RoundTripper enforcing the crawl policy. It is safe for concurrent use
+// and meant to be shared by every fetcher in a process, so limits apply across them.
+type Policy struct {
+	transport       http.RoundTripper
+	userAgent       string
+	hostConcurrency int
+	minDelay        time.Duration
+	robotsTTL       time.Duration
+	now             func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+type hostState struct {
+	slots chan struct{}
+
+	robotsMu      sync.Mutex
+	robots        robotsRules
+	robotsExpires time.Time
+
+	paceMu sync.Mutex
+	nextAt time.Time
+}
+
+func NewPolicy(cfg Config) *Policy {
+	p := &Policy{
+		transport:       cfg.Transport,
+		userAgent:       strings.TrimSpace(cfg.UserAgent),
+		hostConcurrency: cfg.HostConcurrency,
+		minDelay:        cfg.MinDelay,
+		robotsTTL:       cfg.RobotsTTL,
+		now:             time.Now,
+		hosts:           make(map[string]*hostState),
+	}
+	if p.transport == nil {
+		p.transport = http.DefaultTransport
+	}
+	if p.userAgent == "" {
+		p.userAgent = DefaultUserAgent
+	}
+	if p.hostConcurrency <= 0 {
+		p.hostConcurrency = DefaultHostConcurrency
+	}
+	if p.robotsTTL <= 0 {
+		p.robotsTTL = DefaultRobotsTTL
+	}
+	return p
+}
+
+// Client returns an http.Client that sends every request through the policy.
+func (p *Policy) Client(timeout time.Duration) *http.Client {
+	return &http.Client{Transport: p, Timeout: timeout}
+}
+
+func (p *Policy) UserAgent() string {
+	return p.userAgent
+}
+
+func (p *Policy) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", p.userAgent)
+	if req.URL.Path == "/robots.txt" {
+		return p.transport.RoundTrip(req)
+	}
+
+	state := p.host(req.URL)
+	rules, err := p.rules(req.Context(), state, req.URL)
+	if err != nil {
+		return nil, err
+	}
+	if !rules.allowed(req.URL.RequestURI()) {
+		return nil, fmt.Errorf("%w: %s", ErrBlockedByRobots, req.URL.Redacted())
+	}
+
+	select {
+	case state.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	release := func() { <-state.slots }
+
+	if err := p.pace(req.Context(), state, rules.crawlDelay); err != nil {
+		release()
+		return nil, err
+	}
+	resp, err := p.transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// The slot is held until the body is closed so slow downloads count against the limit.
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+func (p *Policy) host(u *url.URL) *hostState {
+	key := strings.ToLower(u.Scheme + "://" + u.Host)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state, ok := p.hosts[key]
+	if !ok {
+		state = &hostState{slots: make(chan struct{}, p.hostConcurrency)}
+		p.hosts[key] = state
+	}
+	return state
+}
+
+// rules returns the cached robots.txt rules for the request's host, fetching them when stale.
+// A 4xx robots.txt allows everything, a 5xx disallows everything for a while, and a network
+// error fails the request without caching. Redirects are followed as RFC 9309 asks.
+func (p *Policy) rules(ctx context.Context, state *hostState, target *url.URL) (robotsRules, error) {
+	state.robotsMu.Lock()
+	defer state.robotsMu.Unlock()
+	now := p.now()
+	if now.Before(state.robotsExpires) {
+		return state.robots, nil
+	}
+
+	robotsURL := &url.URL{Scheme: target.Scheme, Host: target.Host, Path: "/robots.txt"}
+	resp, err := p.fetchRobots(ctx, robotsURL)
+	if err != nil {
+		return robotsRules{}, fmt.Errorf("fetch robots.txt for %s: %w", target.Host, err)
+	}
+	defer resp.Body.Close()
+
+	ttl := p.robotsTTL
+	switch {
+	case resp.StatusCode >= 500:
+		state.robots = robotsRules{disallowed: true}
+		ttl = robotsErrorTTL
+	case resp.StatusCode >= 300:
+		// 4xx, or a redirect chain longer than maxRobotsRedirects: robots.txt is unavailable.
+		state.robots = robotsRules{}
+	default:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, robotsBodyLimit))
+		if err != nil {
+			return robotsRules{}, err
+		}
+		state.robots = parseRobots(body, p.userAgent)
+	}
+	state.robotsExpires = now.Add(ttl)
+	return state.robots, nil
+}
+
+// fetchRobots GETs robots.txt, following up to maxRobotsRedirects redirects. The last
+// response is returned as is, so a longer chain ends on a 3xx.
+func (p *Policy) fetchRobots(ctx context.Context, robotsURL *url.URL) (*http.Response, error) {
+	for redirects := 0; ; redirects++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", p.userAgent)
+		resp, err := p.transport.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		location := resp.Header.Get("Location")
+		if resp.StatusCode < 300 || resp.StatusCode >= 400 || location == "" || redirects == maxRobotsRedirects {
+			return resp, nil
+		}
+		resp.Body.Close()
+		next, err := robotsURL.Parse(location)
+		if err != nil {
+			return nil, err
+		}
+		robotsURL = next
+	}
+}
+
+// pace waits until the host's next request slot, spacing requests by Crawl-delay (capped at
+// a minute) or the configured minimum delay. A request that cannot get its turn before its
+// deadline fails at once, and one cancelled while waiting gives its turn back.
+func (p *Policy) pace(ctx context.Context, state *hostState, crawlDelay time.Duration) error {
+	delay := p.minDelay
+	if crawlDelay > delay {
+		delay = crawlDelay
+	}
+	if delay > maxCrawlDelay {
+		delay = maxCrawlDelay
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	state.paceMu.Lock()
+	now := p.now()
+	start := state.nextAt
+	if start.Before(now) {
+		start = now
+	}
+	wait := start.Sub(now)
+	if deadline, ok := ctx.Deadline(); ok && wait > time.Until(deadline) {
+		state.paceMu.Unlock()
+		return fmt.Errorf("%w: next request to the host allowed in %s", context.DeadlineExceeded, wait)
+	}
+	reserved := start.Add(delay)
+	state.nextAt = reserved
+	state.paceMu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Requests queued behind this one already hold later turns; only the last one can
+		// hand its turn back without letting two requests through together.
+		state.paceMu.Lock()
+		if state.nextAt.Equal(reserved) {
+			state.nextAt = start
+		}
+		state.paceMu.Unlock()
+		return ctx.Err()
+	}
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,180 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPolicy_BlocksDisallowedPathsAndSendsUserAgent(t *testing.T) {
+	var robotsHits int32
+	var mu sync.Mutex
+	agents := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		agents = append(agents, r.UserAgent())
+		mu.Unlock()
+		if r.URL.Path == "/robots.txt" {
+			atomic.AddInt32(&robotsHits, 1)
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /private/\n"))
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := NewPolicy(Config{UserAgent: "test-bot/1.0"}).Client(5 * time.Second)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/news/1", nil)
+	req.Header.Set("User-Agent", "something-else")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("allowed fetch failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := client.Get(srv.URL + "/private/a"); !errors.Is(err, ErrBlockedByRobots) {
+		t.Fatalf("expected ErrBlockedByRobots, got %v", err)
+	}
+	if hits := atomic.LoadInt32(&robotsHits); hits != 1 {
+		t.Fatalf("expected robots.txt fetched once and cached, got %d", hits)
+	}
+	for _, agent := range agents {
+		if agent != "test-bot/1.0" {
+			t.Fatalf("expected configured user agent on every request, got %q", agent)
+		}
+	}
+}
+
+func TestPolicy_ServerErrorOnRobotsBlocksHost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := NewPolicy(Config{}).Client(5 * time.Second)
+	if _, err := client.Get(srv.URL + "/news/1"); !errors.Is(err, ErrBlockedByRobots) {
+		t.Fatalf("expected 5xx robots.txt to block, got %v", err)
+	}
+}
+
+func TestPolicy_LimitsConcurrencyPerHost(t *testing.T) {
+	var inFlight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	client := NewPolicy(Config{HostConcurrency: 2}).Client(5 * time.Second)
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL + "/page")
+			if err != nil {
+				t.Errorf("fetch failed: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	if peak > 2 {
+		t.Fatalf("expected at most 2 concurrent requests, got %d", peak)
+	}
+}
+
+func TestPolicy_PacesRequestsByCrawlDelay(t *testing.T) {
+	p := NewPolicy(Config{})
+	state := &hostState{slots: make(chan struct{}, 1)}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	if err := p.pace(context.Background(), state, 0); err != nil {
+		t.Fatalf("pace without delay: %v", err)
+	}
+	if err := p.pace(context.Background(), state, 5*time.Second); err != nil {
+		t.Fatalf("first paced request should not wait: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.pace(ctx, state, 5*time.Second); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected second request to wait out the crawl delay, got %v", err)
+	}
+}
+
+func TestPolicy_FollowsRobotsRedirects(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			http.Redirect(w, r, "/static/robots.txt", http.StatusMovedPermanently)
+		case "/static/robots.txt":
+			_, _ = w.Write([]byte("User-agent: *\nDisallow: /private/\n"))
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewPolicy(Config{}).Client(5 * time.Second)
+	if _, err := client.Get(srv.URL + "/private/a"); !errors.Is(err, ErrBlockedByRobots) {
+		t.Fatalf("expected the redirected robots.txt obeyed, got %v", err)
+	}
+}
+
+func TestPolicy_PaceFailsFastPastDeadlineAndReturnsCancelledTurns(t *testing.T) {
+	p := NewPolicy(Config{})
+	state := &hostState{slots: make(chan struct{}, 1)}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	if err := p.pace(context.Background(), state, 5*time.Second); err != nil {
+		t.Fatalf("first paced request should not wait: %v", err)
+	}
+	taken := state.nextAt
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	began := time.Now()
+	if err := p.pace(ctx, state, 5*time.Second); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a turn past the deadline refused, got %v", err)
+	}
+	if time.Since(began) > 500*time.Millisecond {
+		t.Fatal("expected the refusal without waiting for the deadline")
+	}
+	if !state.nextAt.Equal(taken) {
+		t.Fatalf("expected no turn reserved by the refused request, got %v", state.nextAt)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if err := p.pace(ctx, state, 5*time.Second); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the wait cancelled, got %v", err)
+	}
+	if !state.nextAt.Equal(taken) {
+		t.Fatalf("expected the cancelled turn given back, got %v", state.nextAt)
+	}
+}
@@ -0,0 +1,147 @@
+package fetch
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// robotsRules is the group of a robots.txt file that applies to our user agent.
+type robotsRules struct {
+	rules      []robotsRule
+	crawlDelay time.Duration
+	disallowed bool // the whole site is off-limits, e.g. robots.txt answered 5xx
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+type robotsGroup struct {
+	agents     []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+// parseRobots reads a robots.txt body and keeps the group for userAgent: the group naming
+// the longest matching product token wins, then "*".
+func parseRobots(body []byte, userAgent string) robotsRules {
+	groups := make([]*robotsGroup, 0)
+	var current *robotsGroup
+	lastWasAgent := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if current == nil || !lastWasAgent {
+				current = &robotsGroup{}
+				groups = append(groups, current)
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			lastWasAgent = true
+		case "allow", "disallow":
+			lastWasAgent = false
+			if current == nil {
+				continue
+			}
+			// An empty Disallow allows everything and adds no rule.
+			if value == "" {
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{allow: key == "allow", pattern: value})
+		case "crawl-delay":
+			lastWasAgent = false
+			if current == nil {
+				continue
+			}
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+			}
+		default:
+			lastWasAgent = false
+		}
+	}
+
+	ua := strings.ToLower(userAgent)
+	var best *robotsGroup
+	bestLen := -1
+	for _, group := range groups {
+		for _, agent := range group.agents {
+			switch {
+			case agent == "*":
+				if bestLen < 0 {
+					best, bestLen = group, 0
+				}
+			case agent != "" && strings.Contains(ua, agent) && len(agent) > bestLen:
+				best, bestLen = group, len(agent)
+			}
+		}
+	}
+	if best == nil {
+		return robotsRules{}
+	}
+	return robotsRules{rules: best.rules, crawlDelay: best.crawlDelay}
+}
+
+// allowed applies the longest matching rule to path (path plus query); Allow wins ties.
+func (r robotsRules) allowed(path string) bool {
+	if r.disallowed {
+		return false
+	}
+	if path == "" {
+		path = "/"
+	}
+	matched := -1
+	allow := true
+	for _, rule := range r.rules {
+		if !matchRobotsPattern(rule.pattern, path) {
+			continue
+		}
+		length := len(rule.pattern)
+		if length > matched || (length == matched && rule.allow) {
+			matched = length
+			allow = rule.allow
+		}
+	}
+	return allow
+}
+
+// matchRobotsPattern supports the "*" wildcard and a trailing "$" end anchor.
+func matchRobotsPattern(pattern string, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	if len(parts) == 1 {
+		return !anchored || rest == ""
+	}
+	for i, part := range parts[1:] {
+		if anchored && i == len(parts)-2 {
+			return strings.HasSuffix(rest, part)
+		}
+		idx := strings.Index(rest, part)
+		if idx < 0 {
+			return false
+		}
+		rest = rest[idx+len(part):]
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package fetch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRobots_PicksMostSpecificGroup(t *testing.T) {
+	body := []byte(`
+# comments are ignored
+User-agent: *
+Disallow: /
+
+User-agent: w-mma-bot
+User-agent: otherbot
+Disallow: /private/
+Allow: /private/press$
+Disallow: /*.pdf$
+Crawl-delay: 2.5
+`)
+	rules := parseRobots(body, DefaultUserAgent)
+	if rules.crawlDelay != 2500*time.Millisecond {
+		t.Fatalf("expected crawl delay 2.5s, got %s", rules.crawlDelay)
+	}
+	cases := map[string]bool{
+		"/news/1":             true,
+		"/private/a":          false,
+		"/private/press":      true,
+		"/private/press/old":  false,
+		"/files/card.pdf":     false,
+		"/files/card.pdf?x=1": true,
+	}
+	for path, want := range cases {
+		if got := rules.allowed(path); got != want {
+			t.Fatalf("%s: expected allowed=%v, got %v", path, want, got)
+		}
+	}
+
+	other := parseRobots(body, "curl/8.0")
+	if other.allowed("/news/1") {
+		t.Fatalf("expected wildcard group to block unknown agents")
+	}
+}
+
+func TestParseRobots_EmptyDisallowAllowsEverything(t *testing.T) {
+	rules := parseRobots([]byte("User-agent: *\nDisallow:\n"), DefaultUserAgent)
+	if !rules.allowed("/anything") {
+		t.Fatalf("expected empty disallow to allow all")
+	}
+}
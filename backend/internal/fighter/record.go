@@ -0,0 +1,58 @@
+package fighter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	OutcomeWin       = "win"
+	OutcomeLoss      = "loss"
+	OutcomeDraw      = "draw"
+	OutcomeNoContest = "no_contest"
+)
+
+var (
+	recordPattern   = regexp.MustCompile(`^\s*(\d+)-(\d+)(?:-(\d+))?`)
+	noContestSuffix = regexp.MustCompile(`(?i)\(\s*(\d+)\s*NC\s*\)`)
+)
+
+// AdjustRecord moves one bout outcome in a "W-L-D (N NC)" record string, removing
+// the previous outcome and adding the new one. Unparseable records are returned unchanged.
+func AdjustRecord(record string, removed string, added string) string {
+	m := recordPattern.FindStringSubmatch(record)
+	if len(m) == 0 {
+		return record
+	}
+	wins, _ := strconv.Atoi(m[1])
+	losses, _ := strconv.Atoi(m[2])
+	draws, _ := strconv.Atoi(m[3])
+	noContests := 0
+	if nc := noContestSuffix.FindStringSubmatch(record); len(nc) > 1 {
+		noContests, _ = strconv.Atoi(nc[1])
+	}
+
+	counters := map[string]*int{
+		OutcomeWin:       &wins,
+		OutcomeLoss:      &losses,
+		OutcomeDraw:      &draws,
+		OutcomeNoContest: &noContests,
+	}
+	if counter, ok := counters[removed]; ok && *counter > 0 {
+		*counter--
+	}
+	if counter, ok := counters[added]; ok {
+		*counter++
+	}
+
+	out := fmt.Sprintf("%d-%d", wins, losses)
+	if m[3] != "" || draws > 0 {
+		out += fmt.Sprintf("-%d", draws)
+	}
+	if noContests > 0 {
+		out += fmt.Sprintf(" (%d NC)", noContests)
+	}
+	return strings.TrimSpace(out)
+}
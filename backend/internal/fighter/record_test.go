@@ -0,0 +1,24 @@
+package fighter
+
+import "testing"
+
+func TestAdjustRecord_MovesOutcome(t *testing.T) {
+	cases := []struct {
+		record  string
+		removed string
+		added   string
+		want    string
+	}{
+		{record: "10-2", removed: "", added: OutcomeWin, want: "11-2"},
+		{record: "19-1-1", removed: OutcomeWin, added: OutcomeNoContest, want: "18-1-1 (1 NC)"},
+		{record: "18-1-1 (1 NC)", removed: OutcomeNoContest, added: OutcomeLoss, want: "18-2-1"},
+		{record: "5-0", removed: OutcomeWin, added: OutcomeDraw, want: "4-0-1"},
+		{record: "0-0", removed: OutcomeLoss, added: "", want: "0-0"},
+		{record: "", removed: "", added: OutcomeWin, want: ""},
+	}
+	for _, tc := range cases {
+		if got := AdjustRecord(tc.record, tc.removed, tc.added); got != tc.want {
+			t.Fatalf("adjust %q -%s +%s: expected %q, got %q", tc.record, tc.removed, tc.added, tc.want, got)
+		}
+	}
+}
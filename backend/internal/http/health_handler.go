@@ -11,13 +11,19 @@ import (
 	"github.com/bajiaozhi/w-mma/backend/internal/auth"
 	"github.com/bajiaozhi/w-mma/backend/internal/compliance"
 	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/featured"
 	"github.com/bajiaozhi/w-mma/backend/internal/fighter"
 	"github.com/bajiaozhi/w-mma/backend/internal/ingest"
+	"github.com/bajiaozhi/w-mma/backend/internal/live"
 	"github.com/bajiaozhi/w-mma/backend/internal/media"
 	"github.com/bajiaozhi/w-mma/backend/internal/review"
+	"github.com/bajiaozhi/w-mma/backend/internal/revision"
+	"github.com/bajiaozhi/w-mma/backend/internal/search"
 	"github.com/bajiaozhi/w-mma/backend/internal/source"
 	"github.com/bajiaozhi/w-mma/backend/internal/summary"
 	"github.com/bajiaozhi/w-mma/backend/internal/takedown"
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
+	"github.com/bajiaozhi/w-mma/backend/internal/translate"
 	"github.com/bajiaozhi/w-mma/backend/internal/ufc"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -32,7 +38,9 @@ func RegisterRoutes(r *gin.Engine) {
 	worker := ingest.NewWorker(queue, &reviewIngestAdapter{repo: reviewRepo}, ingest.NewDefaultParserRegistry(nil))
 	publisher := &immediatePublisher{queue: queue, worker: worker}
 	passwordHash, _ := bcrypt.GenerateFromPassword([]byte("admin123456"), bcrypt.DefaultCost)
-	authSvc := auth.NewService(auth.NewStaticUserRepository("admin", string(passwordHash)), "test-secret")
+	userRepo := auth.NewInMemoryUserRepository()
+	_, _ = auth.BootstrapUser(context.Background(), userRepo, "admin", string(passwordHash))
+	authSvc := auth.NewService(userRepo, "test-secret")
 	sourceSvc := source.NewService(source.NewInMemoryRepository())
 	mediaSvc := media.NewService(media.NewInMemoryRepository())
 	summarySvc := summary.NewService(summary.NewInMemoryRepository(), summary.Config{
@@ -40,20 +48,25 @@ func RegisterRoutes(r *gin.Engine) {
 		APIKey:   "",
 	})
 	takedownSvc := takedown.NewService(takedown.NewInMemoryRepository(), &noopOffliner{}, nil)
+	translationSvc := translate.NewService(translate.NewInMemoryRepository(), sourceSvc, translate.Config{})
 
 	RegisterRoutesWithDependencies(r, Dependencies{
-		ReviewService:   reviewSvc,
-		PendingCreator:  reviewRepo,
-		PublishedRepo:   reviewRepo,
-		EventService:    eventSvc,
-		FighterService:  fighterSvc,
-		IngestPublisher: publisher,
-		AuthService:     authSvc,
-		SourceService:   sourceSvc,
-		MediaService:    mediaSvc,
-		SummaryService:  summarySvc,
-		TakedownService: takedownSvc,
-		AdminJWTSecret:  "test-secret",
+		ReviewService:      reviewSvc,
+		PendingCreator:     reviewRepo,
+		PublishedRepo:      reviewRepo,
+		EntityArticles:     reviewRepo,
+		EventService:       eventSvc,
+		FighterService:     fighterSvc,
+		IngestPublisher:    publisher,
+		AuthService:        authSvc,
+		SourceService:      sourceSvc,
+		MediaService:       mediaSvc,
+		SummaryService:     summarySvc,
+		TakedownService:    takedownSvc,
+		TranslationService: translationSvc,
+		TagService:         taxonomy.NewService(taxonomy.NewInMemoryRepository()),
+		FeaturedService:    featured.NewService(featured.NewInMemoryRepository(), reviewRepo, eventSvc),
+		AdminJWTSecret:     "test-secret",
 	})
 }
 
@@ -70,7 +83,11 @@ func RegisterRoutesWithDependencies(r *gin.Engine, deps Dependencies) {
 	})
 
 	if deps.AdminJWTSecret != "" {
-		adminAuthMiddleware := auth.RequireAdminAuth(deps.AdminJWTSecret)
+		var sessions []auth.SessionChecker
+		if deps.AuthService != nil {
+			sessions = append(sessions, deps.AuthService)
+		}
+		adminAuthMiddleware := auth.RequireAdminAuth(deps.AdminJWTSecret, sessions...)
 		r.Use(func(c *gin.Context) {
 			path := c.Request.URL.Path
 			if strings.HasPrefix(path, "/admin/") && path != "/admin/auth/login" {
@@ -83,6 +100,7 @@ func RegisterRoutesWithDependencies(r *gin.Engine, deps Dependencies) {
 
 	if deps.AuthService != nil {
 		auth.RegisterAdminAuthRoutes(r, deps.AuthService)
+		auth.RegisterAdminUserRoutes(r, deps.AuthService)
 	}
 	if deps.SourceService != nil {
 		source.RegisterAdminSourceRoutes(r, deps.SourceService)
@@ -96,21 +114,54 @@ func RegisterRoutesWithDependencies(r *gin.Engine, deps Dependencies) {
 	if deps.TakedownService != nil {
 		takedown.RegisterAdminTakedownRoutes(r, deps.TakedownService)
 	}
+	if deps.TranslationService != nil {
+		translate.RegisterAdminTranslationRoutes(r, deps.TranslationService)
+	}
+	if deps.TagService != nil {
+		taxonomy.RegisterTagRoutes(r, deps.TagService)
+		taxonomy.RegisterAdminTagRoutes(r, deps.TagService)
+	}
+	if deps.SearchService != nil {
+		search.RegisterSearchRoutes(r, deps.SearchService)
+	}
+	if deps.FeaturedService != nil {
+		featured.RegisterHomeRoutes(r, deps.FeaturedService)
+		featured.RegisterAdminFeaturedRoutes(r, deps.FeaturedService)
+	}
+	if deps.RevisionService != nil {
+		revision.RegisterAdminRevisionRoutes(r, deps.RevisionService)
+	}
 	if deps.UFCSyncService != nil {
 		ufc.RegisterAdminRoutes(r, deps.UFCSyncService)
 	}
+	if deps.LiveControl != nil {
+		live.RegisterAdminLiveRoutes(r, deps.LiveControl)
+	}
+	if deps.LiveScoring != nil {
+		live.RegisterAdminLiveScoringRoutes(r, deps.LiveScoring)
+	}
 
 	review.RegisterAdminReviewRoutes(r, deps.ReviewService)
 	if deps.PendingCreator != nil {
 		review.RegisterAdminManualArticleRoutes(r, deps.PendingCreator)
 	}
 	playbackPolicy := compliance.NewPlaybackPolicy(deps.SourceService)
-	review.RegisterPublicContentRoutes(r, deps.PublishedRepo, playbackPolicy)
+	var articleMedia review.MediaLister
+	if deps.MediaService != nil {
+		articleMedia = deps.MediaService
+	}
+	review.RegisterPublicContentRoutes(r, deps.PublishedRepo, articleMedia, deps.FeedCache, playbackPolicy)
+	if deps.EntityArticles != nil {
+		review.RegisterEntityArticleRoutes(r, deps.EntityArticles, playbackPolicy)
+	}
 	event.RegisterEventRoutes(r, deps.EventService)
 	event.RegisterAdminEventRoutes(r, deps.EventService)
 	fighter.RegisterFighterRoutes(r, deps.FighterService)
 	fighter.RegisterAdminFighterRoutes(r, deps.FighterService)
 	ingest.RegisterAdminIngestRoutes(r, deps.IngestPublisher, deps.SourceService)
+	if deps.DeadLetters != nil {
+		ingest.RegisterAdminDeadLetterRoutes(r, deps.DeadLetters)
+	}
 }
 
 func corsMiddleware() gin.HandlerFunc {
@@ -134,6 +185,6 @@ func corsMiddleware() gin.HandlerFunc {
 
 type noopOffliner struct{}
 
-func (n *noopOffliner) OfflineArticle(context.Context, int64) error {
+func (n *noopOffliner) OfflineArticle(context.Context, int64, int64) error {
 	return nil
 }
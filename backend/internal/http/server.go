@@ -7,29 +7,51 @@ import (
 
 	"github.com/bajiaozhi/w-mma/backend/internal/auth"
 	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/featured"
 	"github.com/bajiaozhi/w-mma/backend/internal/fighter"
 	"github.com/bajiaozhi/w-mma/backend/internal/ingest"
+	"github.com/bajiaozhi/w-mma/backend/internal/live"
 	"github.com/bajiaozhi/w-mma/backend/internal/media"
 	"github.com/bajiaozhi/w-mma/backend/internal/review"
+	"github.com/bajiaozhi/w-mma/backend/internal/revision"
+	"github.com/bajiaozhi/w-mma/backend/internal/search"
 	"github.com/bajiaozhi/w-mma/backend/internal/source"
 	"github.com/bajiaozhi/w-mma/backend/internal/summary"
 	"github.com/bajiaozhi/w-mma/backend/internal/takedown"
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
+	"github.com/bajiaozhi/w-mma/backend/internal/translate"
 	"github.com/bajiaozhi/w-mma/backend/internal/ufc"
 )
 
 type Dependencies struct {
-	ReviewService   *review.Service
-	PendingCreator  review.PendingCreator
-	PublishedRepo   review.PublishedRepository
+	ReviewService  *review.Service
+	PendingCreator review.PendingCreator
+	PublishedRepo  review.PublishedRepository
+	EntityArticles review.EntityArticleRepository
+	// FeedCache keeps public feed pages; nil serves every page from the repository.
+	FeedCache review.FeedCache
+	// TagService serves the tag routes; nil disables them.
+	TagService *taxonomy.Service
+	// SearchService serves /api/search; nil disables it.
+	SearchService *search.Service
+	// FeaturedService serves the featured slots and the home screen; nil disables them.
+	FeaturedService *featured.Service
 	EventService    *event.Service
 	FighterService  *fighter.Service
 	IngestPublisher ingest.FetchPublisher
+	DeadLetters     ingest.DeadLetterStore
 	AuthService     *auth.Service
 	SourceService   *source.Service
 	MediaService    *media.Service
 	SummaryService  *summary.Service
 	TakedownService *takedown.Service
+	// TranslationService serves the translation job routes; nil disables them.
+	TranslationService *translate.Service
+	// RevisionService serves published article edits and their history; nil disables them.
+	RevisionService *revision.Service
 	UFCSyncService  *ufc.Service
+	LiveControl     live.ControlStore
+	LiveScoring     *live.ManualScoring
 	AdminJWTSecret  string
 	MediaCacheDir   string
 }
@@ -56,6 +78,7 @@ func (a *reviewIngestAdapter) SavePending(ctx context.Context, rec ingest.Pendin
 		Title:     rec.Title,
 		Summary:   rec.Summary,
 		SourceURL: rec.SourceURL,
+		Tags:      rec.Tags,
 	})
 	return err
 }
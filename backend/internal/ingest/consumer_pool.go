@@ -0,0 +1,82 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/queue"
+)
+
+// staleConsumerIdle is how long a consumer with nothing pending may sit unread before a
+// starting pool removes it as left over from a worker that is gone.
+const staleConsumerIdle = time.Hour
+
+// ConsumerPool runs several stream consumers of the fetch stream in parallel. Each goroutine
+// reads as its own consumer (namePrefix-1, namePrefix-2, ...), so a crashed process only
+// strands its own pending entries and they can be reclaimed by the rest of the group.
+type ConsumerPool struct {
+	stream     *queue.StreamQueue
+	size       int
+	namePrefix string
+}
+
+func NewConsumerPool(stream *queue.StreamQueue, size int, namePrefix string) *ConsumerPool {
+	if size <= 0 {
+		size = 1
+	}
+	if namePrefix == "" {
+		namePrefix = "worker"
+	}
+	return &ConsumerPool{stream: stream, size: size, namePrefix: namePrefix}
+}
+
+// ConsumerNames lists the consumer names the pool reads as.
+func (p *ConsumerPool) ConsumerNames() []string {
+	names := make([]string, 0, p.size)
+	for i := 1; i <= p.size; i++ {
+		names = append(names, fmt.Sprintf("%s-%d", p.namePrefix, i))
+	}
+	return names
+}
+
+// Run consumes until ctx is cancelled, then waits for every consumer to finish the batch it
+// already read. Jobs get a context that survives the cancellation so in-flight fetches drain
+// instead of failing half-way.
+//
+// Consumer names are per host, so the group would collect one set per worker ever started.
+// Run removes stale consumers of other workers when it starts and its own on the way out,
+// keeping any that still hold pending entries for the reclaim pass.
+func (p *ConsumerPool) Run(ctx context.Context, handle func(context.Context, FetchJob) error) {
+	jobCtx := context.WithoutCancel(ctx)
+	if deleted, err := p.stream.DeleteIdleConsumers(ctx, staleConsumerIdle); err != nil {
+		log.Printf("delete stale ingest consumers failed: %v", err)
+	} else if deleted > 0 {
+		log.Printf("deleted %d stale ingest consumers", deleted)
+	}
+	var wg sync.WaitGroup
+	for _, name := range p.ConsumerNames() {
+		consumer := NewStreamConsumer(p.stream.ForConsumer(name))
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				err := consumer.ConsumeOnce(ctx, func(job FetchJob) error {
+					return handle(jobCtx, job)
+				})
+				if err != nil && ctx.Err() == nil {
+					log.Printf("consume ingest stream failed consumer=%s: %v", name, err)
+				}
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	for _, name := range p.ConsumerNames() {
+		if _, err := p.stream.ForConsumer(name).DeleteConsumer(jobCtx); err != nil {
+			log.Printf("delete ingest consumer %s failed: %v", name, err)
+		}
+	}
+}
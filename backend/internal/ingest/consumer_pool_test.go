@@ -0,0 +1,70 @@
+package ingest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/queue"
+)
+
+func TestConsumerPool_SpreadsJobsAcrossNamedConsumersAndDrains(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mini.Close()
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	defer client.Close()
+
+	stream := queue.NewStreamQueue(client, FetchStreamName, "worker", "test", queue.WithBatchSize(2))
+	if err := stream.EnsureGroup(context.Background()); err != nil {
+		t.Fatalf("ensure group failed: %v", err)
+	}
+	publisher := NewStreamPublisher(stream)
+	for i := 0; i < 6; i++ {
+		if err := publisher.Enqueue(context.Background(), FetchJob{SourceID: int64(i + 1), URL: "https://example.com/a"}); err != nil {
+			t.Fatalf("enqueue failed: %v", err)
+		}
+	}
+
+	pool := NewConsumerPool(stream, 3, "host")
+	if names := pool.ConsumerNames(); len(names) != 3 || names[0] != "host-1" || names[2] != "host-3" {
+		t.Fatalf("unexpected consumer names: %v", names)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	seen := map[int64]bool{}
+	pool.Run(ctx, func(jobCtx context.Context, job FetchJob) error {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[job.SourceID] = true
+		if len(seen) == 6 {
+			// Shutdown arrives while jobs are still in flight; they keep a live context.
+			cancel()
+		}
+		if jobCtx.Err() != nil {
+			t.Errorf("expected job context to survive shutdown")
+		}
+		return nil
+	})
+
+	if len(seen) != 6 {
+		t.Fatalf("expected all 6 jobs handled, got %d", len(seen))
+	}
+	pending, err := client.XPending(context.Background(), FetchStreamName, "worker").Result()
+	if err != nil {
+		t.Fatalf("xpending failed: %v", err)
+	}
+	if pending.Count != 0 {
+		t.Fatalf("expected every handled job acked, got %d pending", pending.Count)
+	}
+	consumers, err := client.XInfoConsumers(context.Background(), FetchStreamName, "worker").Result()
+	if err != nil || len(consumers) != 0 {
+		t.Fatalf("expected the pool to remove its consumers on shutdown, got %+v %v", consumers, err)
+	}
+}
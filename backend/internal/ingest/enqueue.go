@@ -7,6 +7,10 @@ type FetchJob struct {
 	SourceID   int64
 	URL        string
 	ParserKind string
+	// Depth counts listing hops from the source's configured page; 0 for the page itself.
+	Depth int
+	// RunID links a scheduled fetch to its ingest_runs row; 0 for manual and child jobs.
+	RunID int64
 }
 
 // Queue is the ingest job queue abstraction.
@@ -0,0 +1,244 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"html"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+var (
+	ErrFeedEmpty = errors.New("feed has no items")
+
+	feedTagPattern = regexp.MustCompile(`(?s)<[^>]*>`)
+	feedImgPattern = regexp.MustCompile(`(?is)<img[^>]+src=["']([^"']+)["']`)
+
+	feedTimeLayouts = []string{
+		time.RFC1123Z,
+		time.RFC1123,
+		"Mon, 2 Jan 2006 15:04:05 -0700",
+		"Mon, 2 Jan 2006 15:04:05 MST",
+		"2 Jan 2006 15:04:05 -0700",
+		time.RFC3339,
+		"2006-01-02T15:04:05",
+		"2006-01-02",
+	}
+)
+
+// FeedParser reads RSS 2.0 and Atom feeds and yields one pending record per item.
+type FeedParser struct {
+	client *http.Client
+}
+
+func NewFeedParser(client *http.Client) *FeedParser {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &FeedParser{client: client}
+}
+
+func (p *FeedParser) ParseURL(ctx context.Context, url string) (PendingRecord, error) {
+	items, err := p.ParseURLItems(ctx, url)
+	if err != nil {
+		return PendingRecord{}, err
+	}
+	return items[0], nil
+}
+
+func (p *FeedParser) ParseURLItems(ctx context.Context, url string) ([]PendingRecord, error) {
+	body, err := fetchBody(ctx, p.client, url)
+	if err != nil {
+		return nil, err
+	}
+	items, err := parseFeed(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrFeedEmpty
+	}
+	return items, nil
+}
+
+type feedDocument struct {
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssItem struct {
+	Title       string      `xml:"title"`
+	Link        string      `xml:"link"`
+	GUID        string      `xml:"guid"`
+	Description string      `xml:"description"`
+	PubDate     string      `xml:"pubDate"`
+	Enclosures  []feedMedia `xml:"enclosure"`
+	Contents    []feedMedia `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails  []feedMedia `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	ID        string     `xml:"id"`
+	Links     []atomLink `xml:"link"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type feedMedia struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+func parseFeed(body []byte) ([]PendingRecord, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = charset.NewReaderLabel
+	decoder.Strict = false
+
+	var doc feedDocument
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	items := make([]PendingRecord, 0, len(doc.Channel.Items)+len(doc.Entries))
+	for _, item := range doc.Channel.Items {
+		if rec, ok := rssRecord(item); ok {
+			items = append(items, rec)
+		}
+	}
+	for _, entry := range doc.Entries {
+		if rec, ok := atomRecord(entry); ok {
+			items = append(items, rec)
+		}
+	}
+	return items, nil
+}
+
+func rssRecord(item rssItem) (PendingRecord, bool) {
+	link := strings.TrimSpace(item.Link)
+	if link == "" {
+		return PendingRecord{}, false
+	}
+	media := make([]feedMedia, 0, len(item.Enclosures)+len(item.Contents)+len(item.Thumbnails))
+	media = append(media, item.Contents...)
+	media = append(media, item.Thumbnails...)
+	media = append(media, item.Enclosures...)
+
+	cover := firstImage(media)
+	if cover == "" {
+		cover = firstInlineImage(item.Description)
+	}
+	return feedRecord(item.GUID, item.Title, item.Description, link, cover, item.PubDate), true
+}
+
+func atomRecord(entry atomEntry) (PendingRecord, bool) {
+	link := ""
+	media := make([]feedMedia, 0)
+	for _, l := range entry.Links {
+		switch strings.ToLower(strings.TrimSpace(l.Rel)) {
+		case "", "alternate":
+			if link == "" {
+				link = strings.TrimSpace(l.Href)
+			}
+		case "enclosure":
+			media = append(media, feedMedia{URL: l.Href, Type: l.Type})
+		}
+	}
+	if link == "" {
+		return PendingRecord{}, false
+	}
+
+	summary := entry.Summary
+	if strings.TrimSpace(summary) == "" {
+		summary = entry.Content
+	}
+	cover := firstImage(media)
+	if cover == "" {
+		cover = firstInlineImage(entry.Content)
+	}
+	published := entry.Published
+	if strings.TrimSpace(published) == "" {
+		published = entry.Updated
+	}
+	return feedRecord(entry.ID, entry.Title, summary, link, cover, published), true
+}
+
+func feedRecord(guid string, title string, summary string, link string, cover string, published string) PendingRecord {
+	rec := PendingRecord{
+		GUID:      strings.TrimSpace(guid),
+		Title:     cleanFeedText(title),
+		Summary:   cleanFeedText(summary),
+		SourceURL: link,
+		CoverURL:  strings.TrimSpace(cover),
+	}
+	if rec.Title == "" {
+		rec.Title = link
+	}
+	if rec.Summary == "" {
+		rec.Summary = rec.Title
+	}
+	if ts, ok := parseFeedTime(published); ok {
+		rec.PublishedAt = &ts
+	}
+	return rec
+}
+
+func firstImage(media []feedMedia) string {
+	for _, m := range media {
+		url := strings.TrimSpace(m.URL)
+		if url == "" {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(m.Type), "image/") || strings.EqualFold(m.Medium, "image") {
+			return url
+		}
+		if m.Type == "" && m.Medium == "" {
+			return url
+		}
+	}
+	return ""
+}
+
+func firstInlineImage(markup string) string {
+	m := feedImgPattern.FindStringSubmatch(markup)
+	if len(m) < 2 {
+		return ""
+	}
+	return html.UnescapeString(m[1])
+}
+
+func cleanFeedText(raw string) string {
+	text := feedTagPattern.ReplaceAllString(raw, " ")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+}
+
+func parseFeedTime(raw string) (time.Time, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range feedTimeLayouts {
+		if ts, err := time.Parse(layout, raw); err == nil {
+			return ts.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,83 @@
+package ingest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const sampleRSS = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:media="http://search.yahoo.com/mrss/">
+<channel>
+  <title>MMA News</title>
+  <item>
+    <title>Main event &amp; co-main confirmed</title>
+    <link>https://news.example.com/a</link>
+    <guid isPermaLink="false">news-a</guid>
+    <description><![CDATA[<p>Card <b>locked</b> in.</p>]]></description>
+    <pubDate>Sat, 21 Feb 2026 10:30:00 +0000</pubDate>
+    <media:content url="https://img.example.com/a.jpg" medium="image"/>
+  </item>
+  <item>
+    <title>Weigh-in results</title>
+    <link>https://news.example.com/b</link>
+    <description><![CDATA[<img src="https://img.example.com/b.jpg"/> All fighters on weight.]]></description>
+  </item>
+</channel>
+</rss>`
+
+const sampleAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Fight Blog</title>
+  <entry>
+    <title>Post-fight notes</title>
+    <id>tag:blog.example.com,2026:1</id>
+    <link rel="alternate" href="https://blog.example.com/1"/>
+    <link rel="enclosure" type="image/png" href="https://blog.example.com/1.png"/>
+    <updated>2026-02-22T08:00:00Z</updated>
+    <summary>Three takeaways.</summary>
+  </entry>
+</feed>`
+
+func TestFeedParser_ParsesRSSItems(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(sampleRSS))
+	}))
+	defer srv.Close()
+
+	items, err := NewFeedParser(srv.Client()).ParseURLItems(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatalf("parse rss: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(items))
+	}
+	first := items[0]
+	if first.GUID != "news-a" || first.Title != "Main event & co-main confirmed" || first.Summary != "Card locked in." {
+		t.Fatalf("unexpected first item %+v", first)
+	}
+	if first.CoverURL != "https://img.example.com/a.jpg" || first.PublishedAt == nil || first.PublishedAt.Day() != 21 {
+		t.Fatalf("expected cover and published time, got %+v", first)
+	}
+	if items[1].CoverURL != "https://img.example.com/b.jpg" || items[1].SourceURL != "https://news.example.com/b" {
+		t.Fatalf("expected inline image cover, got %+v", items[1])
+	}
+}
+
+func TestFeedParser_ParsesAtomEntries(t *testing.T) {
+	items, err := parseFeed([]byte(sampleAtom))
+	if err != nil {
+		t.Fatalf("parse atom: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(items))
+	}
+	entry := items[0]
+	if entry.GUID != "tag:blog.example.com,2026:1" || entry.SourceURL != "https://blog.example.com/1" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+	if entry.CoverURL != "https://blog.example.com/1.png" || entry.PublishedAt == nil {
+		t.Fatalf("expected enclosure cover and updated time, got %+v", entry)
+	}
+}
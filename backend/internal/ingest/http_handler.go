@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/bajiaozhi/w-mma/backend/internal/queue"
 	"github.com/bajiaozhi/w-mma/backend/internal/source"
 )
 
@@ -16,6 +18,13 @@ type fetchRequest struct {
 	ParserKind string `json:"parser_kind"`
 }
 
+// DeadLetterStore lists, replays and drops fetch jobs that exhausted their retries.
+type DeadLetterStore interface {
+	List(ctx context.Context, limit int64) ([]DeadLetterJob, error)
+	Replay(ctx context.Context, id string) error
+	Drop(ctx context.Context, id string) error
+}
+
 type SourceReader interface {
 	Get(ctx context.Context, sourceID int64) (source.DataSource, error)
 }
@@ -70,3 +79,43 @@ func RegisterAdminIngestRoutes(r *gin.Engine, publisher FetchPublisher, sourceRe
 		c.JSON(http.StatusOK, gin.H{"ok": true})
 	})
 }
+
+func RegisterAdminDeadLetterRoutes(r *gin.Engine, store DeadLetterStore) {
+	r.GET("/admin/ingest/dead-letters", func(c *gin.Context) {
+		limit := int64(50)
+		if raw := c.Query("limit"); raw != "" {
+			parsed, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil || parsed <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+				return
+			}
+			limit = parsed
+		}
+		items, err := store.List(c.Request.Context(), limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": items})
+	})
+
+	r.POST("/admin/ingest/dead-letters/:id/replay", func(c *gin.Context) {
+		writeDeadLetterResult(c, store.Replay(c.Request.Context(), c.Param("id")))
+	})
+
+	r.DELETE("/admin/ingest/dead-letters/:id", func(c *gin.Context) {
+		writeDeadLetterResult(c, store.Drop(c.Request.Context(), c.Param("id")))
+	})
+}
+
+func writeDeadLetterResult(c *gin.Context, err error) {
+	if errors.Is(err, queue.ErrDeadLetterNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
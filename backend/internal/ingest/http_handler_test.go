@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/bajiaozhi/w-mma/backend/internal/queue"
 	"github.com/bajiaozhi/w-mma/backend/internal/source"
 )
 
@@ -65,3 +66,79 @@ func TestAdminIngestFetch_UsesSourceURLWhenURLMissing(t *testing.T) {
 		t.Fatalf("expected parser kind from source, got %q", pub.job.ParserKind)
 	}
 }
+
+type fakeDeadLetterStore struct {
+	items    []DeadLetterJob
+	replayed []string
+	dropped  []string
+}
+
+func (f *fakeDeadLetterStore) List(_ context.Context, _ int64) ([]DeadLetterJob, error) {
+	return f.items, nil
+}
+
+func (f *fakeDeadLetterStore) Replay(_ context.Context, id string) error {
+	if !f.has(id) {
+		return queue.ErrDeadLetterNotFound
+	}
+	f.replayed = append(f.replayed, id)
+	return nil
+}
+
+func (f *fakeDeadLetterStore) Drop(_ context.Context, id string) error {
+	if !f.has(id) {
+		return queue.ErrDeadLetterNotFound
+	}
+	f.dropped = append(f.dropped, id)
+	return nil
+}
+
+func (f *fakeDeadLetterStore) has(id string) bool {
+	for _, item := range f.items {
+		if item.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAdminDeadLetters_ListReplayAndDrop(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &fakeDeadLetterStore{items: []DeadLetterJob{{
+		ID:         "1700000000000-0",
+		SourceID:   7,
+		URL:        "https://example.com/a",
+		ParserKind: "generic",
+		Error:      "upstream 500",
+		Deliveries: 5,
+	}}}
+	r := gin.New()
+	RegisterAdminDeadLetterRoutes(r, store)
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/admin/ingest/dead-letters?limit=10", nil))
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.Code)
+	}
+	var body struct {
+		Items []DeadLetterJob `json:"items"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode list failed: %v", err)
+	}
+	if len(body.Items) != 1 || body.Items[0].URL != "https://example.com/a" {
+		t.Fatalf("unexpected items: %+v", body.Items)
+	}
+
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/admin/ingest/dead-letters/1700000000000-0/replay", nil))
+	if resp.Code != http.StatusOK || len(store.replayed) != 1 {
+		t.Fatalf("expected replay, got %d %v", resp.Code, store.replayed)
+	}
+
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodDelete, "/admin/ingest/dead-letters/1-0", nil))
+	if resp.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown dead letter, got %d", resp.Code)
+	}
+}
@@ -0,0 +1,143 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+// ListingParserKind marks jobs whose URL is a listing page: the worker fans it out into
+// one child job per article link instead of saving a record.
+const ListingParserKind = "listing"
+
+// ListingPage holds the links found on a listing page and the next page, if any.
+type ListingPage struct {
+	Links []string
+	Next  string
+}
+
+// LinkExtractor is implemented by parsers that read listing pages.
+type LinkExtractor interface {
+	ExtractLinks(ctx context.Context, url string) (ListingPage, error)
+}
+
+// ListingParser extracts same-site article links from listing pages. Links in the site's
+// navigation, header and footer are skipped; the rel=next page link is read anywhere.
+type ListingParser struct {
+	client *http.Client
+	page   *HTTPParser
+}
+
+func NewListingParser(client *http.Client) *ListingParser {
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &ListingParser{client: client, page: NewHTTPParser(client)}
+}
+
+// ParseURL reads the listing page itself as a record, for registries that treat it as a plain page.
+func (p *ListingParser) ParseURL(ctx context.Context, url string) (PendingRecord, error) {
+	return p.page.ParseURL(ctx, url)
+}
+
+func (p *ListingParser) ExtractLinks(ctx context.Context, pageURL string) (ListingPage, error) {
+	body, err := fetchBody(ctx, p.client, pageURL)
+	if err != nil {
+		return ListingPage{}, err
+	}
+	return parseListingPage(body, pageURL)
+}
+
+func parseListingPage(body []byte, pageURL string) (ListingPage, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return ListingPage{}, err
+	}
+	reader, err := charset.NewReader(bytes.NewReader(body), "")
+	if err != nil {
+		return ListingPage{}, err
+	}
+	doc, err := html.Parse(reader)
+	if err != nil {
+		return ListingPage{}, err
+	}
+
+	var page ListingPage
+	seen := map[string]struct{}{canonicalLink(base): {}}
+	var walk func(n *html.Node, chrome bool, content bool)
+	walk = func(n *html.Node, chrome bool, content bool) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			switch child.DataAtom {
+			case atom.Article, atom.Main:
+				walk(child, chrome, true)
+				continue
+			case atom.Nav:
+				walk(child, true, content)
+				continue
+			case atom.Header, atom.Footer:
+				walk(child, chrome || !content, content)
+				continue
+			case atom.A, atom.Link:
+				link, ok := resolveSameSite(base, attr(child, "href"))
+				switch {
+				case !ok:
+				case hasRel(child, "next"):
+					if page.Next == "" {
+						page.Next = link
+					}
+				case child.DataAtom == atom.A && !chrome:
+					if _, dup := seen[link]; !dup {
+						seen[link] = struct{}{}
+						page.Links = append(page.Links, link)
+					}
+				}
+			}
+			walk(child, chrome, content)
+		}
+	}
+	walk(doc, false, false)
+	return page, nil
+}
+
+func resolveSameSite(base *url.URL, href string) (string, bool) {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return "", false
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+	abs := base.ResolveReference(ref)
+	if abs.Scheme != "http" && abs.Scheme != "https" {
+		return "", false
+	}
+	if !strings.EqualFold(abs.Hostname(), base.Hostname()) {
+		return "", false
+	}
+	return canonicalLink(abs), true
+}
+
+func canonicalLink(u *url.URL) string {
+	clean := *u
+	clean.Fragment = ""
+	return clean.String()
+}
+
+func hasRel(n *html.Node, rel string) bool {
+	for _, value := range strings.Fields(attr(n, "rel")) {
+		if strings.EqualFold(value, rel) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package ingest
+
+import "testing"
+
+func TestParseListingPage_KeepsSameSiteLinks(t *testing.T) {
+	body := []byte(`<html><head><link rel="next" href="/news?page=2"></head><body>
+<a href="/news">News</a>
+<a href="/news/ufc-327-preview#top">Preview</a>
+<a href="https://example.com/news/ufc-327-preview">Preview again</a>
+<a href="https://other.example.org/story">Elsewhere</a>
+<a href="mailto:desk@example.com">Mail</a>
+<a href="news/weigh-ins">Weigh-ins</a>
+</body></html>`)
+
+	page, err := parseListingPage(body, "https://example.com/news")
+	if err != nil {
+		t.Fatalf("parse listing: %v", err)
+	}
+	want := []string{"https://example.com/news/ufc-327-preview", "https://example.com/news/weigh-ins"}
+	if len(page.Links) != len(want) {
+		t.Fatalf("expected %v, got %v", want, page.Links)
+	}
+	for i := range want {
+		if page.Links[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, page.Links)
+		}
+	}
+	if page.Next != "https://example.com/news?page=2" {
+		t.Fatalf("expected next page link, got %q", page.Next)
+	}
+}
+
+func TestParseListingPage_SkipsSiteNavigation(t *testing.T) {
+	body := []byte(`<html><body>
+<header><a href="/">Home</a><a href="/tag/ufc">UFC</a></header>
+<nav><a href="/about">About</a></nav>
+<main>
+  <article><header><a href="/news/jones-retains-heavyweight-title">Jones retains</a></header></article>
+</main>
+<footer><a href="/privacy">Privacy</a><a rel="next" href="/news?page=2">Older</a></footer>
+</body></html>`)
+
+	page, err := parseListingPage(body, "https://example.com/news")
+	if err != nil {
+		t.Fatalf("parse listing: %v", err)
+	}
+	if len(page.Links) != 1 || page.Links[0] != "https://example.com/news/jones-retains-heavyweight-title" {
+		t.Fatalf("expected only the story link, got %v", page.Links)
+	}
+	if page.Next != "https://example.com/news?page=2" {
+		t.Fatalf("expected the footer's next page link, got %q", page.Next)
+	}
+}
@@ -0,0 +1,362 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	minParagraphLength  = 25
+	summaryExcerptRunes = 140
+)
+
+var (
+	positiveHintPattern = regexp.MustCompile(`(?i)article|body|content|entry|main|page|post|text|blog|story`)
+	negativeHintPattern = regexp.MustCompile(`(?i)comment|footer|sidebar|widget|nav|menu|share|social|related|promo|banner|sponsor|\bads?\b|\bad-|masthead|breadcrumb`)
+)
+
+// pageContent is what the generic parser can learn about an article page.
+type pageContent struct {
+	Title       string
+	Description string
+	Body        string
+	CoverURL    string
+	Author      string
+	PublishedAt *time.Time
+}
+
+// extractPage reads metadata (Open Graph, article:* and JSON-LD) and the main text of an
+// article page. The body uses a readability-style score: paragraphs vote for their parent
+// containers, weighted by text length, commas, class/id hints and link density.
+func extractPage(raw []byte, pageURL string) pageContent {
+	reader, err := charset.NewReader(bytes.NewReader(raw), "")
+	if err != nil {
+		return pageContent{}
+	}
+	doc, err := html.Parse(reader)
+	if err != nil {
+		return pageContent{}
+	}
+
+	var out pageContent
+	meta := map[string]string{}
+	var ldBlocks []string
+	walkNodes(doc, func(n *html.Node) bool {
+		switch n.DataAtom {
+		case atom.Meta:
+			key := strings.ToLower(attr(n, "property"))
+			if key == "" {
+				key = strings.ToLower(attr(n, "name"))
+			}
+			if key != "" && meta[key] == "" {
+				meta[key] = strings.TrimSpace(attr(n, "content"))
+			}
+		case atom.Script:
+			if strings.EqualFold(strings.TrimSpace(attr(n, "type")), "application/ld+json") {
+				ldBlocks = append(ldBlocks, nodeText(n))
+			}
+		case atom.Title:
+			if out.Title == "" {
+				out.Title = normalizeText(nodeText(n))
+			}
+		}
+		return true
+	})
+
+	ld := parseJSONLD(ldBlocks)
+	out.Title = firstNonEmpty(meta["og:title"], out.Title, ld.headline)
+	out.Description = normalizeText(firstNonEmpty(meta["og:description"], meta["description"], ld.description))
+	out.CoverURL = resolveURL(pageURL, firstNonEmpty(meta["og:image"], meta["og:image:url"], meta["twitter:image"], ld.image))
+	out.Author = normalizeText(firstNonEmpty(ld.author, meta["article:author"], meta["author"]))
+	if ts, ok := parseFeedTime(firstNonEmpty(meta["article:published_time"], ld.datePublished, meta["pubdate"])); ok {
+		out.PublishedAt = &ts
+	}
+	// article:author is often a profile URL rather than a name.
+	if strings.HasPrefix(out.Author, "http://") || strings.HasPrefix(out.Author, "https://") {
+		out.Author = normalizeText(meta["author"])
+	}
+	out.Body = extractMainText(doc)
+	return out
+}
+
+func extractMainText(doc *html.Node) string {
+	removeNodes(doc, func(n *html.Node) bool {
+		switch n.DataAtom {
+		case atom.Script, atom.Style, atom.Noscript, atom.Nav, atom.Header, atom.Footer,
+			atom.Aside, atom.Form, atom.Iframe, atom.Svg, atom.Button:
+			return true
+		}
+		if n.Type == html.CommentNode {
+			return true
+		}
+		switch n.DataAtom {
+		case atom.Html, atom.Body, atom.Main, atom.Article:
+			return false
+		}
+		return negativeHintPattern.MatchString(attr(n, "class")+" "+attr(n, "id")) &&
+			!positiveHintPattern.MatchString(attr(n, "class")+" "+attr(n, "id"))
+	})
+
+	scores := map[*html.Node]float64{}
+	order := make([]*html.Node, 0)
+	addScore := func(n *html.Node, value float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			scores[n] = hintWeight(n)
+			order = append(order, n)
+		}
+		scores[n] += value
+	}
+	walkNodes(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.P && n.DataAtom != atom.Pre && n.DataAtom != atom.Td {
+			return true
+		}
+		text := normalizeText(nodeText(n))
+		length := utf8.RuneCountInString(text)
+		if length < minParagraphLength {
+			return false
+		}
+		score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "，")) + minFloat(float64(length)/100, 3)
+		addScore(n.Parent, score)
+		if n.Parent != nil {
+			addScore(n.Parent.Parent, score/2)
+		}
+		return false
+	})
+	if len(order) == 0 {
+		return ""
+	}
+
+	for _, n := range order {
+		scores[n] *= 1 - linkDensity(n)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+	top := order[0]
+
+	paragraphs := make([]string, 0)
+	walkNodes(top, func(n *html.Node) bool {
+		switch n.DataAtom {
+		case atom.P, atom.Pre, atom.H2, atom.H3, atom.Blockquote, atom.Li:
+			if text := normalizeText(nodeText(n)); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+			return false
+		}
+		return true
+	})
+	return strings.Join(paragraphs, "\n\n")
+}
+
+func hintWeight(n *html.Node) float64 {
+	hint := attr(n, "class") + " " + attr(n, "id")
+	weight := 0.0
+	if positiveHintPattern.MatchString(hint) {
+		weight += 25
+	}
+	if negativeHintPattern.MatchString(hint) {
+		weight -= 25
+	}
+	switch n.DataAtom {
+	case atom.Article, atom.Main:
+		weight += 10
+	case atom.Div:
+		weight += 5
+	}
+	return weight
+}
+
+func linkDensity(n *html.Node) float64 {
+	total := utf8.RuneCountInString(normalizeText(nodeText(n)))
+	if total == 0 {
+		return 0
+	}
+	linked := 0
+	walkNodes(n, func(child *html.Node) bool {
+		if child.DataAtom == atom.A {
+			linked += utf8.RuneCountInString(normalizeText(nodeText(child)))
+			return false
+		}
+		return true
+	})
+	return float64(linked) / float64(total)
+}
+
+type jsonLDArticle struct {
+	headline      string
+	description   string
+	image         string
+	author        string
+	datePublished string
+}
+
+func parseJSONLD(blocks []string) jsonLDArticle {
+	var out jsonLDArticle
+	for _, block := range blocks {
+		var payload any
+		if err := json.Unmarshal([]byte(strings.TrimSpace(block)), &payload); err != nil {
+			continue
+		}
+		for _, node := range flattenJSONLD(payload) {
+			if out.headline == "" {
+				out.headline = jsonLDString(node["headline"])
+			}
+			if out.description == "" {
+				out.description = jsonLDString(node["description"])
+			}
+			if out.image == "" {
+				out.image = jsonLDString(node["image"])
+			}
+			if out.author == "" {
+				out.author = jsonLDString(node["author"])
+			}
+			if out.datePublished == "" {
+				out.datePublished = jsonLDString(node["datePublished"])
+			}
+		}
+	}
+	return out
+}
+
+func flattenJSONLD(payload any) []map[string]any {
+	switch v := payload.(type) {
+	case []any:
+		items := make([]map[string]any, 0, len(v))
+		for _, item := range v {
+			items = append(items, flattenJSONLD(item)...)
+		}
+		return items
+	case map[string]any:
+		items := []map[string]any{v}
+		if graph, ok := v["@graph"]; ok {
+			items = append(items, flattenJSONLD(graph)...)
+		}
+		return items
+	default:
+		return nil
+	}
+}
+
+// jsonLDString reads a string, {"name"|"url": ...} object or the first element of a list.
+func jsonLDString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]any:
+		return firstNonEmpty(jsonLDString(v["name"]), jsonLDString(v["url"]))
+	case []any:
+		for _, item := range v {
+			if s := jsonLDString(item); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func excerpt(text string, limit int) string {
+	text = strings.TrimSpace(text)
+	if idx := strings.Index(text, "\n\n"); idx > 0 {
+		text = text[:idx]
+	}
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:limit])) + "…"
+}
+
+func resolveURL(base string, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return baseURL.ResolveReference(refURL).String()
+}
+
+func walkNodes(n *html.Node, visit func(*html.Node) bool) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && !visit(child) {
+			continue
+		}
+		walkNodes(child, visit)
+	}
+}
+
+func removeNodes(n *html.Node, match func(*html.Node) bool) {
+	for child := n.FirstChild; child != nil; {
+		next := child.NextSibling
+		if (child.Type == html.ElementNode || child.Type == html.CommentNode) && match(child) {
+			n.RemoveChild(child)
+		} else {
+			removeNodes(child, match)
+		}
+		child = next
+	}
+}
+
+func nodeText(n *html.Node) string {
+	var b strings.Builder
+	var collect func(*html.Node)
+	collect = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+			return
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			collect(child)
+		}
+	}
+	collect(n)
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func normalizeText(raw string) string {
+	return strings.TrimSpace(spacePattern.ReplaceAllString(raw, " "))
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
+
+func minFloat(a float64, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
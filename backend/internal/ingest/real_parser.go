@@ -2,12 +2,21 @@ package ingest
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"regexp"
 	"strings"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/fetch"
 )
 
+var ErrNotListingParser = errors.New("parser kind does not read listing pages")
+
+// fallbackSummary is used when a page has neither a description nor readable body text.
+const fallbackSummary = "抓取自真实来源页面"
+
 var titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
 var spacePattern = regexp.MustCompile(`\s+`)
 
@@ -15,6 +24,11 @@ type URLParser interface {
 	ParseURL(ctx context.Context, url string) (PendingRecord, error)
 }
 
+// MultiURLParser is implemented by URL parsers that yield one record per item, such as feeds.
+type MultiURLParser interface {
+	ParseURLItems(ctx context.Context, url string) ([]PendingRecord, error)
+}
+
 // HTTPParser fetches and parses real web pages into pending records.
 type HTTPParser struct {
 	client *http.Client
@@ -28,31 +42,36 @@ func NewHTTPParser(client *http.Client) *HTTPParser {
 }
 
 func (p *HTTPParser) ParseURL(ctx context.Context, url string) (PendingRecord, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return PendingRecord{}, err
-	}
-
-	res, err := p.client.Do(req)
-	if err != nil {
-		return PendingRecord{}, err
-	}
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	body, err := fetchBody(ctx, p.client, url)
 	if err != nil {
 		return PendingRecord{}, err
 	}
 
+	page := extractPage(body, url)
 	title := extractTitle(string(body))
+	if title == "" {
+		title = page.Title
+	}
 	if title == "" {
 		title = url
 	}
 
+	summary := page.Description
+	if summary == "" {
+		summary = excerpt(page.Body, summaryExcerptRunes)
+	}
+	if summary == "" {
+		summary = fallbackSummary
+	}
+
 	return PendingRecord{
-		Title:     title,
-		Summary:   "抓取自真实来源页面",
-		SourceURL: url,
+		Title:       title,
+		Summary:     summary,
+		Body:        page.Body,
+		SourceURL:   url,
+		CoverURL:    page.CoverURL,
+		Author:      page.Author,
+		PublishedAt: page.PublishedAt,
 	}, nil
 }
 
@@ -75,6 +94,8 @@ func NewDefaultParserRegistry(client *http.Client) *ParserRegistry {
 	base := NewHTTPParser(client)
 	return NewParserRegistry(base, map[string]URLParser{
 		"generic":      NewLabeledParser("通用来源", base),
+		"rss":          NewFeedParser(client),
+		"listing":      NewListingParser(client),
 		"ufc_schedule": NewLabeledParser("UFC 官方来源", base),
 		"one_schedule": NewLabeledParser("ONE 官方来源", base),
 		"pfl_schedule": NewLabeledParser("PFL 官方来源", base),
@@ -94,6 +115,32 @@ func (r *ParserRegistry) Parse(ctx context.Context, job FetchJob) (PendingRecord
 	return selected.ParseURL(ctx, job.URL)
 }
 
+// ExtractLinks reads a listing page with the job's parser.
+func (r *ParserRegistry) ExtractLinks(ctx context.Context, job FetchJob) (ListingPage, error) {
+	if parser, ok := r.parsers[job.ParserKind]; ok {
+		if extractor, ok := parser.(LinkExtractor); ok {
+			return extractor.ExtractLinks(ctx, job.URL)
+		}
+	}
+	return ListingPage{}, fmt.Errorf("%w: %q", ErrNotListingParser, job.ParserKind)
+}
+
+// ParseItems returns every record the selected parser yields for the job.
+func (r *ParserRegistry) ParseItems(ctx context.Context, job FetchJob) ([]PendingRecord, error) {
+	selected := r.fallback
+	if parser, ok := r.parsers[job.ParserKind]; ok {
+		selected = parser
+	}
+	if multi, ok := selected.(MultiURLParser); ok {
+		return multi.ParseURLItems(ctx, job.URL)
+	}
+	rec, err := selected.ParseURL(ctx, job.URL)
+	if err != nil {
+		return nil, err
+	}
+	return []PendingRecord{rec}, nil
+}
+
 type labeledParser struct {
 	label string
 	base  URLParser
@@ -111,7 +158,9 @@ func (p *labeledParser) ParseURL(ctx context.Context, url string) (PendingRecord
 	if err != nil {
 		return PendingRecord{}, err
 	}
-	rec.Summary = "抓取自" + p.label
+	if rec.Summary == "" || rec.Summary == fallbackSummary {
+		rec.Summary = "抓取自" + p.label
+	}
 	return rec, nil
 }
 
@@ -124,3 +173,22 @@ func extractTitle(html string) string {
 	title = spacePattern.ReplaceAllString(title, " ")
 	return title
 }
+
+func fetchBody(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", fetch.DefaultUserAgent)
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Errorf("fetch %s: unexpected status %d", url, res.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(res.Body, 1<<20))
+}
@@ -2,6 +2,9 @@ package ingest
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -56,3 +59,53 @@ func TestParserRegistry_FallsBackWhenKindMissing(t *testing.T) {
 		t.Fatalf("expected fallback parser selected, got %q", rec.Summary)
 	}
 }
+
+const sampleArticlePage = `<!doctype html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>Title Tag | Fight Site</title>
+  <meta property="og:image" content="/images/cover.jpg">
+  <meta property="article:published_time" content="2026-02-21T10:30:00+08:00">
+  <script type="application/ld+json">
+  {"@context":"https://schema.org","@type":"NewsArticle","headline":"Headline","author":[{"@type":"Person","name":"Ariel Writer"}]}
+  </script>
+</head>
+<body class="has-sidebar">
+  <nav><a href="/">Home</a> <a href="/news">News</a></nav>
+  <div class="sidebar"><p>Trending: a very long list of unrelated headlines, links and more links.</p></div>
+  <article class="post-content">
+    <h1>Headline</h1>
+    <p>The main event was confirmed on Friday, with both camps agreeing to terms after weeks of talks.</p>
+    <p>Officials said the co-main event, a rematch, would also headline the broadcast, pending medicals.</p>
+  </article>
+  <div class="comments"><p>Great fight, can't wait for this one to happen, it will be huge.</p></div>
+</body>
+</html>`
+
+func TestHTTPParser_ExtractsBodyCoverAndMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(sampleArticlePage))
+	}))
+	defer srv.Close()
+
+	rec, err := NewHTTPParser(srv.Client()).ParseURL(context.Background(), srv.URL+"/news/1")
+	if err != nil {
+		t.Fatalf("parse page: %v", err)
+	}
+	if !strings.HasPrefix(rec.Body, "The main event was confirmed") || strings.Contains(rec.Body, "Trending") || strings.Contains(rec.Body, "Great fight") {
+		t.Fatalf("expected article paragraphs only, got %q", rec.Body)
+	}
+	if !strings.HasPrefix(rec.Summary, "The main event was confirmed") {
+		t.Fatalf("expected summary from first paragraph, got %q", rec.Summary)
+	}
+	if rec.CoverURL != srv.URL+"/images/cover.jpg" {
+		t.Fatalf("expected absolute og:image cover, got %q", rec.CoverURL)
+	}
+	if rec.Author != "Ariel Writer" {
+		t.Fatalf("expected JSON-LD author, got %q", rec.Author)
+	}
+	if rec.PublishedAt == nil || rec.PublishedAt.Hour() != 2 {
+		t.Fatalf("expected published time in UTC, got %v", rec.PublishedAt)
+	}
+}
@@ -1,16 +1,88 @@
 package ingest
 
-import "context"
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+)
 
 // PendingRecord is an article candidate waiting for review.
 type PendingRecord struct {
-	SourceID  int64
-	Title     string
-	Summary   string
-	SourceURL string
+	SourceID    int64
+	GUID        string
+	Title       string
+	Summary     string
+	Body        string
+	SourceURL   string
+	CoverURL    string
+	Author      string
+	PublishedAt *time.Time
+	SimHash     uint64
+	// DuplicateOf is the pending item this record near-duplicates, 0 when it is a new story.
+	DuplicateOf int64
+	// Entities are the fighters and events detected in the title and body.
+	Entities []linking.Link
+	// Tags are the slugs of tags suggested by source rules and keywords.
+	Tags []string
+}
+
+// Column sizes of pending_articles. Extracted text longer than these is cut to fit.
+const (
+	maxTitleRunes  = 255
+	maxAuthorRunes = 128
+)
+
+// fitColumns cuts the title and author to their column sizes, so a page with an overlong
+// byline or headline is still saved instead of failing the insert.
+func (r PendingRecord) fitColumns() PendingRecord {
+	r.Title = clipRunes(r.Title, maxTitleRunes)
+	r.Author = clipRunes(r.Author, maxAuthorRunes)
+	return r
+}
+
+func clipRunes(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	return string([]rune(value)[:limit])
 }
 
 // Repository persists pending ingest records.
 type Repository interface {
 	SavePending(ctx context.Context, rec PendingRecord) error
 }
+
+// SeenChecker is implemented by repositories that can tell whether an item was already
+// ingested, by GUID within the source or by link. The worker skips such items when the
+// repository supports it.
+type SeenChecker interface {
+	HasSeen(ctx context.Context, sourceID int64, guid string, link string) (bool, error)
+}
+
+// LinkMarker is implemented by repositories that remember the links listing pages queued,
+// so a child job still waiting in the queue is not queued again by the next run. Marks are
+// only trusted for queuedLinkTTL: a link whose fetch failed or was dead-lettered is queued
+// again after that, and older marks are pruned.
+type LinkMarker interface {
+	WasQueued(ctx context.Context, sourceID int64, link string, since time.Time) (bool, error)
+	MarkQueued(ctx context.Context, sourceID int64, link string, at time.Time) error
+	PruneQueued(ctx context.Context, sourceID int64, before time.Time) error
+}
+
+// DuplicateFinder is implemented by repositories that can match a SimHash fingerprint against
+// pending items. Matches are saved as alternatives of the primary item instead of new stories.
+type DuplicateFinder interface {
+	FindNearDuplicate(ctx context.Context, simhash uint64, maxDistance int) (int64, bool, error)
+}
+
+// EntityDetector finds the fighters and events an article mentions.
+type EntityDetector interface {
+	Detect(ctx context.Context, title string, body string) ([]linking.Link, error)
+}
+
+// TagSuggester picks the tags a new article should start with.
+type TagSuggester interface {
+	Suggest(ctx context.Context, sourceID int64, title string, body string) ([]string, error)
+}
@@ -0,0 +1,168 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/source"
+)
+
+const (
+	RunStatusQueued    = "queued"
+	RunStatusRunning   = "running"
+	RunStatusSucceeded = "succeeded"
+	RunStatusFailed    = "failed"
+	// RunStatusBlocked marks a fetch refused by the site's robots.txt; it backs off like a failure.
+	RunStatusBlocked = "blocked"
+
+	DefaultScheduleTick = time.Minute
+
+	// staleRunAfter bounds how long a queued or running run blocks its source. A run that
+	// never finishes (its job was dead-lettered or the worker died) stops counting as in
+	// flight after this, so the source is fetched again.
+	staleRunAfter   = time.Hour
+	maxFetchBackoff = 24 * time.Hour
+)
+
+// DueSource is an enabled news source whose next automatic fetch is due.
+type DueSource struct {
+	ID             int64
+	URL            string
+	ParserKind     string
+	FetchInterval  int
+	FetchFailCount int
+}
+
+// RunOutcome is the result of one scheduled fetch, applied to the run and its source.
+type RunOutcome struct {
+	RunID        int64
+	SourceID     int64
+	Status       string
+	FetchedCount int
+	Error        string
+	FinishedAt   time.Time
+	FailCount    int
+	NextFetchAt  time.Time
+}
+
+// RunRecorder tracks scheduled fetches through ingest_runs.
+type RunRecorder interface {
+	MarkRunRunning(ctx context.Context, runID int64) error
+	FinishRun(ctx context.Context, outcome RunOutcome) error
+}
+
+type RunStore interface {
+	RunRecorder
+	ListDueSources(ctx context.Context, now time.Time) ([]DueSource, error)
+	// StartRun records a queued run unless the source already has one in flight. Runs
+	// created before staleBefore no longer count as in flight.
+	StartRun(ctx context.Context, src DueSource, staleBefore time.Time) (int64, bool, error)
+}
+
+// Scheduler enqueues fetch jobs for news sources whose interval has elapsed.
+type Scheduler struct {
+	store     RunStore
+	publisher FetchPublisher
+	now       func() time.Time
+}
+
+func NewScheduler(store RunStore, publisher FetchPublisher) *Scheduler {
+	return &Scheduler{store: store, publisher: publisher, now: time.Now}
+}
+
+// RunOnce enqueues one job per due source and returns how many were queued. A source that
+// cannot be started or queued does not hold back the others; its error is joined into the
+// returned one.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	now := s.now()
+	due, err := s.store.ListDueSources(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	var errs []error
+	for _, src := range due {
+		runID, started, err := s.store.StartRun(ctx, src, now.Add(-staleRunAfter))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("start run source=%d: %w", src.ID, err))
+			continue
+		}
+		if !started {
+			continue
+		}
+		parserKind := src.ParserKind
+		if parserKind == "" {
+			parserKind = "generic"
+		}
+		job := FetchJob{SourceID: src.ID, URL: src.URL, ParserKind: parserKind, RunID: runID}
+		if err := s.publisher.Enqueue(ctx, job); err != nil {
+			failCount := src.FetchFailCount + 1
+			if finishErr := s.store.FinishRun(ctx, RunOutcome{
+				RunID:       runID,
+				SourceID:    src.ID,
+				Status:      RunStatusFailed,
+				Error:       err.Error(),
+				FinishedAt:  now,
+				FailCount:   failCount,
+				NextFetchAt: NextFetchAt(now, src.FetchInterval, failCount),
+			}); finishErr != nil {
+				log.Printf("record failed enqueue source=%d: %v", src.ID, finishErr)
+			}
+			errs = append(errs, fmt.Errorf("enqueue source=%d: %w", src.ID, err))
+			continue
+		}
+		queued++
+	}
+	return queued, errors.Join(errs...)
+}
+
+// NextFetchAt schedules the next fetch one interval after finishedAt, doubling the wait for
+// each consecutive failure up to a day.
+func NextFetchAt(finishedAt time.Time, intervalSec int, failCount int) time.Time {
+	if intervalSec <= 0 {
+		intervalSec = source.DefaultFetchIntervalSec
+	}
+	base := time.Duration(intervalSec) * time.Second
+	delay := base
+	for i := 0; i < failCount; i++ {
+		delay *= 2
+		if delay >= maxFetchBackoff {
+			delay = maxFetchBackoff
+			break
+		}
+	}
+	if delay < base {
+		delay = base
+	}
+	return finishedAt.Add(delay)
+}
+
+func StartScheduler(ctx context.Context, scheduler *Scheduler, tick time.Duration) {
+	if scheduler == nil {
+		return
+	}
+	if tick <= 0 {
+		tick = DefaultScheduleTick
+	}
+	if _, err := scheduler.RunOnce(ctx); err != nil {
+		log.Printf("ingest schedule failed: %v", err)
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := scheduler.RunOnce(ctx); err != nil {
+				log.Printf("ingest schedule failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/fetch"
+	"github.com/bajiaozhi/w-mma/backend/internal/source"
+)
+
+type fakeRunStore struct {
+	due      []DueSource
+	inFlight map[int64]bool
+	startErr map[int64]error
+	nextRun  int64
+	started  []int64
+	running  []int64
+	outcomes []RunOutcome
+}
+
+func (s *fakeRunStore) ListDueSources(context.Context, time.Time) ([]DueSource, error) {
+	return s.due, nil
+}
+
+func (s *fakeRunStore) StartRun(_ context.Context, src DueSource, _ time.Time) (int64, bool, error) {
+	if s.inFlight[src.ID] {
+		return 0, false, nil
+	}
+	if err := s.startErr[src.ID]; err != nil {
+		return 0, false, err
+	}
+	s.nextRun++
+	s.started = append(s.started, src.ID)
+	return s.nextRun, true, nil
+}
+
+func (s *fakeRunStore) MarkRunRunning(_ context.Context, runID int64) error {
+	s.running = append(s.running, runID)
+	return nil
+}
+
+func (s *fakeRunStore) FinishRun(_ context.Context, outcome RunOutcome) error {
+	s.outcomes = append(s.outcomes, outcome)
+	return nil
+}
+
+func TestScheduler_QueuesDueSourcesAndSkipsInFlight(t *testing.T) {
+	store := &fakeRunStore{
+		due: []DueSource{
+			{ID: 1, URL: "https://a.example.com/feed", ParserKind: "rss"},
+			{ID: 2, URL: "https://b.example.com/news"},
+		},
+		inFlight: map[int64]bool{1: true},
+	}
+	pub := &fakeFetchPublisher{}
+
+	queued, err := NewScheduler(store, pub).RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	if queued != 1 || len(pub.jobs) != 1 {
+		t.Fatalf("expected only the idle source queued, got %d jobs", len(pub.jobs))
+	}
+	job := pub.jobs[0]
+	if job.SourceID != 2 || job.ParserKind != "generic" || job.RunID != 1 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+}
+
+type failingURLPublisher struct {
+	fakeFetchPublisher
+	failURL string
+}
+
+func (p *failingURLPublisher) Enqueue(ctx context.Context, job FetchJob) error {
+	if job.URL == p.failURL {
+		return errors.New("stream unavailable")
+	}
+	return p.fakeFetchPublisher.Enqueue(ctx, job)
+}
+
+func TestScheduler_KeepsQueueingAfterOneSourceFails(t *testing.T) {
+	store := &fakeRunStore{
+		due: []DueSource{
+			{ID: 1, URL: "https://a.example.com/feed"},
+			{ID: 2, URL: "https://b.example.com/news"},
+			{ID: 3, URL: "https://c.example.com/news"},
+		},
+		startErr: map[int64]error{1: errors.New("deadlock found")},
+	}
+	pub := &failingURLPublisher{failURL: "https://b.example.com/news"}
+
+	queued, err := NewScheduler(store, pub).RunOnce(context.Background())
+	if err == nil {
+		t.Fatal("expected the failures reported")
+	}
+	if queued != 1 || len(pub.jobs) != 1 || pub.jobs[0].SourceID != 3 {
+		t.Fatalf("expected the last source queued despite the failures, got %d %+v", queued, pub.jobs)
+	}
+	if len(store.outcomes) != 1 || store.outcomes[0].SourceID != 2 || store.outcomes[0].Status != RunStatusFailed {
+		t.Fatalf("expected the failed enqueue recorded on its run, got %+v", store.outcomes)
+	}
+}
+
+func TestNextFetchAt_BacksOffOnConsecutiveFailures(t *testing.T) {
+	finished := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		failCount int
+		want      time.Duration
+	}{
+		{0, 10 * time.Minute},
+		{1, 20 * time.Minute},
+		{3, 80 * time.Minute},
+		{20, 24 * time.Hour},
+	}
+	for _, tc := range cases {
+		if got := NextFetchAt(finished, 600, tc.failCount).Sub(finished); got != tc.want {
+			t.Fatalf("fail count %d: expected %s, got %s", tc.failCount, tc.want, got)
+		}
+	}
+	if got := NextFetchAt(finished, 0, 0).Sub(finished); got != source.DefaultFetchIntervalSec*time.Second {
+		t.Fatalf("expected default interval, got %s", got)
+	}
+}
+
+func TestWorker_RecordsScheduledRunOutcome(t *testing.T) {
+	store := &fakeRunStore{}
+	sources := fakeSourceReader{item: source.DataSource{ID: 4, FetchInterval: 600, FetchFailCount: 2}}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	failing := NewQueuelessWorker(newFakeRepo(), fakeParser{err: errors.New("status 503")},
+		WithSourceReader(sources), WithRunRecorder(store))
+	failing.now = func() time.Time { return now }
+	if err := failing.HandleJob(context.Background(), FetchJob{SourceID: 4, URL: "https://a.example.com", RunID: 9}); err != nil {
+		t.Fatalf("expected failed scheduled fetch to be recorded, not returned: %v", err)
+	}
+	if len(store.running) != 1 || store.running[0] != 9 {
+		t.Fatalf("expected run marked running, got %v", store.running)
+	}
+	got := store.outcomes[0]
+	if got.Status != RunStatusFailed || got.Error != "status 503" || got.FailCount != 3 {
+		t.Fatalf("unexpected failure outcome: %+v", got)
+	}
+	if want := now.Add(80 * time.Minute); !got.NextFetchAt.Equal(want) {
+		t.Fatalf("expected backoff to %s, got %s", want, got.NextFetchAt)
+	}
+
+	repo := newFakeRepo()
+	ok := NewQueuelessWorker(repo, fakeParserSuccess(), WithSourceReader(sources), WithRunRecorder(store))
+	ok.now = func() time.Time { return now }
+	if err := ok.HandleJob(context.Background(), FetchJob{SourceID: 4, URL: "https://a.example.com", RunID: 10}); err != nil {
+		t.Fatalf("handle job: %v", err)
+	}
+	got = store.outcomes[1]
+	if got.Status != RunStatusSucceeded || got.FetchedCount != 1 || got.FailCount != 0 {
+		t.Fatalf("unexpected success outcome: %+v", got)
+	}
+	if want := now.Add(10 * time.Minute); !got.NextFetchAt.Equal(want) {
+		t.Fatalf("expected next fetch one interval later, got %s", got.NextFetchAt)
+	}
+}
+
+func TestWorker_RecordsEmptyFeedAsSucceededRun(t *testing.T) {
+	store := &fakeRunStore{}
+	sources := fakeSourceReader{item: source.DataSource{ID: 4, FetchInterval: 600, FetchFailCount: 2}}
+	w := NewQueuelessWorker(newFakeRepo(), fakeParser{err: ErrFeedEmpty}, WithSourceReader(sources), WithRunRecorder(store))
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 4, URL: "https://a.example.com/feed", RunID: 5}); err != nil {
+		t.Fatalf("handle job: %v", err)
+	}
+	if got := store.outcomes[0]; got.Status != RunStatusSucceeded || got.FetchedCount != 0 || got.FailCount != 0 || got.Error != "" {
+		t.Fatalf("expected empty feed recorded as a succeeded run, got %+v", got)
+	}
+}
+
+func TestWorker_RecordsRobotsBlockAsBlockedRun(t *testing.T) {
+	store := &fakeRunStore{}
+	blocked := fmt.Errorf("fetch https://a.example.com/news: %w", fetch.ErrBlockedByRobots)
+	w := NewQueuelessWorker(newFakeRepo(), fakeParser{err: blocked}, WithRunRecorder(store))
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 4, URL: "https://a.example.com/news", RunID: 3}); err != nil {
+		t.Fatalf("handle job: %v", err)
+	}
+	if got := store.outcomes[0]; got.Status != RunStatusBlocked || got.FailCount != 1 {
+		t.Fatalf("expected blocked run with backoff, got %+v", got)
+	}
+}
@@ -0,0 +1,95 @@
+package ingest
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// NearDuplicateDistance is the largest SimHash Hamming distance still treated as the same story.
+// News items are short, so rewrites drift further than the 3 bits used for full web pages;
+// unrelated stories, even on the same card, sit above 20.
+const NearDuplicateDistance = 12
+
+// SimHash returns a 64-bit SimHash fingerprint of an article's title and body. Latin text is
+// split into words and CJK text into character bigrams, so rewordings and light edits of the
+// same story land close to each other. Empty input yields 0.
+func SimHash(title string, body string) uint64 {
+	weights := map[string]int{}
+	// Title tokens count double so headline wording outweighs boilerplate in the body.
+	for _, token := range simHashTokens(title) {
+		weights[token] += 2
+	}
+	for _, token := range simHashTokens(body) {
+		weights[token]++
+	}
+	if len(weights) == 0 {
+		return 0
+	}
+
+	var vector [64]int
+	for token, weight := range weights {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(token))
+		sum := h.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<uint(bit)) != 0 {
+				vector[bit] += weight
+			} else {
+				vector[bit] -= weight
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit := 0; bit < 64; bit++ {
+		if vector[bit] > 0 {
+			fingerprint |= 1 << uint(bit)
+		}
+	}
+	return fingerprint
+}
+
+// HammingDistance counts the differing bits of two fingerprints.
+func HammingDistance(a uint64, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func simHashTokens(text string) []string {
+	tokens := make([]string, 0)
+	var word []rune
+	var cjk []rune
+	flushWord := func() {
+		if len(word) > 1 {
+			tokens = append(tokens, string(word))
+		}
+		word = word[:0]
+	}
+	flushCJK := func() {
+		if len(cjk) == 1 {
+			tokens = append(tokens, string(cjk))
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			tokens = append(tokens, string(cjk[i:i+2]))
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
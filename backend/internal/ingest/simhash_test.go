@@ -0,0 +1,36 @@
+package ingest
+
+import "testing"
+
+func TestSimHash_NearDuplicatesStayClose(t *testing.T) {
+	a := SimHash(
+		"Jon Jones vs Tom Aspinall confirmed for UFC 327 main event",
+		"The UFC confirmed on Friday that heavyweight champion Jon Jones will finally face interim champion Tom Aspinall in the main event of UFC 327 in Las Vegas. Both camps agreed to terms after months of negotiations, and the co-main event will be announced next week.",
+	)
+	b := SimHash(
+		"UFC 327: Jon Jones vs Tom Aspinall confirmed as main event",
+		"UFC confirmed Friday that heavyweight champion Jon Jones will finally face interim champion Tom Aspinall in the UFC 327 main event in Las Vegas. Both camps agreed terms after months of negotiations, and the co-main event will be announced next week.",
+	)
+	c := SimHash(
+		"Weili Zhang returns to strawweight",
+		"Former champion Zhang Weili said she is targeting a summer return after recovering from a knee injury, and will stay at strawweight for at least one more fight.",
+	)
+
+	if d := HammingDistance(a, b); d > NearDuplicateDistance {
+		t.Fatalf("expected reworded story within %d bits, got %d", NearDuplicateDistance, d)
+	}
+	if d := HammingDistance(a, c); d <= NearDuplicateDistance {
+		t.Fatalf("expected different stories to be far apart, got %d", d)
+	}
+}
+
+func TestSimHash_TokenizesChineseByBigram(t *testing.T) {
+	a := SimHash("张伟丽宣布夏季复出", "前冠军张伟丽表示膝伤已经康复，计划在夏季复出，并将继续留在草量级参赛至少一场。")
+	b := SimHash("张伟丽宣布将在夏季复出", "前冠军张伟丽表示膝伤已康复，计划夏季复出，并将继续留在草量级参赛至少一场。")
+	if d := HammingDistance(a, b); d > NearDuplicateDistance {
+		t.Fatalf("expected Chinese rewrite within %d bits, got %d", NearDuplicateDistance, d)
+	}
+	if SimHash("", " ") != 0 {
+		t.Fatalf("expected empty text to have no fingerprint")
+	}
+}
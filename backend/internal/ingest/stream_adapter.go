@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/bajiaozhi/w-mma/backend/internal/queue"
 )
@@ -32,6 +33,12 @@ func (p *StreamPublisher) Enqueue(ctx context.Context, job FetchJob) error {
 	if job.ParserKind != "" {
 		values["parser_kind"] = job.ParserKind
 	}
+	if job.Depth > 0 {
+		values["depth"] = strconv.Itoa(job.Depth)
+	}
+	if job.RunID > 0 {
+		values["run_id"] = strconv.FormatInt(job.RunID, 10)
+	}
 	return p.queue.Publish(ctx, values)
 }
 
@@ -45,33 +52,111 @@ func NewStreamConsumer(queue *queue.StreamQueue) *StreamConsumer {
 
 func (c *StreamConsumer) ConsumeOnce(ctx context.Context, handler func(FetchJob) error) error {
 	return c.queue.Consume(ctx, func(msg queue.StreamMessage) error {
-		rawSourceID, ok := msg.Values["source_id"]
-		if !ok {
-			return fmt.Errorf("missing source_id")
+		job, err := fetchJobFromValues(msg.Values)
+		if err != nil {
+			return err
 		}
-		rawURL, ok := msg.Values["url"]
-		if !ok {
-			return fmt.Errorf("missing url")
+		return handler(job)
+	})
+}
+
+// DeadLetterJob is a fetch job that exhausted its retries on the stream.
+type DeadLetterJob struct {
+	ID         string    `json:"id"`
+	OriginalID string    `json:"original_id"`
+	SourceID   int64     `json:"source_id"`
+	URL        string    `json:"url"`
+	ParserKind string    `json:"parser_kind"`
+	Depth      int       `json:"depth"`
+	Error      string    `json:"error"`
+	Deliveries int64     `json:"deliveries"`
+	FailedAt   time.Time `json:"failed_at"`
+}
+
+// StreamDeadLetters exposes the fetch stream's dead letters as fetch jobs.
+type StreamDeadLetters struct {
+	queue *queue.StreamQueue
+}
+
+func NewStreamDeadLetters(queue *queue.StreamQueue) *StreamDeadLetters {
+	return &StreamDeadLetters{queue: queue}
+}
+
+func (d *StreamDeadLetters) List(ctx context.Context, limit int64) ([]DeadLetterJob, error) {
+	letters, err := d.queue.ListDeadLetters(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]DeadLetterJob, 0, len(letters))
+	for _, letter := range letters {
+		// A malformed job is still listed so it can be inspected and dropped.
+		job, _ := fetchJobFromValues(letter.Values)
+		items = append(items, DeadLetterJob{
+			ID:         letter.ID,
+			OriginalID: letter.OriginalID,
+			SourceID:   job.SourceID,
+			URL:        job.URL,
+			ParserKind: job.ParserKind,
+			Depth:      job.Depth,
+			Error:      letter.Error,
+			Deliveries: letter.Deliveries,
+			FailedAt:   letter.FailedAt,
+		})
+	}
+	return items, nil
+}
+
+func (d *StreamDeadLetters) Replay(ctx context.Context, id string) error {
+	return d.queue.ReplayDeadLetter(ctx, id)
+}
+
+func (d *StreamDeadLetters) Drop(ctx context.Context, id string) error {
+	return d.queue.DropDeadLetter(ctx, id)
+}
+
+func fetchJobFromValues(values map[string]any) (FetchJob, error) {
+	rawSourceID, ok := values["source_id"]
+	if !ok {
+		return FetchJob{}, fmt.Errorf("missing source_id")
+	}
+	rawURL, ok := values["url"]
+	if !ok {
+		return FetchJob{}, fmt.Errorf("missing url")
+	}
+
+	sourceID, err := toInt64(rawSourceID)
+	if err != nil {
+		return FetchJob{}, err
+	}
+	url := fmt.Sprint(rawURL)
+	if url == "" {
+		return FetchJob{}, fmt.Errorf("empty url")
+	}
+
+	parserKind := "generic"
+	if rawParserKind, ok := values["parser_kind"]; ok {
+		if parsed := fmt.Sprint(rawParserKind); parsed != "" {
+			parserKind = parsed
 		}
+	}
 
-		sourceID, err := toInt64(rawSourceID)
+	depth := 0
+	if rawDepth, ok := values["depth"]; ok {
+		parsed, err := toInt64(rawDepth)
 		if err != nil {
-			return err
-		}
-		url := fmt.Sprint(rawURL)
-		if url == "" {
-			return fmt.Errorf("empty url")
+			return FetchJob{}, err
 		}
+		depth = int(parsed)
+	}
 
-		parserKind := "generic"
-		if rawParserKind, ok := msg.Values["parser_kind"]; ok {
-			if parsed := fmt.Sprint(rawParserKind); parsed != "" {
-				parserKind = parsed
-			}
+	var runID int64
+	if rawRunID, ok := values["run_id"]; ok {
+		if runID, err = toInt64(rawRunID); err != nil {
+			return FetchJob{}, err
 		}
+	}
 
-		return handler(FetchJob{SourceID: sourceID, URL: url, ParserKind: parserKind})
-	})
+	return FetchJob{SourceID: sourceID, URL: url, ParserKind: parserKind, Depth: depth, RunID: runID}, nil
 }
 
 func toInt64(v any) (int64, error) {
@@ -1,24 +1,94 @@
 package ingest
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/fetch"
+	"github.com/bajiaozhi/w-mma/backend/internal/source"
+)
+
+var ErrNoFetchPublisher = errors.New("listing crawl needs a fetch publisher")
 
 // Parser parses a remote URL into a pending review record.
 type Parser interface {
 	Parse(ctx context.Context, job FetchJob) (PendingRecord, error)
 }
 
+// ItemsParser is implemented by parsers that can turn one URL into several records, such as feeds.
+type ItemsParser interface {
+	ParseItems(ctx context.Context, job FetchJob) ([]PendingRecord, error)
+}
+
+// ListingReader is implemented by parsers that can read listing pages.
+type ListingReader interface {
+	ExtractLinks(ctx context.Context, job FetchJob) (ListingPage, error)
+}
+
 type Worker struct {
-	queue  Queue
-	repo   Repository
-	parser Parser
+	queue     Queue
+	repo      Repository
+	parser    Parser
+	publisher FetchPublisher
+	sources   SourceReader
+	runs      RunRecorder
+	entities  EntityDetector
+	tags      TagSuggester
+	now       func() time.Time
+}
+
+// WorkerOption configures optional worker dependencies.
+type WorkerOption func(*Worker)
+
+// WithFetchPublisher lets the worker publish child jobs found on listing pages.
+func WithFetchPublisher(publisher FetchPublisher) WorkerOption {
+	return func(w *Worker) {
+		w.publisher = publisher
+	}
+}
+
+// WithSourceReader lets the worker read per-source crawl settings.
+func WithSourceReader(sources SourceReader) WorkerOption {
+	return func(w *Worker) {
+		w.sources = sources
+	}
+}
+
+// WithRunRecorder lets the worker record the outcome of scheduled fetches.
+func WithRunRecorder(runs RunRecorder) WorkerOption {
+	return func(w *Worker) {
+		w.runs = runs
+	}
+}
+
+// WithEntityDetector lets the worker suggest fighter and event links for new records.
+func WithEntityDetector(entities EntityDetector) WorkerOption {
+	return func(w *Worker) {
+		w.entities = entities
+	}
 }
 
-func NewWorker(queue Queue, repo Repository, parser Parser) *Worker {
-	return &Worker{queue: queue, repo: repo, parser: parser}
+// WithTagSuggester lets the worker pre-select tags for new records.
+func WithTagSuggester(tags TagSuggester) WorkerOption {
+	return func(w *Worker) {
+		w.tags = tags
+	}
 }
 
-func NewQueuelessWorker(repo Repository, parser Parser) *Worker {
-	return &Worker{repo: repo, parser: parser}
+func NewWorker(queue Queue, repo Repository, parser Parser, opts ...WorkerOption) *Worker {
+	w := &Worker{queue: queue, repo: repo, parser: parser, now: time.Now}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+func NewQueuelessWorker(repo Repository, parser Parser, opts ...WorkerOption) *Worker {
+	return NewWorker(nil, repo, parser, opts...)
 }
 
 func (w *Worker) RunOnce(ctx context.Context) {
@@ -33,10 +103,271 @@ func (w *Worker) RunOnce(ctx context.Context) {
 }
 
 func (w *Worker) HandleJob(ctx context.Context, job FetchJob) error {
+	if job.RunID > 0 && w.runs != nil {
+		return w.handleRun(ctx, job)
+	}
+	_, err := w.handle(ctx, job)
+	return err
+}
+
+// handleRun handles a scheduled fetch and records its outcome on the run and the source.
+// The schedule's backoff is the retry policy for these jobs, so a failed fetch is recorded
+// and acknowledged rather than left on the stream for redelivery.
+func (w *Worker) handleRun(ctx context.Context, job FetchJob) error {
+	if err := w.runs.MarkRunRunning(ctx, job.RunID); err != nil {
+		return err
+	}
+	fetched, handleErr := w.handle(ctx, job)
+
+	interval, failCount := source.DefaultFetchIntervalSec, 0
+	if w.sources != nil {
+		if item, err := w.sources.Get(ctx, job.SourceID); err == nil {
+			interval, failCount = item.FetchInterval, item.FetchFailCount
+		}
+	}
+	outcome := RunOutcome{
+		RunID:        job.RunID,
+		SourceID:     job.SourceID,
+		Status:       RunStatusSucceeded,
+		FetchedCount: fetched,
+		FinishedAt:   w.now(),
+	}
+	if handleErr != nil {
+		failCount++
+		outcome.Status = RunStatusFailed
+		if errors.Is(handleErr, fetch.ErrBlockedByRobots) {
+			outcome.Status = RunStatusBlocked
+		}
+		outcome.Error = handleErr.Error()
+	} else {
+		failCount = 0
+	}
+	outcome.FailCount = failCount
+	outcome.NextFetchAt = NextFetchAt(outcome.FinishedAt, interval, failCount)
+	return w.runs.FinishRun(ctx, outcome)
+}
+
+// handle processes one job and reports how many records were saved or child jobs queued.
+func (w *Worker) handle(ctx context.Context, job FetchJob) (int, error) {
+	if job.ParserKind == ListingParserKind {
+		return w.handleListing(ctx, job)
+	}
+	records, err := w.parseJob(ctx, job)
+	if errors.Is(err, ErrFeedEmpty) {
+		// A feed with no items is reachable and well formed; there is just nothing new yet.
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	checker, _ := w.repo.(SeenChecker)
+	finder, _ := w.repo.(DuplicateFinder)
+	seen := make(map[string]struct{}, len(records))
+	saved := 0
+	var saveErr error
+	for _, rec := range records {
+		key := rec.GUID
+		if key == "" {
+			key = rec.SourceURL
+		}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if checker != nil {
+			exists, err := checker.HasSeen(ctx, job.SourceID, rec.GUID, rec.SourceURL)
+			if err != nil {
+				return saved, err
+			}
+			if exists {
+				continue
+			}
+		}
+		rec = rec.fitColumns()
+		rec.SourceID = job.SourceID
+		rec.SimHash = SimHash(rec.Title, firstNonEmpty(rec.Body, rec.Summary))
+		if finder != nil && rec.SimHash != 0 {
+			primaryID, found, err := finder.FindNearDuplicate(ctx, rec.SimHash, NearDuplicateDistance)
+			if err != nil {
+				return saved, err
+			}
+			if found {
+				rec.DuplicateOf = primaryID
+			}
+		}
+		if w.entities != nil {
+			// Links are only suggestions for the reviewer; the item is saved without them.
+			links, err := w.entities.Detect(ctx, rec.Title, firstNonEmpty(rec.Body, rec.Summary))
+			if err != nil {
+				log.Printf("detect entities source=%d url=%s: %v", job.SourceID, rec.SourceURL, err)
+			}
+			rec.Entities = links
+		}
+		if w.tags != nil {
+			tags, err := w.tags.Suggest(ctx, rec.SourceID, rec.Title, firstNonEmpty(rec.Body, rec.Summary))
+			if err != nil {
+				log.Printf("suggest tags source=%d url=%s: %v", job.SourceID, rec.SourceURL, err)
+			}
+			rec.Tags = tags
+		}
+		if err := w.repo.SavePending(ctx, rec); err != nil {
+			// One bad item must not drop the rest of the feed; the job fails only when
+			// nothing could be saved.
+			log.Printf("save pending item source=%d url=%s: %v", job.SourceID, rec.SourceURL, err)
+			saveErr = err
+			continue
+		}
+		saved++
+	}
+	if saved == 0 && saveErr != nil {
+		return 0, saveErr
+	}
+	return saved, nil
+}
+
+func (w *Worker) parseJob(ctx context.Context, job FetchJob) ([]PendingRecord, error) {
+	if parser, ok := w.parser.(ItemsParser); ok {
+		return parser.ParseItems(ctx, job)
+	}
 	rec, err := w.parser.Parse(ctx, job)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return []PendingRecord{rec}, nil
+}
+
+// queuedLinkTTL is how long a link queued from a listing page is not queued again. It covers
+// the child job's retries; a link still unseen after it is fetched anew.
+const queuedLinkTTL = 24 * time.Hour
+
+// defaultArticleLinkPattern keeps links whose last path segment looks like a story: a numeric
+// id or a slug of at least three words. It applies to sources without an include pattern, so
+// section, tag and author pages linked from a listing are not queued as articles.
+var defaultArticleLinkPattern = regexp.MustCompile(`/[^/?#]*(?:\d{4,}|[^/?#-]+-[^/?#-]+-[^/?#]+)[^/?#]*/?(?:\?[^#]*)?$`)
+
+type crawlSettings struct {
+	include  *regexp.Regexp
+	exclude  *regexp.Regexp
+	maxDepth int
+	maxItems int
+}
+
+// handleListing publishes one generic job per unseen article link, capped per run, and
+// follows the listing's next page while below the source's max depth.
+func (w *Worker) handleListing(ctx context.Context, job FetchJob) (int, error) {
+	parser, ok := w.parser.(ListingReader)
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrNotListingParser, job.ParserKind)
+	}
+	if w.publisher == nil {
+		return 0, ErrNoFetchPublisher
+	}
+	settings, err := w.crawlSettings(ctx, job.SourceID)
+	if err != nil {
+		return 0, err
+	}
+	page, err := parser.ExtractLinks(ctx, job)
+	if err != nil {
+		return 0, err
+	}
+
+	checker, _ := w.repo.(SeenChecker)
+	marker, _ := w.repo.(LinkMarker)
+	markedSince := w.now().Add(-queuedLinkTTL)
+	if marker != nil {
+		if err := marker.PruneQueued(ctx, job.SourceID, markedSince); err != nil {
+			return 0, err
+		}
+	}
+	published := 0
+	for _, link := range page.Links {
+		if published >= settings.maxItems {
+			break
+		}
+		if settings.include != nil && !settings.include.MatchString(link) {
+			continue
+		}
+		if settings.exclude != nil && settings.exclude.MatchString(link) {
+			continue
+		}
+		if checker != nil {
+			exists, err := checker.HasSeen(ctx, job.SourceID, "", link)
+			if err != nil {
+				return published, err
+			}
+			if exists {
+				continue
+			}
+		}
+		if marker != nil {
+			queued, err := marker.WasQueued(ctx, job.SourceID, link, markedSince)
+			if err != nil {
+				return published, err
+			}
+			if queued {
+				continue
+			}
+		}
+		if err := w.publisher.Enqueue(ctx, FetchJob{
+			SourceID:   job.SourceID,
+			URL:        link,
+			ParserKind: "generic",
+			Depth:      job.Depth + 1,
+		}); err != nil {
+			return published, err
+		}
+		if marker != nil {
+			if err := marker.MarkQueued(ctx, job.SourceID, link, w.now()); err != nil {
+				return published, err
+			}
+		}
+		published++
+	}
+
+	if page.Next != "" && job.Depth+1 < settings.maxDepth {
+		return published, w.publisher.Enqueue(ctx, FetchJob{
+			SourceID:   job.SourceID,
+			URL:        page.Next,
+			ParserKind: ListingParserKind,
+			Depth:      job.Depth + 1,
+		})
+	}
+	return published, nil
+}
+
+func (w *Worker) crawlSettings(ctx context.Context, sourceID int64) (crawlSettings, error) {
+	settings := crawlSettings{
+		include:  defaultArticleLinkPattern,
+		maxDepth: source.DefaultCrawlMaxDepth,
+		maxItems: source.DefaultCrawlMaxItems,
+	}
+	if w.sources == nil {
+		return settings, nil
+	}
+	item, err := w.sources.Get(ctx, sourceID)
+	if errors.Is(err, source.ErrSourceNotFound) {
+		return settings, nil
+	}
+	if err != nil {
+		return crawlSettings{}, err
+	}
+	if item.CrawlMaxDepth > 0 {
+		settings.maxDepth = item.CrawlMaxDepth
+	}
+	if item.CrawlMaxItems > 0 {
+		settings.maxItems = item.CrawlMaxItems
+	}
+	if item.CrawlInclude != "" {
+		if settings.include, err = regexp.Compile(item.CrawlInclude); err != nil {
+			return crawlSettings{}, err
+		}
+	}
+	if item.CrawlExclude != "" {
+		if settings.exclude, err = regexp.Compile(item.CrawlExclude); err != nil {
+			return crawlSettings{}, err
+		}
 	}
-	rec.SourceID = job.SourceID
-	return w.repo.SavePending(ctx, rec)
+	return settings, nil
 }
@@ -3,7 +3,14 @@ package ingest
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+	"github.com/bajiaozhi/w-mma/backend/internal/source"
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
 )
 
 type fakeQueue struct {
@@ -80,3 +87,360 @@ func TestWorker_IgnoresParserFailure(t *testing.T) {
 		t.Fatalf("expected 0 pending record, got %d", repo.pendingCount)
 	}
 }
+
+type fakeSeenRepo struct {
+	saved []PendingRecord
+	seen  map[string]bool
+}
+
+func (r *fakeSeenRepo) SavePending(_ context.Context, rec PendingRecord) error {
+	r.saved = append(r.saved, rec)
+	return nil
+}
+
+func (r *fakeSeenRepo) HasSeen(_ context.Context, _ int64, guid string, link string) (bool, error) {
+	return r.seen[guid] || r.seen[link], nil
+}
+
+type fakeItemsParser struct {
+	fakeParser
+	items []PendingRecord
+}
+
+func (p fakeItemsParser) ParseItems(context.Context, FetchJob) ([]PendingRecord, error) {
+	return p.items, nil
+}
+
+func TestWorker_SavesNewFeedItemsOnly(t *testing.T) {
+	repo := &fakeSeenRepo{seen: map[string]bool{"guid-a": true, "https://example.com/b": true}}
+	parser := fakeItemsParser{items: []PendingRecord{
+		{GUID: "guid-a", SourceURL: "https://example.com/a"},
+		{GUID: "guid-b", SourceURL: "https://example.com/b"},
+		{GUID: "guid-c", SourceURL: "https://example.com/c"},
+		{GUID: "guid-c", SourceURL: "https://example.com/c"},
+	}}
+
+	w := NewQueuelessWorker(repo, parser)
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 3, URL: "https://example.com/feed", ParserKind: "rss"}); err != nil {
+		t.Fatalf("handle job: %v", err)
+	}
+	if len(repo.saved) != 1 || repo.saved[0].GUID != "guid-c" || repo.saved[0].SourceID != 3 {
+		t.Fatalf("expected only guid-c saved once, got %+v", repo.saved)
+	}
+}
+
+func TestWorker_CutsTitleAndAuthorToColumnSizes(t *testing.T) {
+	repo := &fakeSeenRepo{seen: map[string]bool{}}
+	parser := fakeItemsParser{items: []PendingRecord{{
+		GUID:      "guid-long",
+		SourceURL: "https://example.com/long",
+		Title:     strings.Repeat("标", maxTitleRunes+20),
+		Author:    strings.Repeat("a", maxAuthorRunes+1),
+	}}}
+
+	w := NewQueuelessWorker(repo, parser)
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 3, URL: "https://example.com/feed", ParserKind: "rss"}); err != nil {
+		t.Fatalf("handle job: %v", err)
+	}
+	if len(repo.saved) != 1 {
+		t.Fatalf("expected the item saved, got %+v", repo.saved)
+	}
+	if got := utf8.RuneCountInString(repo.saved[0].Title); got != maxTitleRunes {
+		t.Fatalf("expected title cut to %d runes, got %d", maxTitleRunes, got)
+	}
+	if got := len(repo.saved[0].Author); got != maxAuthorRunes {
+		t.Fatalf("expected author cut to %d runes, got %d", maxAuthorRunes, got)
+	}
+}
+
+type failingSaveRepo struct {
+	fakeSeenRepo
+	failURL string
+}
+
+func (r *failingSaveRepo) SavePending(ctx context.Context, rec PendingRecord) error {
+	if rec.SourceURL == r.failURL {
+		return errors.New("data too long for column")
+	}
+	return r.fakeSeenRepo.SavePending(ctx, rec)
+}
+
+func TestWorker_KeepsSavingFeedItemsAfterOneFails(t *testing.T) {
+	repo := &failingSaveRepo{failURL: "https://example.com/a"}
+	parser := fakeItemsParser{items: []PendingRecord{
+		{GUID: "guid-a", SourceURL: "https://example.com/a"},
+		{GUID: "guid-b", SourceURL: "https://example.com/b"},
+	}}
+
+	w := NewQueuelessWorker(repo, parser)
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 3, URL: "https://example.com/feed", ParserKind: "rss"}); err != nil {
+		t.Fatalf("expected the job to succeed with one item saved, got %v", err)
+	}
+	if len(repo.saved) != 1 || repo.saved[0].GUID != "guid-b" {
+		t.Fatalf("expected guid-b saved after guid-a failed, got %+v", repo.saved)
+	}
+
+	repo.failURL = "https://example.com/only"
+	parser.items = []PendingRecord{{GUID: "guid-only", SourceURL: "https://example.com/only"}}
+	w = NewQueuelessWorker(repo, parser)
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 3, URL: "https://example.com/feed", ParserKind: "rss"}); err == nil {
+		t.Fatal("expected the job to fail when no item could be saved")
+	}
+}
+
+type fakeFetchPublisher struct {
+	jobs []FetchJob
+}
+
+func (p *fakeFetchPublisher) Enqueue(_ context.Context, job FetchJob) error {
+	p.jobs = append(p.jobs, job)
+	return nil
+}
+
+type fakeListingParser struct {
+	fakeParser
+	page ListingPage
+}
+
+func (p fakeListingParser) ExtractLinks(context.Context, FetchJob) (ListingPage, error) {
+	return p.page, nil
+}
+
+func TestWorker_FansOutListingIntoChildJobs(t *testing.T) {
+	repo := &fakeSeenRepo{seen: map[string]bool{"https://example.com/news/seen": true}}
+	publisher := &fakeFetchPublisher{}
+	parser := fakeListingParser{page: ListingPage{
+		Links: []string{
+			"https://example.com/news/seen",
+			"https://example.com/about",
+			"https://example.com/news/sponsored-1",
+			"https://example.com/news/a",
+			"https://example.com/news/b",
+			"https://example.com/news/c",
+		},
+		Next: "https://example.com/news?page=2",
+	}}
+	sources := fakeSourceReader{item: source.DataSource{
+		CrawlInclude:  `/news/`,
+		CrawlExclude:  `sponsored`,
+		CrawlMaxDepth: 2,
+		CrawlMaxItems: 2,
+	}}
+
+	w := NewQueuelessWorker(repo, parser, WithFetchPublisher(publisher), WithSourceReader(sources))
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 5, URL: "https://example.com/news", ParserKind: ListingParserKind}); err != nil {
+		t.Fatalf("handle listing: %v", err)
+	}
+
+	if len(publisher.jobs) != 3 {
+		t.Fatalf("expected 2 article jobs and 1 next page job, got %+v", publisher.jobs)
+	}
+	if publisher.jobs[0].URL != "https://example.com/news/a" || publisher.jobs[0].ParserKind != "generic" || publisher.jobs[0].Depth != 1 {
+		t.Fatalf("unexpected first child job %+v", publisher.jobs[0])
+	}
+	if next := publisher.jobs[2]; next.ParserKind != ListingParserKind || next.Depth != 1 {
+		t.Fatalf("expected next listing page at depth 1, got %+v", next)
+	}
+	if len(repo.saved) != 0 {
+		t.Fatalf("expected listing page itself not saved, got %+v", repo.saved)
+	}
+
+	publisher.jobs = nil
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 5, URL: "https://example.com/news?page=2", ParserKind: ListingParserKind, Depth: 1}); err != nil {
+		t.Fatalf("handle listing page 2: %v", err)
+	}
+	for _, job := range publisher.jobs {
+		if job.ParserKind == ListingParserKind {
+			t.Fatalf("expected max depth to stop pagination, got %+v", job)
+		}
+	}
+}
+
+type fakeMarkingRepo struct {
+	fakeSeenRepo
+	queued map[string]time.Time
+}
+
+func (r *fakeMarkingRepo) WasQueued(_ context.Context, _ int64, link string, since time.Time) (bool, error) {
+	at, ok := r.queued[link]
+	return ok && !at.Before(since), nil
+}
+
+func (r *fakeMarkingRepo) MarkQueued(_ context.Context, _ int64, link string, at time.Time) error {
+	r.queued[link] = at
+	return nil
+}
+
+func (r *fakeMarkingRepo) PruneQueued(_ context.Context, _ int64, before time.Time) error {
+	for link, at := range r.queued {
+		if at.Before(before) {
+			delete(r.queued, link)
+		}
+	}
+	return nil
+}
+
+func TestWorker_ListingQueuesStoryLinksOnce(t *testing.T) {
+	repo := &fakeMarkingRepo{queued: map[string]time.Time{}}
+	publisher := &fakeFetchPublisher{}
+	parser := fakeListingParser{page: ListingPage{Links: []string{
+		"https://example.com/tag/ufc",
+		"https://example.com/news/jones-retains-heavyweight-title",
+		"https://example.com/news/20241117",
+	}}}
+
+	w := NewQueuelessWorker(repo, parser, WithFetchPublisher(publisher), WithSourceReader(fakeSourceReader{}))
+	job := FetchJob{SourceID: 5, URL: "https://example.com/news", ParserKind: ListingParserKind}
+	if err := w.HandleJob(context.Background(), job); err != nil {
+		t.Fatalf("handle listing: %v", err)
+	}
+	if len(publisher.jobs) != 2 || publisher.jobs[0].URL != "https://example.com/news/jones-retains-heavyweight-title" {
+		t.Fatalf("expected the two story links queued by the default pattern, got %+v", publisher.jobs)
+	}
+
+	publisher.jobs = nil
+	if err := w.HandleJob(context.Background(), job); err != nil {
+		t.Fatalf("handle listing again: %v", err)
+	}
+	if len(publisher.jobs) != 0 {
+		t.Fatalf("expected links queued by the last run skipped, got %+v", publisher.jobs)
+	}
+
+	// A child fetch that failed for good leaves its link unseen; after the TTL it is queued again.
+	w.now = func() time.Time { return time.Now().Add(queuedLinkTTL + time.Minute) }
+	if err := w.HandleJob(context.Background(), job); err != nil {
+		t.Fatalf("handle listing after the ttl: %v", err)
+	}
+	if len(publisher.jobs) != 2 {
+		t.Fatalf("expected expired marks to let the links be queued again, got %+v", publisher.jobs)
+	}
+}
+
+type fakeDuplicateRepo struct {
+	fakeSeenRepo
+	primaryID   int64
+	primaryHash uint64
+}
+
+func (r *fakeDuplicateRepo) FindNearDuplicate(_ context.Context, simhash uint64, maxDistance int) (int64, bool, error) {
+	if r.primaryHash != 0 && HammingDistance(simhash, r.primaryHash) <= maxDistance {
+		return r.primaryID, true, nil
+	}
+	return 0, false, nil
+}
+
+func TestWorker_MarksNearDuplicateOfPendingItem(t *testing.T) {
+	title := "Jon Jones vs Tom Aspinall confirmed for UFC 327 main event"
+	body := "The UFC confirmed on Friday that heavyweight champion Jon Jones will finally face interim champion Tom Aspinall in the main event of UFC 327 in Las Vegas."
+	repo := &fakeDuplicateRepo{primaryID: 41, primaryHash: SimHash(title, body)}
+	parser := fakeItemsParser{items: []PendingRecord{
+		{Title: title, Body: body, SourceURL: "https://other.example.com/jones-aspinall"},
+		{Title: "Weili Zhang returns to strawweight", Summary: "Former champion Zhang Weili is targeting a summer return.", SourceURL: "https://example.com/zhang"},
+	}}
+
+	w := NewQueuelessWorker(repo, parser)
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 2, URL: "https://other.example.com/feed", ParserKind: "rss"}); err != nil {
+		t.Fatalf("handle job: %v", err)
+	}
+	if len(repo.saved) != 2 {
+		t.Fatalf("expected both records saved, got %d", len(repo.saved))
+	}
+	if repo.saved[0].DuplicateOf != 41 || repo.saved[0].SimHash == 0 {
+		t.Fatalf("expected near-duplicate linked to 41, got %+v", repo.saved[0])
+	}
+	if repo.saved[1].DuplicateOf != 0 {
+		t.Fatalf("expected unrelated story to stay primary, got %+v", repo.saved[1])
+	}
+}
+
+type fakeEntityDirectory []linking.Candidate
+
+func (d fakeEntityDirectory) ListLinkCandidates(context.Context) ([]linking.Candidate, error) {
+	return d, nil
+}
+
+func TestWorker_SuggestsEntityLinks(t *testing.T) {
+	repo := &fakeDuplicateRepo{}
+	parser := fakeItemsParser{items: []PendingRecord{
+		{Title: "张伟丽宣布夏季复出", Summary: "前冠军张伟丽计划在 UFC 312 复出。", SourceURL: "https://example.com/zhang"},
+	}}
+	detector := linking.NewDetector(fakeEntityDirectory{
+		linking.FighterCandidate(7, "Zhang Weili", "张伟丽", "Magnum"),
+		linking.EventCandidate(9, "UFC 312: Du Plessis vs. Strickland 2"),
+	})
+
+	w := NewQueuelessWorker(repo, parser, WithEntityDetector(detector))
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 2, URL: "https://example.com/feed", ParserKind: "rss"}); err != nil {
+		t.Fatalf("handle job: %v", err)
+	}
+	links := repo.saved[0].Entities
+	if len(links) != 2 || links[0].EntityID != 7 || links[1].EntityType != linking.EntityEvent || links[1].MatchedText != "UFC 312" {
+		t.Fatalf("unexpected entity links: %+v", links)
+	}
+}
+
+type failingEntityDirectory struct{}
+
+func (failingEntityDirectory) ListLinkCandidates(context.Context) ([]linking.Candidate, error) {
+	return nil, errors.New("directory unavailable")
+}
+
+func TestWorker_SavesItemWithoutLinksWhenDetectionFails(t *testing.T) {
+	repo := &fakeDuplicateRepo{}
+	parser := fakeItemsParser{items: []PendingRecord{
+		{Title: "张伟丽宣布夏季复出", SourceURL: "https://example.com/zhang"},
+	}}
+
+	w := NewQueuelessWorker(repo, parser, WithEntityDetector(linking.NewDetector(failingEntityDirectory{})))
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 2, URL: "https://example.com/feed", ParserKind: "rss"}); err != nil {
+		t.Fatalf("expected detection failure tolerated, got %v", err)
+	}
+	if len(repo.saved) != 1 || len(repo.saved[0].Entities) != 0 {
+		t.Fatalf("expected item saved without links, got %+v", repo.saved)
+	}
+}
+
+func TestWorker_SuggestsTags(t *testing.T) {
+	repo := &fakeDuplicateRepo{}
+	parser := fakeItemsParser{items: []PendingRecord{
+		{Title: "专访：张伟丽谈复出", Summary: "UFC 前冠军接受采访。", SourceURL: "https://example.com/zhang"},
+	}}
+	tags := taxonomy.NewInMemoryRepository()
+	for _, tag := range []taxonomy.Tag{
+		{Slug: "interviews", Kind: taxonomy.KindCategory, Keywords: []string{"专访"}},
+		{Slug: "videos", Kind: taxonomy.KindCategory, SourceIDs: []int64{2}},
+		{Slug: "one", Kind: taxonomy.KindOrg, Keywords: []string{"ONE Championship"}},
+	} {
+		_, _ = tags.Create(context.Background(), tag)
+	}
+
+	w := NewQueuelessWorker(repo, parser, WithTagSuggester(taxonomy.NewSuggester(tags)))
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 2, URL: "https://example.com/feed", ParserKind: "rss"}); err != nil {
+		t.Fatalf("handle job: %v", err)
+	}
+	got := repo.saved[0].Tags
+	if len(got) != 2 || got[0] != "videos" || got[1] != "interviews" {
+		t.Fatalf("expected source rule then keyword tags, got %v", got)
+	}
+}
+
+type failingTagDirectory struct{}
+
+func (failingTagDirectory) List(context.Context) ([]taxonomy.Tag, error) {
+	return nil, errors.New("tags unavailable")
+}
+
+func TestWorker_SavesItemWithoutTagsWhenSuggestionFails(t *testing.T) {
+	repo := &fakeDuplicateRepo{}
+	parser := fakeItemsParser{items: []PendingRecord{
+		{Title: "专访：张伟丽谈复出", SourceURL: "https://example.com/zhang"},
+	}}
+
+	w := NewQueuelessWorker(repo, parser, WithTagSuggester(taxonomy.NewSuggester(failingTagDirectory{})))
+	if err := w.HandleJob(context.Background(), FetchJob{SourceID: 2, URL: "https://example.com/feed", ParserKind: "rss"}); err != nil {
+		t.Fatalf("expected tag failure tolerated, got %v", err)
+	}
+	if len(repo.saved) != 1 || len(repo.saved[0].Tags) != 0 {
+		t.Fatalf("expected item saved without tags, got %+v", repo.saved)
+	}
+}
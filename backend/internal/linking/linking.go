@@ -0,0 +1,261 @@
+package linking
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	EntityFighter = "fighter"
+	EntityEvent   = "event"
+
+	// StatusSuggested links come from name detection and still wait for an editor.
+	StatusSuggested = "suggested"
+	StatusConfirmed = "confirmed"
+	StatusRejected  = "rejected"
+)
+
+// DefaultRefresh is how long a detector keeps its name index before reloading it.
+const DefaultRefresh = 10 * time.Minute
+
+const (
+	minLatinAliasRunes = 4
+	minCJKAliasRunes   = 2
+)
+
+// Link ties an article to a fighter or event.
+type Link struct {
+	EntityType  string `json:"entity_type"`
+	EntityID    int64  `json:"entity_id"`
+	Name        string `json:"name,omitempty"`
+	MatchedText string `json:"matched_text,omitempty"`
+	Status      string `json:"status"`
+}
+
+// Candidate is one fighter or event with the names it can be mentioned by. Names match
+// case-insensitively; nicknames are often plain words ("Bones", "The Eagle"), so they only
+// match with their exact capitalisation.
+type Candidate struct {
+	EntityType string
+	EntityID   int64
+	Name       string
+	Names      []string
+	Nicknames  []string
+}
+
+// Directory lists the fighters and events articles can be linked to.
+type Directory interface {
+	ListLinkCandidates(ctx context.Context) ([]Candidate, error)
+}
+
+func ValidEntityType(entityType string) bool {
+	return entityType == EntityFighter || entityType == EntityEvent
+}
+
+func ValidStatus(status string) bool {
+	return status == StatusSuggested || status == StatusConfirmed || status == StatusRejected
+}
+
+// FighterCandidate builds a candidate from a fighter's English name, Chinese name and nickname.
+func FighterCandidate(id int64, name string, nameZH string, nickname string) Candidate {
+	return Candidate{
+		EntityType: EntityFighter,
+		EntityID:   id,
+		Name:       firstNonEmpty(nameZH, name),
+		Names:      nonEmpty(name, nameZH),
+		Nicknames:  nonEmpty(strings.Trim(strings.TrimSpace(nickname), `"'“”`)),
+	}
+}
+
+// EventCandidate builds a candidate from an event name. Headlines usually shorten
+// "UFC 300: Pereira vs. Hill" to "UFC 300", so a numbered prefix is matched on its own too.
+func EventCandidate(id int64, name string) Candidate {
+	names := nonEmpty(name)
+	for _, sep := range []string{":", "：", " - ", " – "} {
+		if idx := strings.Index(name, sep); idx > 0 {
+			prefix := strings.TrimSpace(name[:idx])
+			if strings.IndexFunc(prefix, unicode.IsDigit) >= 0 {
+				names = append(names, prefix)
+			}
+			break
+		}
+	}
+	return Candidate{EntityType: EntityEvent, EntityID: id, Name: strings.TrimSpace(name), Names: names}
+}
+
+// Match returns one suggested link per candidate mentioned in the title or body, in order of
+// first mention. Latin names must sit on word boundaries; CJK names match anywhere.
+func Match(candidates []Candidate, title string, body string) []Link {
+	text := title + "\n" + body
+	lower := strings.ToLower(text)
+
+	type hit struct {
+		link Link
+		pos  int
+	}
+	hits := make([]hit, 0)
+	for _, c := range candidates {
+		best := -1
+		matched := ""
+		try := func(haystack string, needle string, alias string) {
+			if pos := findAlias(haystack, needle); pos >= 0 && (best < 0 || pos < best) {
+				best = pos
+				matched = alias
+			}
+		}
+		for _, name := range c.Names {
+			if name = strings.TrimSpace(name); usableAlias(name) {
+				try(lower, strings.ToLower(name), name)
+			}
+		}
+		for _, nickname := range c.Nicknames {
+			if nickname = strings.TrimSpace(nickname); usableAlias(nickname) {
+				try(text, nickname, nickname)
+			}
+		}
+		if best < 0 {
+			continue
+		}
+		hits = append(hits, hit{
+			link: Link{
+				EntityType:  c.EntityType,
+				EntityID:    c.EntityID,
+				Name:        c.Name,
+				MatchedText: matched,
+				Status:      StatusSuggested,
+			},
+			pos: best,
+		})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].pos < hits[j].pos })
+
+	links := make([]Link, 0, len(hits))
+	for _, h := range hits {
+		links = append(links, h.link)
+	}
+	return links
+}
+
+// Merge adds detected links that are not in existing yet. Existing links keep their status,
+// so a re-run never undoes an editor's decision.
+func Merge(existing []Link, detected []Link) []Link {
+	merged := append([]Link(nil), existing...)
+	seen := make(map[Link]struct{}, len(existing))
+	for _, link := range existing {
+		seen[Link{EntityType: link.EntityType, EntityID: link.EntityID}] = struct{}{}
+	}
+	for _, link := range detected {
+		key := Link{EntityType: link.EntityType, EntityID: link.EntityID}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		merged = append(merged, link)
+	}
+	return merged
+}
+
+// Mentions reports whether text mentions term case-insensitively, with Latin terms on word
+// boundaries.
+func Mentions(text string, term string) bool {
+	term = strings.TrimSpace(term)
+	return term != "" && findAlias(strings.ToLower(text), strings.ToLower(term)) >= 0
+}
+
+func usableAlias(alias string) bool {
+	if alias == "" {
+		return false
+	}
+	for _, r := range alias {
+		if isCJK(r) {
+			return utf8.RuneCountInString(alias) >= minCJKAliasRunes
+		}
+	}
+	return utf8.RuneCountInString(alias) >= minLatinAliasRunes
+}
+
+// findAlias returns the byte offset of the first bounded occurrence of alias, or -1.
+func findAlias(text string, alias string) int {
+	for offset := 0; offset < len(text); {
+		idx := strings.Index(text[offset:], alias)
+		if idx < 0 {
+			return -1
+		}
+		start := offset + idx
+		end := start + len(alias)
+		if boundedBefore(text, start, alias) && boundedAfter(text, end, alias) {
+			return start
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+	return -1
+}
+
+func boundedBefore(text string, start int, alias string) bool {
+	first, _ := utf8.DecodeRuneInString(alias)
+	if start == 0 || isCJK(first) {
+		return true
+	}
+	prev, _ := utf8.DecodeLastRuneInString(text[:start])
+	return !isWordRune(prev)
+}
+
+func boundedAfter(text string, end int, alias string) bool {
+	last, _ := utf8.DecodeLastRuneInString(alias)
+	if end >= len(text) || isCJK(last) {
+		return true
+	}
+	next, _ := utf8.DecodeRuneInString(text[end:])
+	return !isWordRune(next)
+}
+
+func isWordRune(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !isCJK(r)
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+// Detector matches articles against the directory, reloading its candidates every refresh
+// interval so fighters and events added after startup are picked up.
+type Detector struct {
+	candidates *RefreshedList[Candidate]
+	now        func() time.Time
+}
+
+func NewDetector(dir Directory) *Detector {
+	return &Detector{candidates: NewRefreshedList(dir.ListLinkCandidates, DefaultRefresh), now: time.Now}
+}
+
+func (d *Detector) Detect(ctx context.Context, title string, body string) ([]Link, error) {
+	candidates, err := d.candidates.Get(ctx, d.now())
+	if err != nil {
+		return nil, err
+	}
+	return Match(candidates, title, body), nil
+}
+
+func nonEmpty(values ...string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
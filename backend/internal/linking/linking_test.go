@@ -0,0 +1,108 @@
+package linking
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMatch_FindsFightersAndEventsByAnyName(t *testing.T) {
+	candidates := []Candidate{
+		FighterCandidate(1, "Jon Jones", "琼斯", "Bones"),
+		FighterCandidate(2, "Tom Aspinall", "", ""),
+		FighterCandidate(3, "Zhang Weili", "张伟丽", "Magnum"),
+		EventCandidate(10, "UFC 300: Pereira vs. Hill"),
+		EventCandidate(11, "UFC 30"),
+	}
+
+	links := Match(candidates, "Aspinall calls out Bones after UFC 300", "Tom Aspinall wants the fight. 张伟丽 also fights on the card.")
+	if len(links) != 4 {
+		t.Fatalf("expected 4 links, got %+v", links)
+	}
+	want := []struct {
+		entityType string
+		id         int64
+		matched    string
+	}{
+		{EntityFighter, 1, "Bones"},
+		{EntityEvent, 10, "UFC 300"},
+		{EntityFighter, 2, "Tom Aspinall"},
+		{EntityFighter, 3, "张伟丽"},
+	}
+	for i, w := range want {
+		got := links[i]
+		if got.EntityType != w.entityType || got.EntityID != w.id || got.MatchedText != w.matched || got.Status != StatusSuggested {
+			t.Fatalf("link %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+	if links[3].Name != "张伟丽" {
+		t.Fatalf("expected Chinese display name, got %q", links[3].Name)
+	}
+}
+
+func TestMatch_RespectsWordBoundariesAndNicknameCase(t *testing.T) {
+	candidates := []Candidate{
+		FighterCandidate(1, "Jon Jones", "", "Bones"),
+		FighterCandidate(2, "Bo Li", "", ""),
+		EventCandidate(10, "UFC 300"),
+	}
+
+	links := Match(candidates, "UFC 3000 is not a thing", "Broken bones heal; Jon Jonesy is a typo.")
+	if len(links) != 0 {
+		t.Fatalf("expected no links, got %+v", links)
+	}
+	if links := Match(candidates, "JON JONES returns", ""); len(links) != 1 || links[0].EntityID != 1 {
+		t.Fatalf("expected case-insensitive name match, got %+v", links)
+	}
+}
+
+func TestMerge_KeepsEditorDecisions(t *testing.T) {
+	existing := []Link{{EntityType: EntityFighter, EntityID: 1, Status: StatusRejected}}
+	detected := []Link{
+		{EntityType: EntityFighter, EntityID: 1, Status: StatusSuggested},
+		{EntityType: EntityEvent, EntityID: 1, Status: StatusSuggested},
+	}
+
+	merged := Merge(existing, detected)
+	if len(merged) != 2 || merged[0].Status != StatusRejected || merged[1].EntityType != EntityEvent {
+		t.Fatalf("unexpected merge result: %+v", merged)
+	}
+}
+
+type fakeDirectory struct {
+	calls      int
+	candidates []Candidate
+	err        error
+}
+
+func (d *fakeDirectory) ListLinkCandidates(context.Context) ([]Candidate, error) {
+	d.calls++
+	return d.candidates, d.err
+}
+
+func TestDetector_CachesAndRefreshesIndex(t *testing.T) {
+	dir := &fakeDirectory{candidates: []Candidate{FighterCandidate(1, "Jon Jones", "", "")}}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	detector := NewDetector(dir)
+	detector.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		links, err := detector.Detect(context.Background(), "Jon Jones", "")
+		if err != nil || len(links) != 1 {
+			t.Fatalf("detect: %v %+v", err, links)
+		}
+	}
+	if dir.calls != 1 {
+		t.Fatalf("expected one directory load, got %d", dir.calls)
+	}
+
+	now = now.Add(DefaultRefresh)
+	dir.err = errors.New("db down")
+	if links, err := detector.Detect(context.Background(), "Jon Jones", ""); err != nil || len(links) != 1 {
+		t.Fatalf("expected stale index on reload failure, got %v %+v", err, links)
+	}
+	if dir.calls != 2 {
+		t.Fatalf("expected a reload attempt, got %d", dir.calls)
+	}
+}
@@ -0,0 +1,47 @@
+package linking
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RefreshedList caches a list read from the database, reloading it once it is older than the
+// refresh interval. A failed reload keeps the previous list: stale suggestions are better
+// than none while the database blips.
+type RefreshedList[T any] struct {
+	load    func(ctx context.Context) ([]T, error)
+	refresh time.Duration
+
+	mu       sync.Mutex
+	items    []T
+	loadedAt time.Time
+}
+
+func NewRefreshedList[T any](load func(ctx context.Context) ([]T, error), refresh time.Duration) *RefreshedList[T] {
+	return &RefreshedList[T]{load: load, refresh: refresh}
+}
+
+// Get returns the cached list, reloading it first when it is older than the refresh interval
+// at now. It fails only when nothing has been loaded yet.
+func (l *RefreshedList[T]) Get(ctx context.Context, now time.Time) ([]T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.items != nil && now.Sub(l.loadedAt) < l.refresh {
+		return l.items, nil
+	}
+	items, err := l.load(ctx)
+	if err != nil {
+		if l.items != nil {
+			return l.items, nil
+		}
+		return nil, err
+	}
+	if items == nil {
+		items = []T{}
+	}
+	l.items = items
+	l.loadedAt = now
+	return items, nil
+}
@@ -0,0 +1,43 @@
+package live
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	PollOutcomeUnchanged = "unchanged"
+	PollOutcomeChanged   = "changed"
+	PollOutcomeCompleted = "completed"
+	PollOutcomeFailed    = "failed"
+)
+
+// EventMonitorStatus is the monitor's view of one tracked event.
+type EventMonitorStatus struct {
+	EventID            int64      `json:"event_id"`
+	Org                string     `json:"org"`
+	Status             string     `json:"status"`
+	Paused             bool       `json:"paused"`
+	NextCheckAt        *time.Time `json:"next_check_at,omitempty"`
+	Failures           int        `json:"consecutive_failures"`
+	LastPollAt         *time.Time `json:"last_poll_at,omitempty"`
+	LastOutcome        string     `json:"last_outcome,omitempty"`
+	LastError          string     `json:"last_error,omitempty"`
+	LastChangedBoutIDs []int64    `json:"last_changed_bout_ids,omitempty"`
+}
+
+// MonitorSnapshot is published by the worker after every monitor tick.
+type MonitorSnapshot struct {
+	UpdatedAt time.Time            `json:"updated_at"`
+	Events    []EventMonitorStatus `json:"events"`
+}
+
+// ControlStore shares monitor state and operator commands between the API and the worker.
+type ControlStore interface {
+	SaveSnapshot(ctx context.Context, snapshot MonitorSnapshot) error
+	LoadSnapshot(ctx context.Context) (MonitorSnapshot, bool, error)
+	RequestPoll(ctx context.Context, eventID int64) error
+	TakePollRequests(ctx context.Context) ([]int64, error)
+	SetPaused(ctx context.Context, eventID int64, paused bool) error
+	PausedEvents(ctx context.Context) (map[int64]bool, error)
+}
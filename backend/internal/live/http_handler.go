@@ -0,0 +1,142 @@
+package live
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
+)
+
+func RegisterAdminLiveRoutes(r *gin.Engine, control ControlStore) {
+	r.GET("/admin/live/status", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		snapshot, ok, err := control.LoadSnapshot(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		paused, err := control.PausedEvents(ctx)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		items := make([]EventMonitorStatus, 0, len(snapshot.Events))
+		for _, item := range snapshot.Events {
+			// Pause flags are read live so the toggle shows before the worker's next tick.
+			item.Paused = paused[item.EventID]
+			items = append(items, item)
+		}
+		resp := gin.H{"items": items}
+		if ok {
+			resp["updated_at"] = snapshot.UpdatedAt
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	r.POST("/admin/live/events/:id/poll-now", func(c *gin.Context) {
+		eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+			return
+		}
+		if err := control.RequestPoll(c.Request.Context(), eventID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{"ok": true})
+	})
+
+	r.POST("/admin/live/events/:id/pause", func(c *gin.Context) {
+		setPaused(c, control, true)
+	})
+
+	r.POST("/admin/live/events/:id/resume", func(c *gin.Context) {
+		setPaused(c, control, false)
+	})
+}
+
+func setPaused(c *gin.Context, control ControlStore, paused bool) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+		return
+	}
+	if err := control.SetPaused(c.Request.Context(), eventID, paused); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"ok": true})
+}
+
+type manualResultRequest struct {
+	WinnerID int64  `json:"winner_id"`
+	Method   string `json:"method"`
+	Round    int    `json:"round"`
+	TimeSec  int    `json:"time_sec"`
+	Result   string `json:"result"`
+}
+
+func RegisterAdminLiveScoringRoutes(r *gin.Engine, scoring *ManualScoring) {
+	r.PUT("/admin/live/events/:id/bouts/:bout_id/result", func(c *gin.Context) {
+		eventID, boutID, ok := parseBoutPath(c)
+		if !ok {
+			return
+		}
+		var req manualResultRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if req.Round < 0 || req.TimeSec < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "round and time_sec must not be negative"})
+			return
+		}
+		err := scoring.SetResult(c.Request.Context(), eventID, boutID, event.BoutResultInput{
+			WinnerID: req.WinnerID,
+			Method:   req.Method,
+			Round:    req.Round,
+			TimeSec:  req.TimeSec,
+			Result:   req.Result,
+		}, c.GetInt64("admin_user_id"))
+		writeScoringResult(c, err)
+	})
+
+	r.DELETE("/admin/live/events/:id/bouts/:bout_id/result", func(c *gin.Context) {
+		eventID, boutID, ok := parseBoutPath(c)
+		if !ok {
+			return
+		}
+		writeScoringResult(c, scoring.Release(c.Request.Context(), eventID, boutID))
+	})
+}
+
+func parseBoutPath(c *gin.Context) (int64, int64, bool) {
+	eventID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+		return 0, 0, false
+	}
+	boutID, err := strconv.ParseInt(c.Param("bout_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid bout id"})
+		return 0, 0, false
+	}
+	return eventID, boutID, true
+}
+
+func writeScoringResult(c *gin.Context, err error) {
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	case errors.Is(err, event.ErrBoutNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, event.ErrInvalidWinner):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,60 @@
+package live
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminLiveRoutes_StatusAndControls(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	control := &fakeControlStore{
+		snapshot: MonitorSnapshot{
+			UpdatedAt: time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC),
+			Events:    []EventMonitorStatus{{EventID: 10, Org: "UFC", Status: "live"}},
+		},
+		saved: 1,
+	}
+	r := gin.New()
+	RegisterAdminLiveRoutes(r, control)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/live/events/10/pause", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 on pause, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/live/status", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var resp struct {
+		Items []EventMonitorStatus `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode status: %v", err)
+	}
+	if len(resp.Items) != 1 || !resp.Items[0].Paused {
+		t.Fatalf("expected paused event in status, got %+v", resp.Items)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/live/events/10/poll-now", nil))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 on poll-now, got %d", w.Code)
+	}
+	if len(control.pollNow) != 1 || control.pollNow[0] != 10 {
+		t.Fatalf("expected poll request for event 10, got %v", control.pollNow)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/live/events/abc/resume", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid id, got %d", w.Code)
+	}
+}
@@ -0,0 +1,52 @@
+package live
+
+import (
+	"context"
+	"strings"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
+)
+
+// ManualResultRepository stores operator-entered results. Manually scored bouts stay
+// locked against provider writes until released.
+type ManualResultRepository interface {
+	RecordManualBoutResult(ctx context.Context, eventID int64, boutID int64, input event.BoutResultInput, editorID int64) error
+	ReleaseBoutResult(ctx context.Context, eventID int64, boutID int64) error
+}
+
+// ManualScoring lets operators score bouts live when the provider lags or is down.
+type ManualScoring struct {
+	repo  ManualResultRepository
+	cache UFCEventCache
+}
+
+// NewManualScoring returns the scoring service. cache may be nil when event cards are not cached.
+func NewManualScoring(repo ManualResultRepository, cache UFCEventCache) *ManualScoring {
+	return &ManualScoring{repo: repo, cache: cache}
+}
+
+func (s *ManualScoring) SetResult(ctx context.Context, eventID int64, boutID int64, input event.BoutResultInput, editorID int64) error {
+	input.Method = strings.TrimSpace(input.Method)
+	input.Result = strings.TrimSpace(input.Result)
+	if err := s.repo.RecordManualBoutResult(ctx, eventID, boutID, input, editorID); err != nil {
+		return err
+	}
+	invalidateEventCache(ctx, s.cache, eventID)
+	return nil
+}
+
+func (s *ManualScoring) Release(ctx context.Context, eventID int64, boutID int64) error {
+	if err := s.repo.ReleaseBoutResult(ctx, eventID, boutID); err != nil {
+		return err
+	}
+	invalidateEventCache(ctx, s.cache, eventID)
+	return nil
+}
+
+func invalidateEventCache(ctx context.Context, cache UFCEventCache, eventID int64) {
+	if cache == nil {
+		return
+	}
+	_ = cache.InvalidateEvent(ctx, eventID)
+	_ = cache.InvalidateEvents(ctx)
+}
@@ -0,0 +1,82 @@
+package live
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
+)
+
+type fakeManualResultRepo struct {
+	input    event.BoutResultInput
+	editorID int64
+	released []int64
+}
+
+func (r *fakeManualResultRepo) RecordManualBoutResult(_ context.Context, _ int64, boutID int64, input event.BoutResultInput, editorID int64) error {
+	if boutID != 1001 {
+		return event.ErrBoutNotFound
+	}
+	if input.WinnerID != 0 && input.WinnerID != 20 && input.WinnerID != 21 {
+		return event.ErrInvalidWinner
+	}
+	r.input = input
+	r.editorID = editorID
+	return nil
+}
+
+func (r *fakeManualResultRepo) ReleaseBoutResult(_ context.Context, _ int64, boutID int64) error {
+	if boutID != 1001 {
+		return event.ErrBoutNotFound
+	}
+	r.released = append(r.released, boutID)
+	return nil
+}
+
+func TestAdminLiveScoringRoutes_SetAndRelease(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := &fakeManualResultRepo{}
+	cache := &fakeUFCCache{}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("admin_user_id", int64(7))
+		c.Next()
+	})
+	RegisterAdminLiveScoringRoutes(r, NewManualScoring(repo, cache))
+
+	body := `{"winner_id":20,"method":" KO/TKO ","round":2,"time_sec":95}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/admin/live/events/10/bouts/1001/result", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.input.WinnerID != 20 || repo.input.Method != "KO/TKO" || repo.editorID != 7 {
+		t.Fatalf("unexpected manual result %+v by %d", repo.input, repo.editorID)
+	}
+	if len(cache.invalidatedEventIDs) != 1 || cache.invalidatedEvents != 1 {
+		t.Fatalf("expected event caches invalidated once, got %v/%d", cache.invalidatedEventIDs, cache.invalidatedEvents)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/admin/live/events/10/bouts/1001/result", strings.NewReader(`{"winner_id":99}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for foreign winner, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/live/events/10/bouts/1002/result", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown bout, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/live/events/10/bouts/1001/result", nil))
+	if w.Code != http.StatusOK || len(repo.released) != 1 {
+		t.Fatalf("expected release to succeed, got %d", w.Code)
+	}
+}
@@ -0,0 +1,77 @@
+package live
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// EventResults is a normalized live snapshot of one event card, bouts in card order.
+type EventResults struct {
+	Status string
+	Bouts  []BoutResult
+}
+
+// BoutResult is one bout outcome as reported by a results provider.
+type BoutResult struct {
+	WinnerSide string
+	Method     string
+	Round      int
+	TimeSec    int
+	Result     string
+	// State is the live bout state when the source exposes one, otherwise empty.
+	State        string
+	CurrentRound int
+}
+
+// ResultsProvider fetches live results for an event page of one organization.
+type ResultsProvider interface {
+	FetchEventResults(ctx context.Context, eventURL string) (EventResults, error)
+}
+
+type ProviderRegistry struct {
+	providers map[string]ResultsProvider
+}
+
+func NewProviderRegistry(providers map[string]ResultsProvider) *ProviderRegistry {
+	normalized := make(map[string]ResultsProvider, len(providers))
+	for org, provider := range providers {
+		key := normalizeOrg(org)
+		if key == "" || provider == nil {
+			continue
+		}
+		normalized[key] = provider
+	}
+	return &ProviderRegistry{providers: normalized}
+}
+
+func NewDefaultProviderRegistry(ufcScraper UFCEventScraper) *ProviderRegistry {
+	return NewProviderRegistry(map[string]ResultsProvider{
+		"UFC": NewUFCResultsProvider(ufcScraper),
+	})
+}
+
+func (r *ProviderRegistry) Lookup(org string) (ResultsProvider, bool) {
+	if r == nil {
+		return nil, false
+	}
+	provider, ok := r.providers[normalizeOrg(org)]
+	return provider, ok
+}
+
+// Orgs returns the registered organizations in stable order.
+func (r *ProviderRegistry) Orgs() []string {
+	if r == nil {
+		return nil
+	}
+	items := make([]string, 0, len(r.providers))
+	for org := range r.providers {
+		items = append(items, org)
+	}
+	sort.Strings(items)
+	return items
+}
+
+func normalizeOrg(org string) string {
+	return strings.ToUpper(strings.TrimSpace(org))
+}
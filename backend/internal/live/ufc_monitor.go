@@ -6,13 +6,14 @@ import (
 	"strings"
 	"time"
 
-	"github.com/bajiaozhi/w-mma/backend/internal/ufc"
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
 )
 
 const staleLiveCompletionWindow = 18 * time.Hour
 
 type UFCTrackableEvent struct {
 	ID          int64
+	Org         string
 	Status      string
 	StartsAt    time.Time
 	ExternalURL string
@@ -28,17 +29,18 @@ type UFCBoutSnapshot struct {
 	Round         int
 	TimeSec       int
 	Result        string
+	LiveState     string
+	CurrentRound  int
+	// ResultLocked marks a manually scored bout; the provider must not overwrite it.
+	ResultLocked bool
 }
 
 type UFCEventRepository interface {
-	ListTrackableEvents(ctx context.Context) ([]UFCTrackableEvent, error)
+	ListTrackableEvents(ctx context.Context, orgs []string) ([]UFCTrackableEvent, error)
 	ListBoutSnapshots(ctx context.Context, eventID int64) ([]UFCBoutSnapshot, error)
 	UpdateEventStatus(ctx context.Context, eventID int64, status string) error
 	UpsertBoutResult(ctx context.Context, eventID int64, boutID int64, winnerID int64, method string, round int, timeSec int, result string) error
-}
-
-type UFCEventScraper interface {
-	GetEventCard(ctx context.Context, eventURL string) (ufc.EventCard, error)
+	UpdateBoutLiveState(ctx context.Context, eventID int64, boutID int64, state string, currentRound int) error
 }
 
 type UFCEventCache interface {
@@ -54,14 +56,20 @@ type UFCLiveMonitorConfig struct {
 	RetryBackoffPlan []time.Duration
 	Random           *rand.Rand
 	Now              func() time.Time
+	// Control is optional; when set the monitor publishes its status and honors
+	// poll-now and pause requests from the admin API.
+	Control         ControlStore
+	ControlInterval time.Duration
 }
 
 type UFCLiveMonitor struct {
-	repo    UFCEventRepository
-	scraper UFCEventScraper
-	cache   UFCEventCache
+	repo      UFCEventRepository
+	providers *ProviderRegistry
+	cache     UFCEventCache
+	control   ControlStore
 
 	tickInterval     time.Duration
+	controlInterval  time.Duration
 	minPollInterval  time.Duration
 	maxPollInterval  time.Duration
 	maxPollPerTick   int
@@ -71,12 +79,24 @@ type UFCLiveMonitor struct {
 
 	nextCheckAt map[int64]time.Time
 	failure     map[int64]int
+	lastPoll    map[int64]pollReport
+	pollNow     map[int64]struct{}
+}
+
+type pollReport struct {
+	At             time.Time
+	Outcome        string
+	Error          string
+	ChangedBoutIDs []int64
 }
 
-func NewUFCLiveMonitor(repo UFCEventRepository, scraper UFCEventScraper, cache UFCEventCache, cfg UFCLiveMonitorConfig) *UFCLiveMonitor {
+func NewUFCLiveMonitor(repo UFCEventRepository, providers *ProviderRegistry, cache UFCEventCache, cfg UFCLiveMonitorConfig) *UFCLiveMonitor {
 	if cfg.TickInterval <= 0 {
 		cfg.TickInterval = time.Minute
 	}
+	if cfg.ControlInterval <= 0 {
+		cfg.ControlInterval = 5 * time.Second
+	}
 	if cfg.MinPollInterval <= 0 {
 		cfg.MinPollInterval = 5 * time.Minute
 	}
@@ -101,9 +121,11 @@ func NewUFCLiveMonitor(repo UFCEventRepository, scraper UFCEventScraper, cache U
 
 	return &UFCLiveMonitor{
 		repo:             repo,
-		scraper:          scraper,
+		providers:        providers,
 		cache:            cache,
+		control:          cfg.Control,
 		tickInterval:     cfg.TickInterval,
+		controlInterval:  cfg.ControlInterval,
 		minPollInterval:  cfg.MinPollInterval,
 		maxPollInterval:  cfg.MaxPollInterval,
 		maxPollPerTick:   cfg.MaxPollPerTick,
@@ -112,6 +134,8 @@ func NewUFCLiveMonitor(repo UFCEventRepository, scraper UFCEventScraper, cache U
 		now:              cfg.Now,
 		nextCheckAt:      map[int64]time.Time{},
 		failure:          map[int64]int{},
+		lastPoll:         map[int64]pollReport{},
+		pollNow:          map[int64]struct{}{},
 	}
 }
 
@@ -120,99 +144,206 @@ func (m *UFCLiveMonitor) Run(ctx context.Context) {
 	ticker := time.NewTicker(m.tickInterval)
 	defer ticker.Stop()
 
+	var controlC <-chan time.Time
+	if m.control != nil {
+		controlTicker := time.NewTicker(m.controlInterval)
+		defer controlTicker.Stop()
+		controlC = controlTicker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
 			_ = m.RunOnce(ctx)
+		case <-controlC:
+			// Poll-now requests should not wait for the next regular tick.
+			if m.takePollRequests(ctx) > 0 {
+				_ = m.RunOnce(ctx)
+			}
 		}
 	}
 }
 
 func (m *UFCLiveMonitor) RunOnce(ctx context.Context) error {
-	events, err := m.repo.ListTrackableEvents(ctx)
+	events, err := m.repo.ListTrackableEvents(ctx, m.providers.Orgs())
 	if err != nil {
 		return err
 	}
 	now := m.now().UTC()
 	active := map[int64]struct{}{}
-	for _, event := range events {
-		active[event.ID] = struct{}{}
+	for _, item := range events {
+		active[item.ID] = struct{}{}
 	}
 	for eventID := range m.nextCheckAt {
 		if _, ok := active[eventID]; !ok {
-			delete(m.nextCheckAt, eventID)
-			delete(m.failure, eventID)
+			m.forget(eventID)
+		}
+	}
+	for eventID := range m.lastPoll {
+		if _, ok := active[eventID]; !ok {
+			m.forget(eventID)
 		}
 	}
 
+	m.takePollRequests(ctx)
+	paused := map[int64]bool{}
+	if m.control != nil {
+		if items, err := m.control.PausedEvents(ctx); err == nil {
+			paused = items
+		}
+	}
+
+	statuses := make([]EventMonitorStatus, 0, len(events))
 	pollCount := 0
 	for _, item := range events {
 		if strings.TrimSpace(item.ExternalURL) == "" {
 			continue
 		}
+		if _, ok := m.providers.Lookup(item.Org); !ok {
+			continue
+		}
 
 		status := strings.ToLower(strings.TrimSpace(item.Status))
-		if status == "scheduled" && !item.StartsAt.IsZero() && !item.StartsAt.After(now) {
-			if err := m.repo.UpdateEventStatus(ctx, item.ID, "live"); err == nil {
-				status = "live"
-				m.invalidateCache(ctx, item.ID)
-			}
-		}
-		if status != "live" {
-			continue
+		m.pollEvent(ctx, item, &status, paused[item.ID], now, &pollCount)
+		statuses = append(statuses, m.eventStatus(item, status, paused[item.ID]))
+	}
+
+	m.publishSnapshot(ctx, now, statuses)
+	return nil
+}
+
+func (m *UFCLiveMonitor) pollEvent(ctx context.Context, item UFCTrackableEvent, status *string, paused bool, now time.Time, pollCount *int) {
+	if *status == "scheduled" && !item.StartsAt.IsZero() && !item.StartsAt.After(now) {
+		if err := m.repo.UpdateEventStatus(ctx, item.ID, "live"); err == nil {
+			*status = "live"
+			m.invalidateCache(ctx, item.ID)
 		}
-		if !item.StartsAt.IsZero() && item.StartsAt.Before(now.Add(-staleLiveCompletionWindow)) {
-			if err := m.repo.UpdateEventStatus(ctx, item.ID, "completed"); err == nil {
-				delete(m.nextCheckAt, item.ID)
-				delete(m.failure, item.ID)
-				m.invalidateCache(ctx, item.ID)
-			}
-			continue
+	}
+	if *status != "live" {
+		delete(m.pollNow, item.ID)
+		return
+	}
+	if !item.StartsAt.IsZero() && item.StartsAt.Before(now.Add(-staleLiveCompletionWindow)) {
+		if err := m.repo.UpdateEventStatus(ctx, item.ID, "completed"); err == nil {
+			*status = "completed"
+			m.forget(item.ID)
+			m.invalidateCache(ctx, item.ID)
 		}
+		return
+	}
 
+	_, forced := m.pollNow[item.ID]
+	if !forced {
+		if paused {
+			return
+		}
 		dueAt, ok := m.nextCheckAt[item.ID]
 		if !ok {
 			m.nextCheckAt[item.ID] = now.Add(m.randomInterval())
-			continue
+			return
 		}
 		if now.Before(dueAt) {
-			continue
+			return
 		}
-		if pollCount >= m.maxPollPerTick {
-			continue
+		if *pollCount >= m.maxPollPerTick {
+			return
 		}
-		pollCount++
+		*pollCount++
+	}
+	delete(m.pollNow, item.ID)
 
-		completed, err := m.pollLiveEvent(ctx, item, now)
-		if err != nil {
-			m.applyBackoff(item.ID, now)
-			continue
-		}
-		if completed {
-			delete(m.nextCheckAt, item.ID)
-			delete(m.failure, item.ID)
-			continue
-		}
+	completed, changedBoutIDs, err := m.pollLiveEvent(ctx, item, now)
+	if err != nil {
+		m.lastPoll[item.ID] = pollReport{At: now, Outcome: PollOutcomeFailed, Error: err.Error()}
+		m.applyBackoff(item.ID, now)
+		return
+	}
+	report := pollReport{At: now, Outcome: PollOutcomeUnchanged, ChangedBoutIDs: changedBoutIDs}
+	if len(changedBoutIDs) > 0 {
+		report.Outcome = PollOutcomeChanged
+	}
+	if completed {
+		*status = "completed"
+		report.Outcome = PollOutcomeCompleted
+		delete(m.nextCheckAt, item.ID)
+		delete(m.failure, item.ID)
+		m.lastPoll[item.ID] = report
+		return
+	}
+
+	m.lastPoll[item.ID] = report
+	m.failure[item.ID] = 0
+	m.nextCheckAt[item.ID] = now.Add(m.randomInterval())
+}
 
-		m.failure[item.ID] = 0
-		m.nextCheckAt[item.ID] = now.Add(m.randomInterval())
+func (m *UFCLiveMonitor) takePollRequests(ctx context.Context) int {
+	if m.control == nil {
+		return 0
 	}
-	return nil
+	ids, err := m.control.TakePollRequests(ctx)
+	if err != nil {
+		return 0
+	}
+	for _, id := range ids {
+		m.pollNow[id] = struct{}{}
+	}
+	return len(ids)
 }
 
-func (m *UFCLiveMonitor) pollLiveEvent(ctx context.Context, event UFCTrackableEvent, now time.Time) (bool, error) {
-	card, err := m.scraper.GetEventCard(ctx, event.ExternalURL)
+func (m *UFCLiveMonitor) forget(eventID int64) {
+	delete(m.nextCheckAt, eventID)
+	delete(m.failure, eventID)
+	delete(m.lastPoll, eventID)
+	delete(m.pollNow, eventID)
+}
+
+func (m *UFCLiveMonitor) eventStatus(item UFCTrackableEvent, status string, paused bool) EventMonitorStatus {
+	out := EventMonitorStatus{
+		EventID:  item.ID,
+		Org:      item.Org,
+		Status:   status,
+		Paused:   paused,
+		Failures: m.failure[item.ID],
+	}
+	if next, ok := m.nextCheckAt[item.ID]; ok {
+		next := next
+		out.NextCheckAt = &next
+	}
+	if report, ok := m.lastPoll[item.ID]; ok {
+		at := report.At
+		out.LastPollAt = &at
+		out.LastOutcome = report.Outcome
+		out.LastError = report.Error
+		out.LastChangedBoutIDs = report.ChangedBoutIDs
+	}
+	return out
+}
+
+func (m *UFCLiveMonitor) publishSnapshot(ctx context.Context, now time.Time, statuses []EventMonitorStatus) {
+	if m.control == nil {
+		return
+	}
+	_ = m.control.SaveSnapshot(ctx, MonitorSnapshot{UpdatedAt: now, Events: statuses})
+}
+
+func (m *UFCLiveMonitor) pollLiveEvent(ctx context.Context, event UFCTrackableEvent, now time.Time) (bool, []int64, error) {
+	provider, ok := m.providers.Lookup(event.Org)
+	if !ok {
+		return false, nil, nil
+	}
+	card, err := provider.FetchEventResults(ctx, event.ExternalURL)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	bouts, err := m.repo.ListBoutSnapshots(ctx, event.ID)
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 
 	changed := false
+	changedBoutIDs := make([]int64, 0)
 	limit := len(card.Bouts)
 	if len(bouts) < limit {
 		limit = len(bouts)
@@ -220,34 +351,47 @@ func (m *UFCLiveMonitor) pollLiveEvent(ctx context.Context, event UFCTrackableEv
 	for idx := 0; idx < limit; idx++ {
 		src := card.Bouts[idx]
 		current := bouts[idx]
+		if current.ResultLocked {
+			continue
+		}
 
 		winnerID := winnerIDBySide(src.WinnerSide, current.RedFighterID, current.BlueFighterID)
 		method := strings.TrimSpace(src.Method)
 		round := src.Round
 		timeSec := src.TimeSec
 		result := strings.TrimSpace(src.Result)
+		boutChanged := false
 
-		if winnerID == 0 && method == "" && round <= 0 && timeSec <= 0 && result == "" {
-			continue
+		if state, currentRound, ok := resolveBoutState(src); ok &&
+			(state != current.LiveState || currentRound != current.CurrentRound) {
+			if err := m.repo.UpdateBoutLiveState(ctx, event.ID, current.BoutID, state, currentRound); err != nil {
+				return false, nil, err
+			}
+			boutChanged = true
 		}
-		if winnerID == current.WinnerID &&
-			method == strings.TrimSpace(current.Method) &&
-			round == current.Round &&
-			timeSec == current.TimeSec &&
-			result == strings.TrimSpace(current.Result) {
-			continue
+
+		reported := winnerID != 0 || method != "" || round > 0 || timeSec > 0 || result != ""
+		if reported && (winnerID != current.WinnerID ||
+			method != strings.TrimSpace(current.Method) ||
+			round != current.Round ||
+			timeSec != current.TimeSec ||
+			result != strings.TrimSpace(current.Result)) {
+			if err := m.repo.UpsertBoutResult(ctx, event.ID, current.BoutID, winnerID, method, round, timeSec, result); err != nil {
+				return false, nil, err
+			}
+			boutChanged = true
 		}
-		if err := m.repo.UpsertBoutResult(ctx, event.ID, current.BoutID, winnerID, method, round, timeSec, result); err != nil {
-			return false, err
+		if boutChanged {
+			changed = true
+			changedBoutIDs = append(changedBoutIDs, current.BoutID)
 		}
-		changed = true
 	}
 
 	status := normalizePolledStatus(card.Status, event.StartsAt, now, card.Bouts)
 	completed := status == "completed"
 	if completed {
 		if err := m.repo.UpdateEventStatus(ctx, event.ID, "completed"); err != nil {
-			return false, err
+			return false, nil, err
 		}
 		changed = true
 	}
@@ -255,7 +399,48 @@ func (m *UFCLiveMonitor) pollLiveEvent(ctx context.Context, event UFCTrackableEv
 	if changed {
 		m.invalidateCache(ctx, event.ID)
 	}
-	return completed, nil
+	return completed, changedBoutIDs, nil
+}
+
+// resolveBoutState returns the bout live state reported by the provider.
+// Bouts with an outcome are finished even when the source sends no explicit state.
+func resolveBoutState(src BoutResult) (string, int, bool) {
+	state := normalizeBoutState(src.State)
+	if state == "" && boutOutcomeReported(src) {
+		state = event.BoutStateFinished
+	}
+	switch state {
+	case "":
+		return "", 0, false
+	case event.BoutStateInProgress:
+		return state, src.CurrentRound, true
+	default:
+		return state, 0, true
+	}
+}
+
+func normalizeBoutState(raw string) string {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case event.BoutStateUpcoming, "scheduled":
+		return event.BoutStateUpcoming
+	case event.BoutStateWalkouts, "walkout":
+		return event.BoutStateWalkouts
+	case event.BoutStateInProgress, "in-progress", "live":
+		return event.BoutStateInProgress
+	case event.BoutStateFinished, "final", "completed":
+		return event.BoutStateFinished
+	default:
+		return ""
+	}
+}
+
+func boutOutcomeReported(item BoutResult) bool {
+	if winnerIDBySide(item.WinnerSide, 1, 2) != 0 {
+		return true
+	}
+	return strings.TrimSpace(item.Result) != "" ||
+		strings.TrimSpace(item.Method) != "" ||
+		item.Round > 0 || item.TimeSec > 0
 }
 
 func winnerIDBySide(side string, redID int64, blueID int64) int64 {
@@ -269,7 +454,7 @@ func winnerIDBySide(side string, redID int64, blueID int64) int64 {
 	}
 }
 
-func normalizePolledStatus(raw string, startsAt time.Time, now time.Time, bouts []ufc.EventBout) string {
+func normalizePolledStatus(raw string, startsAt time.Time, now time.Time, bouts []BoutResult) string {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case "completed", "final":
 		return "completed"
@@ -302,20 +487,14 @@ func normalizePolledStatus(raw string, startsAt time.Time, now time.Time, bouts
 	return "live"
 }
 
-func allBoutsResolved(items []ufc.EventBout) bool {
+func allBoutsResolved(items []BoutResult) bool {
 	if len(items) == 0 {
 		return false
 	}
 	for _, item := range items {
-		if winnerIDBySide(item.WinnerSide, 1, 2) != 0 {
-			continue
-		}
-		if strings.TrimSpace(item.Result) != "" ||
-			strings.TrimSpace(item.Method) != "" ||
-			item.Round > 0 || item.TimeSec > 0 {
-			continue
+		if !boutOutcomeReported(item) {
+			return false
 		}
-		return false
 	}
 	return true
 }
@@ -338,9 +517,5 @@ func (m *UFCLiveMonitor) applyBackoff(eventID int64, now time.Time) {
 }
 
 func (m *UFCLiveMonitor) invalidateCache(ctx context.Context, eventID int64) {
-	if m.cache == nil {
-		return
-	}
-	_ = m.cache.InvalidateEvent(ctx, eventID)
-	_ = m.cache.InvalidateEvents(ctx)
+	invalidateEventCache(ctx, m.cache, eventID)
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
 	"github.com/bajiaozhi/w-mma/backend/internal/ufc"
 )
 
@@ -15,11 +16,19 @@ type fakeUFCLiveRepo struct {
 	boutsByEvent       map[int64][]UFCBoutSnapshot
 	eventStatusUpdates []string
 	boutUpdates        int
+	stateUpdates       int
 }
 
-func (r *fakeUFCLiveRepo) ListTrackableEvents(context.Context) ([]UFCTrackableEvent, error) {
-	items := make([]UFCTrackableEvent, len(r.events))
-	copy(items, r.events)
+func (r *fakeUFCLiveRepo) ListTrackableEvents(_ context.Context, orgs []string) ([]UFCTrackableEvent, error) {
+	items := make([]UFCTrackableEvent, 0, len(r.events))
+	for _, item := range r.events {
+		for _, org := range orgs {
+			if item.Org == org {
+				items = append(items, item)
+				break
+			}
+		}
+	}
 	return items, nil
 }
 
@@ -56,6 +65,18 @@ func (r *fakeUFCLiveRepo) UpsertBoutResult(_ context.Context, eventID int64, bou
 	return nil
 }
 
+func (r *fakeUFCLiveRepo) UpdateBoutLiveState(_ context.Context, eventID int64, boutID int64, state string, currentRound int) error {
+	r.stateUpdates++
+	for i := range r.boutsByEvent[eventID] {
+		item := &r.boutsByEvent[eventID][i]
+		if item.BoutID == boutID {
+			item.LiveState = state
+			item.CurrentRound = currentRound
+		}
+	}
+	return nil
+}
+
 type fakeUFCCache struct {
 	invalidatedEventIDs []int64
 	invalidatedEvents   int
@@ -85,17 +106,27 @@ func (s *fakeUFCScraper) GetEventCard(context.Context, string) (ufc.EventCard, e
 	return s.card, nil
 }
 
+type fakeResultsProvider struct {
+	results EventResults
+	calls   int
+}
+
+func (p *fakeResultsProvider) FetchEventResults(context.Context, string) (EventResults, error) {
+	p.calls++
+	return p.results, nil
+}
+
 func TestUFCLiveMonitor_TransitionsScheduledEventToLive(t *testing.T) {
 	now := time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC)
 	repo := &fakeUFCLiveRepo{
 		events: []UFCTrackableEvent{
-			{ID: 10, Status: "scheduled", StartsAt: now.Add(-time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
+			{ID: 10, Org: "UFC", Status: "scheduled", StartsAt: now.Add(-time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
 		},
 		boutsByEvent: map[int64][]UFCBoutSnapshot{},
 	}
 	cache := &fakeUFCCache{}
 	scraper := &fakeUFCScraper{}
-	monitor := NewUFCLiveMonitor(repo, scraper, cache, UFCLiveMonitorConfig{
+	monitor := NewUFCLiveMonitor(repo, NewDefaultProviderRegistry(scraper), cache, UFCLiveMonitorConfig{
 		TickInterval:     time.Minute,
 		MinPollInterval:  5 * time.Minute,
 		MaxPollInterval:  5 * time.Minute,
@@ -117,7 +148,7 @@ func TestUFCLiveMonitor_UpdatesBoutAndCompletesEvent(t *testing.T) {
 	now := time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC)
 	repo := &fakeUFCLiveRepo{
 		events: []UFCTrackableEvent{
-			{ID: 10, Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
+			{ID: 10, Org: "UFC", Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
 		},
 		boutsByEvent: map[int64][]UFCBoutSnapshot{
 			10: {
@@ -134,7 +165,7 @@ func TestUFCLiveMonitor_UpdatesBoutAndCompletesEvent(t *testing.T) {
 			},
 		},
 	}
-	monitor := NewUFCLiveMonitor(repo, scraper, cache, UFCLiveMonitorConfig{
+	monitor := NewUFCLiveMonitor(repo, NewDefaultProviderRegistry(scraper), cache, UFCLiveMonitorConfig{
 		TickInterval:     time.Minute,
 		MinPollInterval:  time.Second,
 		MaxPollInterval:  time.Second,
@@ -166,7 +197,7 @@ func TestUFCLiveMonitor_FailureUsesBackoff(t *testing.T) {
 	now := time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC)
 	repo := &fakeUFCLiveRepo{
 		events: []UFCTrackableEvent{
-			{ID: 10, Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
+			{ID: 10, Org: "UFC", Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
 		},
 		boutsByEvent: map[int64][]UFCBoutSnapshot{
 			10: {{BoutID: 1001, SequenceNo: 1, RedFighterID: 20, BlueFighterID: 21}},
@@ -174,7 +205,7 @@ func TestUFCLiveMonitor_FailureUsesBackoff(t *testing.T) {
 	}
 	cache := &fakeUFCCache{}
 	scraper := &fakeUFCScraper{err: errors.New("429 too many requests")}
-	monitor := NewUFCLiveMonitor(repo, scraper, cache, UFCLiveMonitorConfig{
+	monitor := NewUFCLiveMonitor(repo, NewDefaultProviderRegistry(scraper), cache, UFCLiveMonitorConfig{
 		TickInterval:     time.Minute,
 		MinPollInterval:  time.Second,
 		MaxPollInterval:  time.Second,
@@ -197,7 +228,7 @@ func TestUFCLiveMonitor_StaleLiveEventForceCompletesWithoutPolling(t *testing.T)
 	now := time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC)
 	repo := &fakeUFCLiveRepo{
 		events: []UFCTrackableEvent{
-			{ID: 10, Status: "live", StartsAt: now.Add(-48 * time.Hour), ExternalURL: "https://www.ufc.com/event/ufc-323"},
+			{ID: 10, Org: "UFC", Status: "live", StartsAt: now.Add(-48 * time.Hour), ExternalURL: "https://www.ufc.com/event/ufc-323"},
 		},
 		boutsByEvent: map[int64][]UFCBoutSnapshot{
 			10: {{BoutID: 1001, SequenceNo: 1, RedFighterID: 20, BlueFighterID: 21}},
@@ -205,7 +236,7 @@ func TestUFCLiveMonitor_StaleLiveEventForceCompletesWithoutPolling(t *testing.T)
 	}
 	cache := &fakeUFCCache{}
 	scraper := &fakeUFCScraper{err: errors.New("should not be called")}
-	monitor := NewUFCLiveMonitor(repo, scraper, cache, UFCLiveMonitorConfig{
+	monitor := NewUFCLiveMonitor(repo, NewDefaultProviderRegistry(scraper), cache, UFCLiveMonitorConfig{
 		TickInterval:     time.Minute,
 		MinPollInterval:  time.Second,
 		MaxPollInterval:  time.Second,
@@ -225,3 +256,251 @@ func TestUFCLiveMonitor_StaleLiveEventForceCompletesWithoutPolling(t *testing.T)
 		t.Fatalf("expected stale live event to skip polling, got calls=%d", scraper.calls)
 	}
 }
+
+func TestUFCLiveMonitor_DispatchesByEventOrg(t *testing.T) {
+	now := time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC)
+	repo := &fakeUFCLiveRepo{
+		events: []UFCTrackableEvent{
+			{ID: 10, Org: "UFC", Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
+			{ID: 11, Org: "ONE", Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://www.onefc.com/events/one-170"},
+			{ID: 12, Org: "PFL", Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://pflmma.com/event/pfl-1"},
+		},
+		boutsByEvent: map[int64][]UFCBoutSnapshot{
+			11: {{BoutID: 2001, SequenceNo: 1, RedFighterID: 30, BlueFighterID: 31}},
+		},
+	}
+	ufcScraper := &fakeUFCScraper{}
+	oneProvider := &fakeResultsProvider{
+		results: EventResults{
+			Status: "live",
+			Bouts:  []BoutResult{{WinnerSide: "blue", Method: "SUB", Round: 1, TimeSec: 95}},
+		},
+	}
+	registry := NewProviderRegistry(map[string]ResultsProvider{
+		"ufc": NewUFCResultsProvider(ufcScraper),
+		"One": oneProvider,
+	})
+	monitor := NewUFCLiveMonitor(repo, registry, &fakeUFCCache{}, UFCLiveMonitorConfig{
+		MinPollInterval: time.Second,
+		MaxPollInterval: time.Second,
+		MaxPollPerTick:  1,
+		Random:          rand.New(rand.NewSource(7)),
+		Now:             func() time.Time { return now },
+	})
+	monitor.nextCheckAt[11] = now
+
+	if err := monitor.RunOnce(context.Background()); err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	if oneProvider.calls != 1 {
+		t.Fatalf("expected ONE provider to be polled once, got %d", oneProvider.calls)
+	}
+	if ufcScraper.calls != 0 {
+		t.Fatalf("expected UFC event not due yet, got calls=%d", ufcScraper.calls)
+	}
+	if got := repo.boutsByEvent[11][0].WinnerID; got != 31 {
+		t.Fatalf("expected winner id 31, got %d", got)
+	}
+	if _, ok := monitor.nextCheckAt[12]; ok {
+		t.Fatalf("expected event without provider to be ignored")
+	}
+}
+
+func TestUFCLiveMonitor_TracksInProgressBoutState(t *testing.T) {
+	now := time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC)
+	repo := &fakeUFCLiveRepo{
+		events: []UFCTrackableEvent{
+			{ID: 10, Org: "UFC", Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
+		},
+		boutsByEvent: map[int64][]UFCBoutSnapshot{
+			10: {
+				{BoutID: 1001, SequenceNo: 1, RedFighterID: 20, BlueFighterID: 21, LiveState: event.BoutStateUpcoming},
+				{BoutID: 1002, SequenceNo: 2, RedFighterID: 22, BlueFighterID: 23},
+			},
+		},
+	}
+	cache := &fakeUFCCache{}
+	scraper := &fakeUFCScraper{
+		card: ufc.EventCard{
+			Status: "live",
+			Bouts: []ufc.EventBout{
+				{LiveState: event.BoutStateInProgress, CurrentRound: 2},
+				{WinnerSide: "blue", Method: "SUB", Round: 1, TimeSec: 80},
+			},
+		},
+	}
+	monitor := NewUFCLiveMonitor(repo, NewDefaultProviderRegistry(scraper), cache, UFCLiveMonitorConfig{
+		MinPollInterval: time.Second,
+		MaxPollInterval: time.Second,
+		Random:          rand.New(rand.NewSource(7)),
+		Now:             func() time.Time { return now },
+	})
+	monitor.nextCheckAt[10] = now
+
+	if err := monitor.RunOnce(context.Background()); err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	first := repo.boutsByEvent[10][0]
+	if first.LiveState != event.BoutStateInProgress || first.CurrentRound != 2 {
+		t.Fatalf("expected first bout in progress round 2, got %q round %d", first.LiveState, first.CurrentRound)
+	}
+	if got := repo.boutsByEvent[10][1].LiveState; got != event.BoutStateFinished {
+		t.Fatalf("expected resolved bout to be finished, got %q", got)
+	}
+	if len(cache.invalidatedEventIDs) == 0 {
+		t.Fatalf("expected event cache invalidation on state change")
+	}
+
+	monitor.nextCheckAt[10] = now
+	if err := monitor.RunOnce(context.Background()); err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	if repo.stateUpdates != 2 {
+		t.Fatalf("expected unchanged state to skip writes, got %d updates", repo.stateUpdates)
+	}
+}
+
+type fakeControlStore struct {
+	snapshot MonitorSnapshot
+	saved    int
+	pollNow  []int64
+	paused   map[int64]bool
+}
+
+func (s *fakeControlStore) SaveSnapshot(_ context.Context, snapshot MonitorSnapshot) error {
+	s.snapshot = snapshot
+	s.saved++
+	return nil
+}
+
+func (s *fakeControlStore) LoadSnapshot(context.Context) (MonitorSnapshot, bool, error) {
+	return s.snapshot, s.saved > 0, nil
+}
+
+func (s *fakeControlStore) RequestPoll(_ context.Context, eventID int64) error {
+	s.pollNow = append(s.pollNow, eventID)
+	return nil
+}
+
+func (s *fakeControlStore) TakePollRequests(context.Context) ([]int64, error) {
+	items := s.pollNow
+	s.pollNow = nil
+	return items, nil
+}
+
+func (s *fakeControlStore) SetPaused(_ context.Context, eventID int64, paused bool) error {
+	if s.paused == nil {
+		s.paused = map[int64]bool{}
+	}
+	if paused {
+		s.paused[eventID] = true
+	} else {
+		delete(s.paused, eventID)
+	}
+	return nil
+}
+
+func (s *fakeControlStore) PausedEvents(context.Context) (map[int64]bool, error) {
+	items := map[int64]bool{}
+	for id := range s.paused {
+		items[id] = true
+	}
+	return items, nil
+}
+
+func TestUFCLiveMonitor_SkipsManuallyLockedBouts(t *testing.T) {
+	now := time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC)
+	repo := &fakeUFCLiveRepo{
+		events: []UFCTrackableEvent{
+			{ID: 10, Org: "UFC", Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
+		},
+		boutsByEvent: map[int64][]UFCBoutSnapshot{
+			10: {
+				{BoutID: 1001, SequenceNo: 1, RedFighterID: 20, BlueFighterID: 21, WinnerID: 20, Method: "KO/TKO", Round: 1, LiveState: event.BoutStateFinished, ResultLocked: true},
+				{BoutID: 1002, SequenceNo: 2, RedFighterID: 22, BlueFighterID: 23},
+			},
+		},
+	}
+	scraper := &fakeUFCScraper{
+		card: ufc.EventCard{
+			Status: "live",
+			Bouts: []ufc.EventBout{
+				{LiveState: event.BoutStateInProgress, CurrentRound: 1},
+				{WinnerSide: "red", Method: "DEC", Round: 3, TimeSec: 300},
+			},
+		},
+	}
+	monitor := NewUFCLiveMonitor(repo, NewDefaultProviderRegistry(scraper), &fakeUFCCache{}, UFCLiveMonitorConfig{
+		MinPollInterval: time.Second,
+		MaxPollInterval: time.Second,
+		Random:          rand.New(rand.NewSource(7)),
+		Now:             func() time.Time { return now },
+	})
+	monitor.nextCheckAt[10] = now
+
+	if err := monitor.RunOnce(context.Background()); err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	locked := repo.boutsByEvent[10][0]
+	if locked.WinnerID != 20 || locked.LiveState != event.BoutStateFinished {
+		t.Fatalf("expected locked bout to keep manual result, got %+v", locked)
+	}
+	if repo.boutsByEvent[10][1].WinnerID != 22 {
+		t.Fatalf("expected unlocked bout to take provider result")
+	}
+}
+
+func TestUFCLiveMonitor_HonorsPauseAndPollNow(t *testing.T) {
+	now := time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC)
+	repo := &fakeUFCLiveRepo{
+		events: []UFCTrackableEvent{
+			{ID: 10, Org: "UFC", Status: "live", StartsAt: now.Add(-20 * time.Minute), ExternalURL: "https://www.ufc.com/event/ufc-326"},
+		},
+		boutsByEvent: map[int64][]UFCBoutSnapshot{
+			10: {{BoutID: 1001, SequenceNo: 1, RedFighterID: 20, BlueFighterID: 21}},
+		},
+	}
+	scraper := &fakeUFCScraper{
+		card: ufc.EventCard{
+			Status: "live",
+			Bouts:  []ufc.EventBout{{WinnerSide: "red", Method: "KO/TKO", Round: 1, TimeSec: 30}},
+		},
+	}
+	control := &fakeControlStore{paused: map[int64]bool{10: true}}
+	monitor := NewUFCLiveMonitor(repo, NewDefaultProviderRegistry(scraper), &fakeUFCCache{}, UFCLiveMonitorConfig{
+		MinPollInterval: time.Minute,
+		MaxPollInterval: time.Minute,
+		Random:          rand.New(rand.NewSource(7)),
+		Now:             func() time.Time { return now },
+		Control:         control,
+	})
+	monitor.nextCheckAt[10] = now
+
+	if err := monitor.RunOnce(context.Background()); err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	if scraper.calls != 0 {
+		t.Fatalf("expected paused event to skip polling, got calls=%d", scraper.calls)
+	}
+	if len(control.snapshot.Events) != 1 || !control.snapshot.Events[0].Paused {
+		t.Fatalf("expected paused event in snapshot, got %+v", control.snapshot.Events)
+	}
+
+	_ = control.RequestPoll(context.Background(), 10)
+	if err := monitor.RunOnce(context.Background()); err != nil {
+		t.Fatalf("run once: %v", err)
+	}
+	if scraper.calls != 1 {
+		t.Fatalf("expected poll-now to override pause, got calls=%d", scraper.calls)
+	}
+	status := control.snapshot.Events[0]
+	if status.LastOutcome != PollOutcomeChanged {
+		t.Fatalf("expected changed outcome, got %q", status.LastOutcome)
+	}
+	if len(status.LastChangedBoutIDs) != 1 || status.LastChangedBoutIDs[0] != 1001 {
+		t.Fatalf("expected changed bout 1001, got %v", status.LastChangedBoutIDs)
+	}
+	if status.LastPollAt == nil || status.NextCheckAt == nil {
+		t.Fatalf("expected poll timestamps in snapshot, got %+v", status)
+	}
+}
@@ -0,0 +1,43 @@
+package live
+
+import (
+	"context"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/ufc"
+)
+
+type UFCEventScraper interface {
+	GetEventCard(ctx context.Context, eventURL string) (ufc.EventCard, error)
+}
+
+// UFCResultsProvider reads live results from ufc.com event pages.
+type UFCResultsProvider struct {
+	scraper UFCEventScraper
+}
+
+func NewUFCResultsProvider(scraper UFCEventScraper) *UFCResultsProvider {
+	return &UFCResultsProvider{scraper: scraper}
+}
+
+func (p *UFCResultsProvider) FetchEventResults(ctx context.Context, eventURL string) (EventResults, error) {
+	card, err := p.scraper.GetEventCard(ctx, eventURL)
+	if err != nil {
+		return EventResults{}, err
+	}
+	results := EventResults{
+		Status: card.Status,
+		Bouts:  make([]BoutResult, 0, len(card.Bouts)),
+	}
+	for _, bout := range card.Bouts {
+		results.Bouts = append(results.Bouts, BoutResult{
+			WinnerSide:   bout.WinnerSide,
+			Method:       bout.Method,
+			Round:        bout.Round,
+			TimeSec:      bout.TimeSec,
+			Result:       bout.Result,
+			State:        bout.LiveState,
+			CurrentRound: bout.CurrentRound,
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type AdminUser struct {
+	ID                int64      `gorm:"primaryKey;autoIncrement"`
+	Username          string     `gorm:"size:64;not null;uniqueIndex:uk_admin_users_username"`
+	PasswordHash      string     `gorm:"column:password_hash;size:255;not null"`
+	PasswordChangedAt *time.Time `gorm:"column:password_changed_at"`
+	Status            string     `gorm:"type:enum('active','disabled');not null;default:active"`
+	LastLoginAt       *time.Time `gorm:"column:last_login_at"`
+	CreatedAt         time.Time  `gorm:"not null"`
+	UpdatedAt         time.Time  `gorm:"not null"`
+}
+
+func (AdminUser) TableName() string {
+	return "admin_users"
+}
@@ -3,33 +3,146 @@ package model
 import "time"
 
 type Article struct {
-	ID            int64     `gorm:"primaryKey;autoIncrement"`
-	SourceID      *int64    `gorm:"column:source_id"`
-	Title         string    `gorm:"size:255;not null"`
-	Content       string    `gorm:"type:text;not null"`
-	SourceURL     string    `gorm:"size:512;not null;uniqueIndex"`
-	CoverURL      *string   `gorm:"size:512"`
-	VideoURL      *string   `gorm:"size:512"`
-	PublishedMode string    `gorm:"size:16;not null"`
-	Status        string    `gorm:"size:16;not null"`
-	PublishedAt   time.Time `gorm:"not null"`
-	CreatedAt     time.Time `gorm:"not null"`
-	UpdatedAt     time.Time `gorm:"not null"`
+	ID        int64   `gorm:"primaryKey;autoIncrement"`
+	SourceID  *int64  `gorm:"column:source_id"`
+	GUID      *string `gorm:"column:guid;size:512"`
+	Title     string  `gorm:"size:255;not null"`
+	Summary   *string `gorm:"type:text"`
+	Content   string  `gorm:"type:mediumtext;not null"`
+	Author    *string `gorm:"size:128"`
+	SourceURL string  `gorm:"size:512;not null;uniqueIndex"`
+	CoverURL  *string `gorm:"size:512"`
+	VideoURL  *string `gorm:"size:512"`
+	// OriginalTitle and OriginalContent keep the source text of translated articles.
+	OriginalTitle   *string   `gorm:"column:original_title;size:255"`
+	OriginalContent *string   `gorm:"column:original_content;type:mediumtext"`
+	PublishedMode   string    `gorm:"size:16;not null"`
+	Status          string    `gorm:"size:16;not null"`
+	PublishedAt     time.Time `gorm:"not null"`
+	// PublishAt holds a scheduled article back until then; EmbargoUntil records the source's embargo.
+	PublishAt    *time.Time `gorm:"column:publish_at"`
+	EmbargoUntil *time.Time `gorm:"column:embargo_until"`
+	CreatedAt    time.Time  `gorm:"not null"`
+	UpdatedAt    time.Time  `gorm:"not null"`
 }
 
 type PendingArticle struct {
-	ID         int64  `gorm:"primaryKey;autoIncrement"`
-	SourceID   *int64 `gorm:"column:source_id"`
-	Title      string `gorm:"size:255;not null"`
-	Summary    string `gorm:"type:text;not null"`
-	SourceURL  string `gorm:"size:512;not null;uniqueIndex"`
-	Status     string `gorm:"size:16;not null;index:idx_pending_status_created,priority:1"`
-	ReviewerID *int64 `gorm:"column:reviewer_id"`
-	ReviewedAt *time.Time
-	CreatedAt  time.Time `gorm:"not null;index:idx_pending_status_created,priority:2"`
-	UpdatedAt  time.Time `gorm:"not null"`
+	ID           int64   `gorm:"primaryKey;autoIncrement"`
+	SourceID     *int64  `gorm:"column:source_id"`
+	GUID         *string `gorm:"column:guid;size:512"`
+	Title        string  `gorm:"size:255;not null"`
+	Summary      string  `gorm:"type:text;not null"`
+	Content      *string `gorm:"type:mediumtext"`
+	Author       *string `gorm:"size:128"`
+	SourceURL    string  `gorm:"size:512;not null;uniqueIndex"`
+	CoverURL     *string `gorm:"size:512"`
+	VideoURL     *string `gorm:"size:512"`
+	PublishedAt  *time.Time
+	SimHash      *uint64 `gorm:"column:simhash"`
+	DuplicateOf  *int64  `gorm:"column:duplicate_of"`
+	Status       string  `gorm:"size:16;not null;index:idx_pending_status_created,priority:1"`
+	RejectReason *string `gorm:"column:reject_reason;size:512"`
+	ReviewerID   *int64  `gorm:"column:reviewer_id"`
+	ReviewedAt   *time.Time
+	Version      int        `gorm:"not null;default:1"`
+	ClaimedBy    *int64     `gorm:"column:claimed_by"`
+	ClaimedUntil *time.Time `gorm:"column:claimed_until"`
+	// EditedFields is a comma list of the fields a reviewer changed before approval.
+	EditedFields *string   `gorm:"column:edited_fields;size:64"`
+	CreatedAt    time.Time `gorm:"not null;index:idx_pending_status_created,priority:2"`
+	UpdatedAt    time.Time `gorm:"not null"`
 }
 
 func (PendingArticle) TableName() string {
 	return "pending_articles"
 }
+
+type ReviewNote struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement"`
+	PendingArticleID int64     `gorm:"column:pending_article_id;not null;index:idx_review_notes_pending,priority:1"`
+	ReviewerID       *int64    `gorm:"column:reviewer_id"`
+	Body             string    `gorm:"type:text;not null"`
+	CreatedAt        time.Time `gorm:"not null"`
+}
+
+func (ReviewNote) TableName() string {
+	return "review_notes"
+}
+
+// ArticleRevision is a snapshot of a published article taken after each change.
+type ArticleRevision struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	ArticleID int64     `gorm:"column:article_id;not null;index:idx_article_revisions_article,priority:1"`
+	EditorID  *int64    `gorm:"column:editor_id"`
+	Action    string    `gorm:"size:16;not null"`
+	Title     string    `gorm:"size:255;not null"`
+	Summary   *string   `gorm:"type:text"`
+	Content   string    `gorm:"type:mediumtext;not null"`
+	CoverURL  *string   `gorm:"size:512"`
+	VideoURL  *string   `gorm:"size:512"`
+	Status    string    `gorm:"size:16;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (ArticleRevision) TableName() string {
+	return "article_revisions"
+}
+
+// ArticleEntity links a pending or published article to a fighter or event. Links are
+// created against the pending item and gain an article_id once it is published.
+type ArticleEntity struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement"`
+	PendingArticleID *int64    `gorm:"column:pending_article_id;uniqueIndex:uk_article_entities_pending,priority:1"`
+	ArticleID        *int64    `gorm:"column:article_id;index:idx_article_entities_article"`
+	EntityType       string    `gorm:"column:entity_type;type:enum('fighter','event');not null;uniqueIndex:uk_article_entities_pending,priority:2;index:idx_article_entities_entity,priority:1"`
+	EntityID         int64     `gorm:"column:entity_id;not null;uniqueIndex:uk_article_entities_pending,priority:3;index:idx_article_entities_entity,priority:2"`
+	EntityName       string    `gorm:"column:entity_name;size:255;not null"`
+	MatchedText      *string   `gorm:"column:matched_text;size:255"`
+	Status           string    `gorm:"type:enum('suggested','confirmed','rejected');not null;index:idx_article_entities_entity,priority:3"`
+	CreatedAt        time.Time `gorm:"not null"`
+	UpdatedAt        time.Time `gorm:"not null"`
+}
+
+func (ArticleEntity) TableName() string {
+	return "article_entities"
+}
+
+// Tag is a category, promotion or free tag articles can be filed under. Keywords is a
+// comma-separated list used to suggest the tag at ingest.
+type Tag struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	Slug      string    `gorm:"size:64;not null;uniqueIndex:uk_tags_slug"`
+	Name      string    `gorm:"size:64;not null"`
+	Kind      string    `gorm:"type:enum('category','org','tag');not null"`
+	Keywords  string    `gorm:"size:1024;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TagSourceRule suggests a tag for every article ingested from a source.
+type TagSourceRule struct {
+	TagID    int64 `gorm:"column:tag_id;primaryKey"`
+	SourceID int64 `gorm:"column:source_id;primaryKey;index:idx_tag_source_rules_source"`
+}
+
+func (TagSourceRule) TableName() string {
+	return "tag_source_rules"
+}
+
+// ArticleTag files a pending or published article under a tag. Like entity links, rows are
+// created against the pending item and gain an article_id once it is published.
+type ArticleTag struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement"`
+	TagID            int64     `gorm:"column:tag_id;not null;uniqueIndex:uk_article_tags_pending,priority:2;index:idx_article_tags_tag,priority:1"`
+	PendingArticleID *int64    `gorm:"column:pending_article_id;uniqueIndex:uk_article_tags_pending,priority:1"`
+	ArticleID        *int64    `gorm:"column:article_id;index:idx_article_tags_tag,priority:2;index:idx_article_tags_article"`
+	CreatedAt        time.Time `gorm:"not null"`
+}
+
+func (ArticleTag) TableName() string {
+	return "article_tags"
+}
@@ -32,6 +32,34 @@ type Bout struct {
 	Method          *string
 	Round           *int
 	TimeSec         *int
+	LiveState       *string `gorm:"size:16"`
+	CurrentRound    *int
+	ResultLocked    bool `gorm:"column:result_locked;not null"`
+	ResultLockedBy  *int64
+	ResultLockedAt  *time.Time
 	CreatedAt       time.Time `gorm:"not null"`
 	UpdatedAt       time.Time `gorm:"not null"`
 }
+
+// BoutResultHistory keeps every result change of a bout. Schedule resyncs update bouts in
+// place, so rows follow the bout id; the sequence number records the card position at the time.
+type BoutResultHistory struct {
+	ID                      int64 `gorm:"primaryKey;autoIncrement"`
+	EventID                 int64 `gorm:"not null;index:idx_bout_result_history_event_sequence,priority:1"`
+	BoutID                  int64 `gorm:"not null;index:idx_bout_result_history_bout"`
+	SequenceNo              int   `gorm:"not null;index:idx_bout_result_history_event_sequence,priority:2"`
+	WinnerFighterID         *int64
+	Method                  *string `gorm:"size:128"`
+	Round                   *int
+	TimeSec                 *int
+	Result                  *string `gorm:"size:255"`
+	PreviousWinnerFighterID *int64
+	PreviousResult          *string   `gorm:"size:255"`
+	Source                  string    `gorm:"type:enum('scraper','manual','correction');not null"`
+	Note                    *string   `gorm:"size:255"`
+	CreatedAt               time.Time `gorm:"not null"`
+}
+
+func (BoutResultHistory) TableName() string {
+	return "bout_result_history"
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// FeaturedSlot promotes an article or event on the miniapp home screen for a time window.
+type FeaturedSlot struct {
+	ID         int64      `gorm:"primaryKey;autoIncrement"`
+	Kind       string     `gorm:"type:enum('carousel','pinned','event');not null;index:idx_featured_slots_kind_position,priority:1"`
+	TargetType string     `gorm:"type:enum('article','event');not null"`
+	TargetID   int64      `gorm:"not null"`
+	Title      *string    `gorm:"size:255"`
+	ImageURL   *string    `gorm:"column:image_url;size:512"`
+	Position   int        `gorm:"not null;index:idx_featured_slots_kind_position,priority:2"`
+	StartsAt   *time.Time `gorm:"column:starts_at"`
+	EndsAt     *time.Time `gorm:"column:ends_at"`
+	CreatedAt  time.Time  `gorm:"not null"`
+	UpdatedAt  time.Time  `gorm:"not null"`
+}
+
+func (FeaturedSlot) TableName() string {
+	return "featured_slots"
+}
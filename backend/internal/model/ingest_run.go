@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type IngestRun struct {
+	ID           int64      `gorm:"primaryKey;autoIncrement"`
+	SourceID     int64      `gorm:"column:source_id;not null;index:idx_ingest_runs_source_status,priority:1"`
+	SourceURL    string     `gorm:"column:source_url;size:512;not null"`
+	ParserKind   string     `gorm:"column:parser_kind;size:64;not null"`
+	Status       string     `gorm:"size:16;not null;index:idx_ingest_runs_source_status,priority:2"`
+	FetchedCount int        `gorm:"column:fetched_count;not null"`
+	ErrorMsg     *string    `gorm:"column:error_msg;type:text"`
+	StartedAt    *time.Time `gorm:"column:started_at"`
+	FinishedAt   *time.Time `gorm:"column:finished_at"`
+	CreatedAt    time.Time  `gorm:"not null"`
+	UpdatedAt    time.Time  `gorm:"not null"`
+}
+
+func (IngestRun) TableName() string {
+	return "ingest_runs"
+}
+
+type IngestRunError struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	RunID     int64     `gorm:"column:run_id;not null;index"`
+	ErrorMsg  string    `gorm:"column:error_msg;type:text;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (IngestRunError) TableName() string {
+	return "ingest_run_errors"
+}
+
+// CrawlLink is an article link a listing page queued for fetching.
+type CrawlLink struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement"`
+	SourceID  int64     `gorm:"column:source_id;not null;uniqueIndex:uk_crawl_links_source_url,priority:1"`
+	URL       string    `gorm:"column:url;size:512;not null;uniqueIndex:uk_crawl_links_source_url,priority:2"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (CrawlLink) TableName() string {
+	return "crawl_links"
+}
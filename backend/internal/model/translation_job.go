@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type TranslationJob struct {
+	ID               int64     `gorm:"primaryKey;autoIncrement"`
+	PendingArticleID int64     `gorm:"column:pending_article_id;not null;index:idx_translation_jobs_pending,priority:1"`
+	SourceID         int64     `gorm:"column:source_id;not null"`
+	Status           string    `gorm:"type:enum('pending','running','done','failed','manual_required');not null;index:idx_translation_jobs_status,priority:1"`
+	Provider         *string   `gorm:"size:64"`
+	TargetLang       string    `gorm:"column:target_lang;size:16;not null"`
+	OriginalTitle    string    `gorm:"column:original_title;size:255;not null"`
+	OriginalBody     *string   `gorm:"column:original_body;type:mediumtext"`
+	Title            *string   `gorm:"size:255"`
+	Body             *string   `gorm:"type:mediumtext"`
+	ErrorMsg         *string   `gorm:"column:error_msg;type:text"`
+	CreatedAt        time.Time `gorm:"not null"`
+	UpdatedAt        time.Time `gorm:"not null"`
+}
+
+func (TranslationJob) TableName() string {
+	return "translation_jobs"
+}
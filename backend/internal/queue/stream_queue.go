@@ -4,26 +4,137 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	DefaultMaxDeliveries = 5
+	DefaultRetryBackoff  = 30 * time.Second
+	DefaultMaxBackoff    = 10 * time.Minute
+
+	deadLetterSuffix = ":dead"
+	reclaimBatch     = 32
+
+	deadOriginalIDField = "dead_original_id"
+	deadErrorField      = "dead_error"
+	deadDeliveriesField = "dead_deliveries"
+	deadFailedAtField   = "dead_failed_at"
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
 type StreamMessage struct {
 	ID     string
 	Values map[string]any
 }
 
+// DeadLetter is a message that exhausted its deliveries, with the last failure recorded.
+type DeadLetter struct {
+	ID         string
+	OriginalID string
+	Values     map[string]any
+	Error      string
+	Deliveries int64
+	FailedAt   time.Time
+}
+
 type StreamQueue struct {
-	client   redis.Cmdable
-	stream   string
-	group    string
-	consumer string
+	client        redis.Cmdable
+	stream        string
+	group         string
+	consumer      string
+	deadStream    string
+	maxDeliveries int64
+	retryBackoff  time.Duration
+	maxBackoff    time.Duration
+	batchSize     int64
+	maxLen        int64
+	deadMaxLen    int64
+	now           func() time.Time
+}
+
+type StreamOption func(*StreamQueue)
+
+// WithMaxDeliveries sets how many times a message is handed out before it is dead-lettered.
+func WithMaxDeliveries(n int) StreamOption {
+	return func(q *StreamQueue) {
+		if n > 0 {
+			q.maxDeliveries = int64(n)
+		}
+	}
+}
+
+// WithRetryBackoff sets the idle time before the first retry and the cap it doubles up to.
+// The base also guards in-flight work: a pending message younger than it is never reclaimed.
+func WithRetryBackoff(base time.Duration, max time.Duration) StreamOption {
+	return func(q *StreamQueue) {
+		if base > 0 {
+			q.retryBackoff = base
+		}
+		if max >= q.retryBackoff {
+			q.maxBackoff = max
+		}
+	}
 }
 
-func NewStreamQueue(client redis.Cmdable, stream string, group string, consumer string) *StreamQueue {
-	return &StreamQueue{client: client, stream: stream, group: group, consumer: consumer}
+// WithBatchSize sets how many messages one Consume call reads and handles.
+func WithBatchSize(n int) StreamOption {
+	return func(q *StreamQueue) {
+		if n > 0 {
+			q.batchSize = int64(n)
+		}
+	}
+}
+
+// WithMaxLen caps the stream at roughly n entries; Publish trims with XADD MAXLEN ~. The
+// dead-letter stream is not affected.
+func WithMaxLen(n int64) StreamOption {
+	return func(q *StreamQueue) {
+		if n > 0 {
+			q.maxLen = n
+		}
+	}
+}
+
+// WithDeadLetterMaxLen caps the dead-letter stream at roughly n entries. It is uncapped by
+// default so a burst of failures does not evict dead letters before they are replayed.
+func WithDeadLetterMaxLen(n int64) StreamOption {
+	return func(q *StreamQueue) {
+		if n > 0 {
+			q.deadMaxLen = n
+		}
+	}
+}
+
+func WithDeadLetterStream(stream string) StreamOption {
+	return func(q *StreamQueue) {
+		if strings.TrimSpace(stream) != "" {
+			q.deadStream = stream
+		}
+	}
+}
+
+func NewStreamQueue(client redis.Cmdable, stream string, group string, consumer string, opts ...StreamOption) *StreamQueue {
+	q := &StreamQueue{
+		client:        client,
+		stream:        stream,
+		group:         group,
+		consumer:      consumer,
+		deadStream:    stream + deadLetterSuffix,
+		maxDeliveries: DefaultMaxDeliveries,
+		retryBackoff:  DefaultRetryBackoff,
+		maxBackoff:    DefaultMaxBackoff,
+		batchSize:     1,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
 }
 
 func (q *StreamQueue) EnsureGroup(ctx context.Context) error {
@@ -41,33 +152,377 @@ func (q *StreamQueue) Publish(ctx context.Context, values map[string]any) error
 	if len(values) == 0 {
 		return errors.New("values cannot be empty")
 	}
-	return q.client.XAdd(ctx, &redis.XAddArgs{Stream: q.stream, Values: values}).Err()
+	return q.client.XAdd(ctx, addArgs(q.stream, values, q.maxLen)).Err()
+}
+
+func addArgs(stream string, values map[string]any, maxLen int64) *redis.XAddArgs {
+	args := &redis.XAddArgs{Stream: stream, Values: values}
+	if maxLen > 0 {
+		args.MaxLen = maxLen
+		args.Approx = true
+	}
+	return args
 }
 
+// ForConsumer returns a queue on the same stream and group that reads as another consumer,
+// so a pool of goroutines each gets its own pending entries list.
+func (q *StreamQueue) ForConsumer(consumer string) *StreamQueue {
+	clone := *q
+	clone.consumer = consumer
+	return &clone
+}
+
+// Consume handles up to one batch of messages. Pending messages that have waited out their
+// retry backoff, including those stranded by a crashed consumer, are reclaimed before new
+// ones are read. A failed message stays pending for a later retry until it reaches the
+// delivery limit, then it moves to the dead-letter stream.
+//
+// Cancelling ctx stops the read, but a batch already read is handled and acked in full so
+// shutdown does not leave half-processed messages waiting for a retry.
 func (q *StreamQueue) Consume(ctx context.Context, handler func(StreamMessage) error) error {
+	messages, err := q.reclaim(ctx)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		messages, err = q.readNew(ctx)
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+	}
+
+	drainCtx := context.WithoutCancel(ctx)
+	var errs []error
+	for len(messages) > 0 {
+		msg := messages[0]
+		messages = messages[1:]
+		if err := handler(msg); err != nil {
+			errs = append(errs, q.fail(drainCtx, msg, err))
+		} else if err := q.client.XAck(drainCtx, q.stream, q.group, msg.ID).Err(); err != nil {
+			errs = append(errs, err)
+		}
+		if len(messages) == 0 {
+			break
+		}
+		kept, err := q.keep(drainCtx, messages)
+		if err != nil {
+			// Leave the rest pending; they are retried once their backoff passes.
+			errs = append(errs, err)
+			break
+		}
+		messages = kept
+	}
+	return errors.Join(errs...)
+}
+
+// keep claims the rest of a batch again so its idle time restarts. Messages wait in this
+// consumer's pending list while earlier ones are handled, and without this a slow batch
+// would let other consumers reclaim the tail and handle it twice. Messages another consumer
+// has already taken over are dropped from the batch.
+func (q *StreamQueue) keep(ctx context.Context, messages []StreamMessage) ([]StreamMessage, error) {
+	pending, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   q.stream,
+		Group:    q.group,
+		Start:    messages[0].ID,
+		End:      messages[len(messages)-1].ID,
+		Count:    int64(len(messages)),
+		Consumer: q.consumer,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	owned := make(map[string]struct{}, len(pending))
+	ids := make([]string, 0, len(pending))
+	for _, entry := range pending {
+		owned[entry.ID] = struct{}{}
+		ids = append(ids, entry.ID)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	// JUSTID leaves the delivery count alone.
+	if err := q.client.XClaimJustID(ctx, &redis.XClaimArgs{
+		Stream:   q.stream,
+		Group:    q.group,
+		Consumer: q.consumer,
+		Messages: ids,
+	}).Err(); err != nil {
+		return nil, err
+	}
+	kept := messages[:0]
+	for _, msg := range messages {
+		if _, ok := owned[msg.ID]; ok {
+			kept = append(kept, msg)
+		}
+	}
+	return kept, nil
+}
+
+func (q *StreamQueue) readNew(ctx context.Context) ([]StreamMessage, error) {
 	streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
 		Group:    q.group,
 		Consumer: q.consumer,
 		Streams:  []string{q.stream, ">"},
-		Count:    1,
+		Count:    q.batchSize,
 		Block:    1 * time.Second,
 	}).Result()
 	if err == redis.Nil {
-		return nil
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(streams) == 0 {
+		return nil, nil
+	}
+	messages := make([]StreamMessage, 0, len(streams[0].Messages))
+	for _, msg := range streams[0].Messages {
+		messages = append(messages, StreamMessage{ID: msg.ID, Values: msg.Values})
+	}
+	return messages, nil
+}
+
+// reclaim claims up to one batch of the oldest pending messages whose backoff has elapsed.
+// It pages through the pending list, so older entries still inside a longer backoff do not
+// hide newer ones that are due. Entries already at the delivery limit (their consumer died
+// on the last attempt) are dead-lettered on the way.
+func (q *StreamQueue) reclaim(ctx context.Context) ([]StreamMessage, error) {
+	messages := make([]StreamMessage, 0)
+	start := "-"
+	for int64(len(messages)) < q.batchSize {
+		pending, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: q.stream,
+			Group:  q.group,
+			Idle:   q.retryBackoff,
+			Start:  start,
+			End:    "+",
+			Count:  reclaimBatch,
+		}).Result()
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+
+		for _, entry := range pending {
+			if int64(len(messages)) >= q.batchSize {
+				break
+			}
+			if entry.RetryCount >= q.maxDeliveries {
+				if err := q.deadLetterPending(ctx, entry.ID, entry.RetryCount); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			delay := q.backoff(entry.RetryCount)
+			if entry.Idle < delay {
+				continue
+			}
+			claimed, err := q.client.XClaim(ctx, &redis.XClaimArgs{
+				Stream:   q.stream,
+				Group:    q.group,
+				Consumer: q.consumer,
+				MinIdle:  delay,
+				Messages: []string{entry.ID},
+			}).Result()
+			if err != nil {
+				return nil, err
+			}
+			// An empty result means another consumer claimed it first, or it was trimmed.
+			for _, msg := range claimed {
+				messages = append(messages, StreamMessage{ID: msg.ID, Values: msg.Values})
+			}
+		}
+		if len(pending) < reclaimBatch {
+			break
+		}
+		next, ok := nextStreamID(pending[len(pending)-1].ID)
+		if !ok {
+			break
+		}
+		start = next
+	}
+	return messages, nil
+}
+
+// nextStreamID returns the smallest stream id after id, for paging inclusive ranges.
+func nextStreamID(id string) (string, bool) {
+	ms, seq, found := strings.Cut(id, "-")
+	if !found {
+		return "", false
 	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return "", false
+	}
+	return ms + "-" + strconv.FormatUint(n+1, 10), true
+}
+
+// backoff is the idle time a message must reach before its next delivery: the base,
+// doubled for every delivery after the first, capped at maxBackoff.
+func (q *StreamQueue) backoff(deliveries int64) time.Duration {
+	delay := q.retryBackoff
+	for i := int64(1); i < deliveries; i++ {
+		delay *= 2
+		if delay >= q.maxBackoff {
+			return q.maxBackoff
+		}
+	}
+	return delay
+}
+
+func (q *StreamQueue) fail(ctx context.Context, msg StreamMessage, handlerErr error) error {
+	pending, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: q.stream,
+		Group:  q.group,
+		Start:  msg.ID,
+		End:    msg.ID,
+		Count:  1,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return errors.Join(handlerErr, err)
+	}
+	if len(pending) == 0 || pending[0].RetryCount < q.maxDeliveries {
+		return handlerErr
+	}
+	if err := q.deadLetter(ctx, msg, handlerErr.Error(), pending[0].RetryCount); err != nil {
+		return errors.Join(handlerErr, err)
+	}
+	return fmt.Errorf("dead-lettered after %d deliveries: %w", pending[0].RetryCount, handlerErr)
+}
+
+func (q *StreamQueue) deadLetterPending(ctx context.Context, id string, deliveries int64) error {
+	messages, err := q.client.XRangeN(ctx, q.stream, id, id, 1).Result()
 	if err != nil {
 		return err
 	}
-	if len(streams) == 0 || len(streams[0].Messages) == 0 {
-		return nil
+	if len(messages) == 0 {
+		return q.client.XAck(ctx, q.stream, q.group, id).Err()
 	}
+	msg := StreamMessage{ID: messages[0].ID, Values: messages[0].Values}
+	return q.deadLetter(ctx, msg, fmt.Sprintf("consumer gave up after %d deliveries", deliveries), deliveries)
+}
 
-	msg := streams[0].Messages[0]
-	wrapped := StreamMessage{ID: msg.ID, Values: msg.Values}
-	if err := handler(wrapped); err != nil {
+func (q *StreamQueue) deadLetter(ctx context.Context, msg StreamMessage, reason string, deliveries int64) error {
+	values := make(map[string]any, len(msg.Values)+4)
+	for key, value := range msg.Values {
+		values[key] = value
+	}
+	values[deadOriginalIDField] = msg.ID
+	values[deadErrorField] = reason
+	values[deadDeliveriesField] = strconv.FormatInt(deliveries, 10)
+	values[deadFailedAtField] = strconv.FormatInt(q.now().Unix(), 10)
+	if err := q.client.XAdd(ctx, addArgs(q.deadStream, values, q.deadMaxLen)).Err(); err != nil {
+		return err
+	}
+	if err := q.client.XAck(ctx, q.stream, q.group, msg.ID).Err(); err != nil {
 		return err
 	}
-	return q.client.XAck(ctx, q.stream, q.group, msg.ID).Err()
+	return q.client.XDel(ctx, q.stream, msg.ID).Err()
+}
+
+// DeleteConsumer removes this queue's consumer from the group if it holds no pending entries,
+// and reports whether it did. Entries still pending keep the consumer until they are acked
+// or reclaimed.
+func (q *StreamQueue) DeleteConsumer(ctx context.Context) (bool, error) {
+	pending, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   q.stream,
+		Group:    q.group,
+		Start:    "-",
+		End:      "+",
+		Count:    1,
+		Consumer: q.consumer,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return false, err
+	}
+	if len(pending) > 0 {
+		return false, nil
+	}
+	return true, q.client.XGroupDelConsumer(ctx, q.stream, q.group, q.consumer).Err()
+}
+
+// DeleteIdleConsumers removes the group's consumers that hold no pending entries and have
+// been idle for at least minIdle, and returns how many it removed. Workers that crashed or
+// were replaced leave their consumer names behind otherwise; once the reclaim pass has moved
+// their entries away, nothing else cleans them up.
+func (q *StreamQueue) DeleteIdleConsumers(ctx context.Context, minIdle time.Duration) (int, error) {
+	consumers, err := q.client.XInfoConsumers(ctx, q.stream, q.group).Result()
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, consumer := range consumers {
+		if consumer.Pending > 0 || consumer.Idle < minIdle {
+			continue
+		}
+		if err := q.client.XGroupDelConsumer(ctx, q.stream, q.group, consumer.Name).Err(); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// ListDeadLetters returns up to limit dead-lettered messages, newest first.
+func (q *StreamQueue) ListDeadLetters(ctx context.Context, limit int64) ([]DeadLetter, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	messages, err := q.client.XRevRangeN(ctx, q.deadStream, "+", "-", limit).Result()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]DeadLetter, 0, len(messages))
+	for _, msg := range messages {
+		items = append(items, toDeadLetter(msg))
+	}
+	return items, nil
+}
+
+// ReplayDeadLetter publishes the original message again as a fresh job and drops the dead letter.
+func (q *StreamQueue) ReplayDeadLetter(ctx context.Context, id string) error {
+	messages, err := q.client.XRangeN(ctx, q.deadStream, id, id, 1).Result()
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return ErrDeadLetterNotFound
+	}
+	if err := q.Publish(ctx, toDeadLetter(messages[0]).Values); err != nil {
+		return err
+	}
+	return q.client.XDel(ctx, q.deadStream, id).Err()
+}
+
+func (q *StreamQueue) DropDeadLetter(ctx context.Context, id string) error {
+	deleted, err := q.client.XDel(ctx, q.deadStream, id).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrDeadLetterNotFound
+	}
+	return nil
+}
+
+func toDeadLetter(msg redis.XMessage) DeadLetter {
+	item := DeadLetter{ID: msg.ID, Values: map[string]any{}}
+	for key, value := range msg.Values {
+		switch key {
+		case deadOriginalIDField:
+			item.OriginalID = fmt.Sprint(value)
+		case deadErrorField:
+			item.Error = fmt.Sprint(value)
+		case deadDeliveriesField:
+			item.Deliveries, _ = strconv.ParseInt(fmt.Sprint(value), 10, 64)
+		case deadFailedAtField:
+			if ts, err := strconv.ParseInt(fmt.Sprint(value), 10, 64); err == nil {
+				item.FailedAt = time.Unix(ts, 0).UTC()
+			}
+		default:
+			item.Values[key] = value
+		}
+	}
+	return item
 }
 
 func (q *StreamQueue) Stream() string {
@@ -78,6 +533,10 @@ func (q *StreamQueue) Group() string {
 	return q.group
 }
 
+func (q *StreamQueue) DeadLetterStream() string {
+	return q.deadStream
+}
+
 func (q *StreamQueue) String() string {
 	return fmt.Sprintf("stream=%s group=%s consumer=%s", q.stream, q.group, q.consumer)
 }
@@ -2,7 +2,9 @@ package queue
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
@@ -39,3 +41,329 @@ func TestConsume_AcksMessageOnSuccess(t *testing.T) {
 		t.Fatalf("message was not handled")
 	}
 }
+
+func newTestQueue(t *testing.T, opts ...StreamOption) (*miniredis.Miniredis, *StreamQueue) {
+	t.Helper()
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	t.Cleanup(mini.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	q := NewStreamQueue(client, "stream:test", "worker", "c1", opts...)
+	if err := q.EnsureGroup(context.Background()); err != nil {
+		t.Fatalf("ensure group failed: %v", err)
+	}
+	return mini, q
+}
+
+func TestConsume_RetriesFailedMessageAfterBackoff(t *testing.T) {
+	mini, q := newTestQueue(t, WithRetryBackoff(time.Minute, 10*time.Minute))
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mini.SetTime(now)
+	if err := q.Publish(ctx, map[string]any{"url": "https://example.com/a"}); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	attempts := 0
+	handler := func(_ StreamMessage) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("fetch failed")
+		}
+		return nil
+	}
+
+	if err := q.Consume(ctx, handler); err == nil {
+		t.Fatalf("expected first delivery to fail")
+	}
+	// Still inside the first backoff: nothing new to read and nothing to reclaim.
+	mini.SetTime(now.Add(30 * time.Second))
+	if err := q.Consume(ctx, handler); err != nil {
+		t.Fatalf("consume during backoff failed: %v", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("expected no retry during backoff, got %d attempts", attempts)
+	}
+
+	mini.SetTime(now.Add(61 * time.Second))
+	if err := q.Consume(ctx, handler); err == nil {
+		t.Fatalf("expected second delivery to fail")
+	}
+	// The second retry waits twice as long.
+	mini.SetTime(now.Add(61*time.Second + 90*time.Second))
+	_ = q.Consume(ctx, handler)
+	if attempts != 2 {
+		t.Fatalf("expected doubled backoff before third delivery, got %d attempts", attempts)
+	}
+	mini.SetTime(now.Add(61*time.Second + 121*time.Second))
+	if err := q.Consume(ctx, handler); err != nil {
+		t.Fatalf("expected third delivery to succeed, got %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+
+	pending, err := q.client.XPending(ctx, q.stream, q.group).Result()
+	if err != nil {
+		t.Fatalf("xpending failed: %v", err)
+	}
+	if pending.Count != 0 {
+		t.Fatalf("expected message acked, got %d pending", pending.Count)
+	}
+}
+
+func TestConsume_ReclaimsMessageStrandedByCrashedConsumer(t *testing.T) {
+	mini, q := newTestQueue(t, WithRetryBackoff(time.Minute, time.Minute))
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mini.SetTime(now)
+	if err := q.Publish(ctx, map[string]any{"url": "https://example.com/a"}); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	crashed := q.ForConsumer("c0")
+	if _, err := crashed.readNew(ctx); err != nil {
+		t.Fatalf("read by crashed consumer failed: %v", err)
+	}
+
+	mini.SetTime(now.Add(2 * time.Minute))
+	var got StreamMessage
+	if err := q.Consume(ctx, func(msg StreamMessage) error {
+		got = msg
+		return nil
+	}); err != nil {
+		t.Fatalf("consume failed: %v", err)
+	}
+	if got.Values["url"] != "https://example.com/a" {
+		t.Fatalf("expected stranded message reclaimed, got %+v", got)
+	}
+}
+
+func TestConsume_DeadLettersAfterMaxDeliveriesAndReplays(t *testing.T) {
+	mini, q := newTestQueue(t, WithMaxDeliveries(2), WithRetryBackoff(time.Second, time.Second))
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mini.SetTime(now)
+	if err := q.Publish(ctx, map[string]any{"url": "https://example.com/a"}); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	failing := func(_ StreamMessage) error { return errors.New("upstream 500") }
+	_ = q.Consume(ctx, failing)
+	mini.SetTime(now.Add(2 * time.Second))
+	if err := q.Consume(ctx, failing); err == nil {
+		t.Fatalf("expected final delivery to fail")
+	}
+
+	items, err := q.ListDeadLetters(ctx, 10)
+	if err != nil {
+		t.Fatalf("list dead letters failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(items))
+	}
+	if items[0].Error != "upstream 500" || items[0].Deliveries != 2 || items[0].OriginalID == "" {
+		t.Fatalf("unexpected dead letter: %+v", items[0])
+	}
+	if items[0].Values["url"] != "https://example.com/a" {
+		t.Fatalf("expected original values kept, got %+v", items[0].Values)
+	}
+	if _, ok := items[0].Values[deadErrorField]; ok {
+		t.Fatalf("expected dead-letter metadata stripped from values")
+	}
+	pending, err := q.client.XPending(ctx, q.stream, q.group).Result()
+	if err != nil {
+		t.Fatalf("xpending failed: %v", err)
+	}
+	if pending.Count != 0 {
+		t.Fatalf("expected dead-lettered message acked, got %d pending", pending.Count)
+	}
+
+	if err := q.ReplayDeadLetter(ctx, items[0].ID); err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	var replayed StreamMessage
+	if err := q.Consume(ctx, func(msg StreamMessage) error {
+		replayed = msg
+		return nil
+	}); err != nil {
+		t.Fatalf("consume replayed message failed: %v", err)
+	}
+	if replayed.Values["url"] != "https://example.com/a" {
+		t.Fatalf("expected replayed job, got %+v", replayed)
+	}
+	if _, ok := replayed.Values[deadOriginalIDField]; ok {
+		t.Fatalf("expected replayed job without dead-letter metadata")
+	}
+	if err := q.DropDeadLetter(ctx, items[0].ID); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Fatalf("expected replayed dead letter removed, got %v", err)
+	}
+}
+
+func TestConsume_ReadsBatchAndPublishTrimsStream(t *testing.T) {
+	_, q := newTestQueue(t, WithBatchSize(3), WithMaxLen(5))
+	ctx := context.Background()
+	for i := 0; i < 8; i++ {
+		if err := q.Publish(ctx, map[string]any{"n": i}); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+	}
+	length, err := q.client.XLen(ctx, q.stream).Result()
+	if err != nil {
+		t.Fatalf("xlen failed: %v", err)
+	}
+	if length > 5 {
+		t.Fatalf("expected stream trimmed to 5 entries, got %d", length)
+	}
+
+	handled := 0
+	if err := q.Consume(ctx, func(_ StreamMessage) error {
+		handled++
+		return nil
+	}); err != nil {
+		t.Fatalf("consume failed: %v", err)
+	}
+	if handled != 3 {
+		t.Fatalf("expected one batch of 3, got %d", handled)
+	}
+}
+
+func TestConsume_SlowBatchKeepsItsTailFromOtherConsumers(t *testing.T) {
+	mini, q := newTestQueue(t, WithBatchSize(3), WithRetryBackoff(time.Minute, time.Minute))
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mini.SetTime(now)
+	for i := 0; i < 3; i++ {
+		if err := q.Publish(ctx, map[string]any{"n": i}); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+	}
+
+	other := q.ForConsumer("c2")
+	handled := 0
+	if err := q.Consume(ctx, func(_ StreamMessage) error {
+		handled++
+		if handled == 1 {
+			// The first message outlasts the retry backoff.
+			mini.SetTime(now.Add(2 * time.Minute))
+			return nil
+		}
+		stolen, err := other.reclaim(ctx)
+		if err != nil {
+			return err
+		}
+		if len(stolen) != 0 {
+			t.Fatalf("expected the batch tail kept from other consumers, got %+v", stolen)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("consume failed: %v", err)
+	}
+	if handled != 3 {
+		t.Fatalf("expected the whole batch handled, got %d", handled)
+	}
+	pending, err := q.client.XPending(ctx, q.stream, q.group).Result()
+	if err != nil || pending.Count != 0 {
+		t.Fatalf("expected the batch acked, got %+v %v", pending, err)
+	}
+}
+
+func TestConsume_ReclaimLooksPastEntriesStillBackingOff(t *testing.T) {
+	mini, q := newTestQueue(t, WithRetryBackoff(time.Minute, time.Hour))
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mini.SetTime(now)
+	for i := 0; i <= reclaimBatch; i++ {
+		if err := q.Publish(ctx, map[string]any{"n": i}); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+	}
+	crashed := q.ForConsumer("c0")
+	crashed.batchSize = reclaimBatch + 1
+	read, err := crashed.readNew(ctx)
+	if err != nil || len(read) != reclaimBatch+1 {
+		t.Fatalf("read by crashed consumer failed: %d %v", len(read), err)
+	}
+	// The oldest entries have been delivered three times and wait four minutes for the next try.
+	for _, msg := range read[:reclaimBatch] {
+		for i := 0; i < 2; i++ {
+			if err := q.client.XClaim(ctx, &redis.XClaimArgs{Stream: q.stream, Group: q.group, Consumer: "c0", Messages: []string{msg.ID}}).Err(); err != nil {
+				t.Fatalf("xclaim failed: %v", err)
+			}
+		}
+	}
+
+	mini.SetTime(now.Add(2 * time.Minute))
+	var got StreamMessage
+	if err := q.Consume(ctx, func(msg StreamMessage) error {
+		got = msg
+		return nil
+	}); err != nil {
+		t.Fatalf("consume failed: %v", err)
+	}
+	if got.ID != read[reclaimBatch].ID {
+		t.Fatalf("expected the due entry behind the backing-off ones reclaimed, got %+v", got)
+	}
+}
+
+func TestDeadLetters_AreNotTrimmedWithTheWorkStream(t *testing.T) {
+	mini, q := newTestQueue(t, WithMaxLen(2), WithMaxDeliveries(1))
+	ctx := context.Background()
+	mini.SetTime(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	for i := 0; i < 5; i++ {
+		if err := q.Publish(ctx, map[string]any{"n": i}); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+		_ = q.Consume(ctx, func(StreamMessage) error { return errors.New("upstream 500") })
+	}
+
+	items, err := q.ListDeadLetters(ctx, 10)
+	if err != nil || len(items) != 5 {
+		t.Fatalf("expected every dead letter kept, got %d %v", len(items), err)
+	}
+}
+
+func TestDeleteConsumers_KeepsConsumersWithPendingEntries(t *testing.T) {
+	mini, q := newTestQueue(t)
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mini.SetTime(now)
+	if err := q.Publish(ctx, map[string]any{"url": "https://example.com/a"}); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	holding := q.ForConsumer("holding")
+	if _, err := holding.readNew(ctx); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	// miniredis tracks a consumer's idle time only through XCLAIM.
+	touch := func(name string) {
+		if err := q.client.XClaimJustID(ctx, &redis.XClaimArgs{
+			Stream: q.stream, Group: q.group, Consumer: name, Messages: []string{"0-1"},
+		}).Err(); err != nil {
+			t.Fatalf("touch %s failed: %v", name, err)
+		}
+	}
+	touch("gone")
+	mini.SetTime(now.Add(2 * time.Hour))
+	touch("fresh")
+
+	deleted, err := q.DeleteIdleConsumers(ctx, time.Hour)
+	if err != nil || deleted != 1 {
+		t.Fatalf("expected only the idle empty consumer deleted, got %d %v", deleted, err)
+	}
+	if ok, err := holding.DeleteConsumer(ctx); err != nil || ok {
+		t.Fatalf("expected consumer with a pending entry kept, got %v %v", ok, err)
+	}
+	if ok, err := q.ForConsumer("fresh").DeleteConsumer(ctx); err != nil || !ok {
+		t.Fatalf("expected empty consumer deleted, got %v %v", ok, err)
+	}
+
+	consumers, err := q.client.XInfoConsumers(ctx, q.stream, q.group).Result()
+	if err != nil || len(consumers) != 1 || consumers[0].Name != "holding" {
+		t.Fatalf("expected only the holding consumer left, got %+v %v", consumers, err)
+	}
+}
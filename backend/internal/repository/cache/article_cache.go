@@ -2,12 +2,20 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
 )
 
-const ArticlesListKey = "cache:articles:list:v1"
+const (
+	ArticlesListKey = "cache:articles:list:v1"
+	// ArticlesFeedPrefix starts the key of every cached feed page.
+	ArticlesFeedPrefix = "cache:articles:feed:v1:"
+)
 
 type ArticleCache struct {
 	client redis.Cmdable
@@ -21,6 +29,51 @@ func NewArticleCache(client redis.Cmdable, ttl time.Duration) *ArticleCache {
 	return &ArticleCache{client: client, ttl: ttl}
 }
 
+// articleFeedKey keys a feed page by tag first, so each tag's pages sit under their own prefix.
+func articleFeedKey(query review.FeedQuery) string {
+	tag := query.Tag
+	if tag == "" {
+		tag = "ALL"
+	}
+	cursor := "first"
+	if query.After != nil {
+		cursor = query.After.Encode()
+	}
+	return fmt.Sprintf("%s%s:source:%d:fighter:%d:limit:%d:%s", ArticlesFeedPrefix, tag, query.SourceID, query.FighterID, query.Limit, cursor)
+}
+
+func (c *ArticleCache) GetFeed(ctx context.Context, query review.FeedQuery) (review.FeedPage, bool, error) {
+	payload, err := c.client.Get(ctx, articleFeedKey(query)).Result()
+	if err == redis.Nil {
+		return review.FeedPage{}, false, nil
+	}
+	if err != nil {
+		return review.FeedPage{}, false, err
+	}
+	var page review.FeedPage
+	if err := json.Unmarshal([]byte(payload), &page); err != nil {
+		return review.FeedPage{}, false, err
+	}
+	return page, true, nil
+}
+
+func (c *ArticleCache) SetFeed(ctx context.Context, query review.FeedQuery, page review.FeedPage) error {
+	payload, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, articleFeedKey(query), payload, c.ttl).Err()
+}
+
+// InvalidateArticlesList drops the article list, every cached feed page and the home screen.
 func (c *ArticleCache) InvalidateArticlesList(ctx context.Context) error {
-	return c.client.Del(ctx, ArticlesListKey).Err()
+	keys := []string{ArticlesListKey, HomeKey}
+	iter := c.client.Scan(ctx, 0, ArticlesFeedPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return c.client.Del(ctx, keys...).Err()
 }
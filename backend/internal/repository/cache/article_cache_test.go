@@ -7,6 +7,8 @@ import (
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
 )
 
 func TestInvalidateArticlesList(t *testing.T) {
@@ -34,3 +36,44 @@ func TestInvalidateArticlesList(t *testing.T) {
 		t.Fatalf("expected key %q to be deleted", ArticlesListKey)
 	}
 }
+
+func TestArticleFeedCache_KeysByTagAndInvalidatesAllPages(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	defer client.Close()
+
+	cache := NewArticleCache(client, time.Minute)
+	ctx := context.Background()
+
+	ufc := review.FeedQuery{Tag: "ufc", Limit: 20}
+	all := review.FeedQuery{Limit: 20}
+	if err := cache.SetFeed(ctx, ufc, review.FeedPage{Items: []review.PendingArticle{{ID: 1}}, NextCursor: "abc"}); err != nil {
+		t.Fatalf("set feed failed: %v", err)
+	}
+	if err := cache.SetFeed(ctx, all, review.FeedPage{Items: []review.PendingArticle{{ID: 1}, {ID: 2}}}); err != nil {
+		t.Fatalf("set feed failed: %v", err)
+	}
+
+	page, ok, err := cache.GetFeed(ctx, ufc)
+	if err != nil || !ok || len(page.Items) != 1 || page.NextCursor != "abc" {
+		t.Fatalf("expected cached ufc page, got %+v %v %v", page, ok, err)
+	}
+	if !mini.Exists(ArticlesFeedPrefix + "ufc:source:0:fighter:0:limit:20:first") {
+		t.Fatalf("expected the ufc page under its own key, got %v", mini.Keys())
+	}
+	if _, ok, _ := cache.GetFeed(ctx, review.FeedQuery{Tag: "results", Limit: 20}); ok {
+		t.Fatalf("expected a miss for another tag")
+	}
+
+	if err := cache.InvalidateArticlesList(ctx); err != nil {
+		t.Fatalf("invalidate cache failed: %v", err)
+	}
+	if keys := mini.Keys(); len(keys) != 0 {
+		t.Fatalf("expected every feed page dropped, got %v", keys)
+	}
+}
@@ -88,8 +88,5 @@ func (c *EventCache) InvalidateEvents(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	if len(keys) == 0 {
-		return nil
-	}
-	return c.client.Del(ctx, keys...).Err()
+	return c.client.Del(ctx, append(keys, HomeKey)...).Err()
 }
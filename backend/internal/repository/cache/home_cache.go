@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/featured"
+)
+
+// HomeKey holds the miniapp home screen. Article and event invalidation drop it as well,
+// since the home screen embeds the latest news and the next events.
+const HomeKey = "cache:home:v1"
+
+type HomeCache struct {
+	client redis.Cmdable
+}
+
+func NewHomeCache(client redis.Cmdable) *HomeCache {
+	return &HomeCache{client: client}
+}
+
+func (c *HomeCache) GetHome(ctx context.Context) (featured.Home, bool, error) {
+	payload, err := c.client.Get(ctx, HomeKey).Result()
+	if err == redis.Nil {
+		return featured.Home{}, false, nil
+	}
+	if err != nil {
+		return featured.Home{}, false, err
+	}
+	var home featured.Home
+	if err := json.Unmarshal([]byte(payload), &home); err != nil {
+		return featured.Home{}, false, err
+	}
+	return home, true, nil
+}
+
+func (c *HomeCache) SetHome(ctx context.Context, home featured.Home, ttl time.Duration) error {
+	payload, err := json.Marshal(home)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, HomeKey, payload, ttl).Err()
+}
+
+func (c *HomeCache) InvalidateHome(ctx context.Context) error {
+	return c.client.Del(ctx, HomeKey).Err()
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/featured"
+)
+
+func TestHomeCache_KeepsTTLAndDropsWithArticlesAndEvents(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	defer client.Close()
+
+	cache := NewHomeCache(client)
+	ctx := context.Background()
+	home := featured.Home{Latest: []featured.HomeArticle{{ID: 3, Title: "Latest"}}}
+
+	if err := cache.SetHome(ctx, home, 30*time.Second); err != nil {
+		t.Fatalf("set home failed: %v", err)
+	}
+	if ttl := mini.TTL(HomeKey); ttl != 30*time.Second {
+		t.Fatalf("expected the given ttl, got %v", ttl)
+	}
+	got, ok, err := cache.GetHome(ctx)
+	if err != nil || !ok || len(got.Latest) != 1 || got.Latest[0].Title != "Latest" {
+		t.Fatalf("expected cached home, got %+v %v %v", got, ok, err)
+	}
+
+	if err := NewArticleCache(client, time.Minute).InvalidateArticlesList(ctx); err != nil {
+		t.Fatalf("invalidate articles failed: %v", err)
+	}
+	if mini.Exists(HomeKey) {
+		t.Fatalf("expected home dropped with the article list")
+	}
+
+	_ = cache.SetHome(ctx, home, time.Minute)
+	if err := NewEventCache(client).InvalidateEvents(ctx); err != nil {
+		t.Fatalf("invalidate events failed: %v", err)
+	}
+	if _, ok, _ := cache.GetHome(ctx); ok {
+		t.Fatalf("expected home dropped with the event list")
+	}
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/live"
+)
+
+const (
+	liveMonitorStatusKey  = "live:monitor:status:v1"
+	liveMonitorPollKey    = "live:monitor:poll_now:v1"
+	liveMonitorPausedKey  = "live:monitor:paused:v1"
+	liveMonitorStatusTTL  = 10 * time.Minute
+	liveMonitorPollBatch  = 100
+	liveMonitorPollMaxAge = time.Hour
+)
+
+// LiveControlStore implements live.ControlStore on Redis so the API can reach the worker's monitor.
+type LiveControlStore struct {
+	client redis.Cmdable
+}
+
+func NewLiveControlStore(client redis.Cmdable) *LiveControlStore {
+	return &LiveControlStore{client: client}
+}
+
+func (s *LiveControlStore) SaveSnapshot(ctx context.Context, snapshot live.MonitorSnapshot) error {
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, liveMonitorStatusKey, payload, liveMonitorStatusTTL).Err()
+}
+
+func (s *LiveControlStore) LoadSnapshot(ctx context.Context) (live.MonitorSnapshot, bool, error) {
+	payload, err := s.client.Get(ctx, liveMonitorStatusKey).Result()
+	if err == redis.Nil {
+		return live.MonitorSnapshot{}, false, nil
+	}
+	if err != nil {
+		return live.MonitorSnapshot{}, false, err
+	}
+	var snapshot live.MonitorSnapshot
+	if err := json.Unmarshal([]byte(payload), &snapshot); err != nil {
+		return live.MonitorSnapshot{}, false, err
+	}
+	return snapshot, true, nil
+}
+
+func (s *LiveControlStore) RequestPoll(ctx context.Context, eventID int64) error {
+	if err := s.client.SAdd(ctx, liveMonitorPollKey, eventID).Err(); err != nil {
+		return err
+	}
+	// Requests nobody picks up (worker down) should not fire hours later.
+	return s.client.Expire(ctx, liveMonitorPollKey, liveMonitorPollMaxAge).Err()
+}
+
+func (s *LiveControlStore) TakePollRequests(ctx context.Context) ([]int64, error) {
+	members, err := s.client.SPopN(ctx, liveMonitorPollKey, liveMonitorPollBatch).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseEventIDs(members), nil
+}
+
+func (s *LiveControlStore) SetPaused(ctx context.Context, eventID int64, paused bool) error {
+	if paused {
+		return s.client.SAdd(ctx, liveMonitorPausedKey, eventID).Err()
+	}
+	return s.client.SRem(ctx, liveMonitorPausedKey, eventID).Err()
+}
+
+func (s *LiveControlStore) PausedEvents(ctx context.Context) (map[int64]bool, error) {
+	members, err := s.client.SMembers(ctx, liveMonitorPausedKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[int64]bool, len(members))
+	for _, id := range parseEventIDs(members) {
+		items[id] = true
+	}
+	return items, nil
+}
+
+func parseEventIDs(members []string) []int64 {
+	items := make([]int64, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseInt(member, 10, 64)
+		if err != nil || id <= 0 {
+			continue
+		}
+		items = append(items, id)
+	}
+	return items
+}
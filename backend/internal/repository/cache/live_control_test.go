@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/live"
+)
+
+func TestLiveControlStore_RoundTripsCommandsAndSnapshot(t *testing.T) {
+	mini, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis failed: %v", err)
+	}
+	defer mini.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mini.Addr()})
+	defer client.Close()
+
+	store := NewLiveControlStore(client)
+	ctx := context.Background()
+
+	if err := store.RequestPoll(ctx, 10); err != nil {
+		t.Fatalf("request poll failed: %v", err)
+	}
+	ids, err := store.TakePollRequests(ctx)
+	if err != nil || len(ids) != 1 || ids[0] != 10 {
+		t.Fatalf("expected poll request for event 10, got %v err=%v", ids, err)
+	}
+	if ids, _ := store.TakePollRequests(ctx); len(ids) != 0 {
+		t.Fatalf("expected poll requests to be consumed, got %v", ids)
+	}
+
+	if err := store.SetPaused(ctx, 11, true); err != nil {
+		t.Fatalf("pause failed: %v", err)
+	}
+	paused, err := store.PausedEvents(ctx)
+	if err != nil || !paused[11] {
+		t.Fatalf("expected event 11 paused, got %v err=%v", paused, err)
+	}
+	if err := store.SetPaused(ctx, 11, false); err != nil {
+		t.Fatalf("resume failed: %v", err)
+	}
+	if paused, _ := store.PausedEvents(ctx); paused[11] {
+		t.Fatalf("expected event 11 resumed")
+	}
+
+	now := time.Date(2026, 2, 23, 14, 0, 0, 0, time.UTC)
+	if err := store.SaveSnapshot(ctx, live.MonitorSnapshot{
+		UpdatedAt: now,
+		Events:    []live.EventMonitorStatus{{EventID: 10, Status: "live", LastOutcome: live.PollOutcomeChanged}},
+	}); err != nil {
+		t.Fatalf("save snapshot failed: %v", err)
+	}
+	snapshot, ok, err := store.LoadSnapshot(ctx)
+	if err != nil || !ok {
+		t.Fatalf("expected snapshot, ok=%v err=%v", ok, err)
+	}
+	if len(snapshot.Events) != 1 || snapshot.Events[0].LastOutcome != live.PollOutcomeChanged {
+		t.Fatalf("unexpected snapshot: %+v", snapshot)
+	}
+}
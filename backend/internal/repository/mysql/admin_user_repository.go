@@ -0,0 +1,142 @@
+package mysqlrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	mysqlerr "github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/auth"
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+)
+
+type AdminUserRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminUserRepository(db *gorm.DB) *AdminUserRepository {
+	return &AdminUserRepository{db: db}
+}
+
+func (r *AdminUserRepository) FindByUsername(ctx context.Context, username string) (auth.AdminUser, error) {
+	var row model.AdminUser
+	err := r.db.WithContext(ctx).Where("username = ?", username).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return auth.AdminUser{}, auth.ErrUserNotFound
+	}
+	if err != nil {
+		return auth.AdminUser{}, err
+	}
+	return adminUserFromRow(row), nil
+}
+
+func (r *AdminUserRepository) TouchLastLogin(ctx context.Context, userID int64) error {
+	return r.db.WithContext(ctx).Model(&model.AdminUser{}).Where("id = ?", userID).
+		UpdateColumn("last_login_at", time.Now().UTC()).Error
+}
+
+func (r *AdminUserRepository) List(ctx context.Context) ([]auth.AdminUser, error) {
+	var rows []model.AdminUser
+	if err := r.db.WithContext(ctx).Order("id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]auth.AdminUser, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, adminUserFromRow(row))
+	}
+	return items, nil
+}
+
+func (r *AdminUserRepository) Get(ctx context.Context, userID int64) (auth.AdminUser, error) {
+	var row model.AdminUser
+	err := r.db.WithContext(ctx).Where("id = ?", userID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return auth.AdminUser{}, auth.ErrUserNotFound
+	}
+	if err != nil {
+		return auth.AdminUser{}, err
+	}
+	return adminUserFromRow(row), nil
+}
+
+func (r *AdminUserRepository) Create(ctx context.Context, user auth.AdminUser) (auth.AdminUser, error) {
+	row := model.AdminUser{
+		Username:     user.Username,
+		PasswordHash: user.PasswordHash,
+		Status:       user.Status,
+	}
+	// The unique username index decides races between concurrent creates and bootstraps.
+	err := r.db.WithContext(ctx).Create(&row).Error
+	if isDuplicateEntry(err) {
+		return auth.AdminUser{}, auth.ErrDuplicateUser
+	}
+	if err != nil {
+		return auth.AdminUser{}, err
+	}
+	return adminUserFromRow(row), nil
+}
+
+func (r *AdminUserRepository) SetStatus(ctx context.Context, userID int64, status string) error {
+	return r.updateUser(ctx, userID, map[string]any{"status": status})
+}
+
+func (r *AdminUserRepository) SetPassword(ctx context.Context, userID int64, passwordHash string, changedAt time.Time) error {
+	return r.updateUser(ctx, userID, map[string]any{
+		"password_hash":       passwordHash,
+		"password_changed_at": changedAt,
+	})
+}
+
+func (r *AdminUserRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.AdminUser{}).Count(&count).Error
+	return count, err
+}
+
+func (r *AdminUserRepository) updateUser(ctx context.Context, userID int64, values map[string]any) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.AdminUser{}).Where("id = ?", userID).Updates(values)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+		// MySQL reports zero affected rows when nothing changed, so tell that apart from a miss.
+		var count int64
+		if err := tx.Model(&model.AdminUser{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return auth.ErrUserNotFound
+		}
+		return nil
+	})
+}
+
+func adminUserFromRow(row model.AdminUser) auth.AdminUser {
+	user := auth.AdminUser{
+		ID:           row.ID,
+		Username:     row.Username,
+		PasswordHash: row.PasswordHash,
+		Status:       row.Status,
+		CreatedAt:    row.CreatedAt.UTC(),
+	}
+	if row.PasswordChangedAt != nil {
+		changedAt := row.PasswordChangedAt.UTC()
+		user.PasswordChangedAt = &changedAt
+	}
+	if row.LastLoginAt != nil {
+		lastLoginAt := row.LastLoginAt.UTC()
+		user.LastLoginAt = &lastLoginAt
+	}
+	return user
+}
+
+// isDuplicateEntry reports whether err is MySQL error 1062, a unique key violation.
+func isDuplicateEntry(err error) bool {
+	var mysqlError *mysqlerr.MySQLError
+	return errors.As(err, &mysqlError) && mysqlError.Number == 1062
+}
@@ -0,0 +1,219 @@
+package mysqlrepo
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
+)
+
+const entityTextLimit = 255
+
+// SavePendingEntity adds or updates one link of a pending article. The display name is read
+// from the fighter or event, so editors can add links detection missed.
+func (r *ArticleRepository) SavePendingEntity(ctx context.Context, pendingID int64, link linking.Link) (linking.Link, error) {
+	name, err := entityName(r.db.WithContext(ctx), link.EntityType, link.EntityID)
+	if err != nil {
+		return linking.Link{}, err
+	}
+	link.Name = name
+
+	row := entityRow(link)
+	row.PendingArticleID = &pendingID
+	err = r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"status", "entity_name", "updated_at"}),
+	}).Create(&row).Error
+	if err != nil {
+		return linking.Link{}, err
+	}
+	return link, nil
+}
+
+// ListArticlesByEntity returns published articles linked to the entity, newest first.
+func (r *ArticleRepository) ListArticlesByEntity(ctx context.Context, entityType string, entityID int64, limit int) ([]review.PendingArticle, error) {
+	var rows []model.Article
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", "published").
+		Where("id IN (?)", linkedArticleIDs(r.db, entityType, entityID)).
+		Order("published_at DESC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]review.PendingArticle, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, publishedArticleFromRow(row))
+	}
+	return items, nil
+}
+
+// linkedArticleIDs selects the published articles linked to an entity, leaving out rejected
+// links.
+func linkedArticleIDs(db *gorm.DB, entityType string, entityID int64) *gorm.DB {
+	return db.Model(&model.ArticleEntity{}).
+		Select("article_id").
+		Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Where("status <> ?", linking.StatusRejected).
+		Where("article_id IS NOT NULL")
+}
+
+// loadArticleEntities returns the links of a published article, leaving out rejected ones.
+func (r *ArticleRepository) loadArticleEntities(ctx context.Context, articleID int64) ([]linking.Link, error) {
+	var rows []model.ArticleEntity
+	if err := r.db.WithContext(ctx).
+		Where("article_id = ? AND status <> ?", articleID, linking.StatusRejected).
+		Order("id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	links := make([]linking.Link, 0, len(rows))
+	for _, row := range rows {
+		links = append(links, linking.Link{
+			EntityType:  row.EntityType,
+			EntityID:    row.EntityID,
+			Name:        row.EntityName,
+			MatchedText: ptrStringValue(row.MatchedText),
+			Status:      row.Status,
+		})
+	}
+	return links, nil
+}
+
+func (r *ArticleRepository) loadPendingEntities(ctx context.Context, pendingIDs []int64) (map[int64][]linking.Link, error) {
+	out := make(map[int64][]linking.Link, len(pendingIDs))
+	if len(pendingIDs) == 0 {
+		return out, nil
+	}
+	var rows []model.ArticleEntity
+	if err := r.db.WithContext(ctx).
+		Where("pending_article_id IN ?", pendingIDs).
+		Order("id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		pendingID := ptrInt64Value(row.PendingArticleID)
+		out[pendingID] = append(out[pendingID], linking.Link{
+			EntityType:  row.EntityType,
+			EntityID:    row.EntityID,
+			Name:        row.EntityName,
+			MatchedText: ptrStringValue(row.MatchedText),
+			Status:      row.Status,
+		})
+	}
+	return out, nil
+}
+
+func savePendingEntities(tx *gorm.DB, pendingID int64, links []linking.Link) error {
+	if len(links) == 0 {
+		return nil
+	}
+	rows := make([]model.ArticleEntity, 0, len(links))
+	for _, link := range links {
+		row := entityRow(link)
+		row.PendingArticleID = &pendingID
+		rows = append(rows, row)
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+// publishEntities points the pending item's links at the new article. Links found only at
+// approval are inserted; rejected links stay behind on the pending item.
+func publishEntities(tx *gorm.DB, rec review.PendingArticle, articleID int64) error {
+	links := make([]linking.Link, 0, len(rec.Entities))
+	for _, link := range rec.Entities {
+		if link.Status != linking.StatusRejected {
+			links = append(links, link)
+		}
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	rows := make([]model.ArticleEntity, 0, len(links))
+	for _, link := range links {
+		row := entityRow(link)
+		row.PendingArticleID = ptrInt64(rec.ID)
+		row.ArticleID = &articleID
+		rows = append(rows, row)
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"article_id", "updated_at"}),
+	}).Create(&rows).Error
+}
+
+func entityRow(link linking.Link) model.ArticleEntity {
+	status := link.Status
+	if status == "" {
+		status = linking.StatusSuggested
+	}
+	return model.ArticleEntity{
+		EntityType:  link.EntityType,
+		EntityID:    link.EntityID,
+		EntityName:  truncateRunes(link.Name, entityTextLimit),
+		MatchedText: stringOrNil(truncateRunes(link.MatchedText, entityTextLimit)),
+		Status:      status,
+	}
+}
+
+func entityName(db *gorm.DB, entityType string, entityID int64) (string, error) {
+	var err error
+	name := ""
+	switch entityType {
+	case linking.EntityFighter:
+		var fighter model.Fighter
+		err = db.Select("id", "name", "name_zh").Where("id = ?", entityID).Take(&fighter).Error
+		name = fighter.Name
+		if zh := ptrStringValue(fighter.NameZH); zh != "" {
+			name = zh
+		}
+	case linking.EntityEvent:
+		var event model.Event
+		err = db.Select("id", "name").Where("id = ?", entityID).Take(&event).Error
+		name = event.Name
+	default:
+		return "", review.ErrInvalidEntityLink
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", review.ErrUnknownEntity
+	}
+	return name, err
+}
+
+// LinkDirectory lists the fighters and events article links can point at.
+type LinkDirectory struct {
+	db *gorm.DB
+}
+
+func NewLinkDirectory(db *gorm.DB) *LinkDirectory {
+	return &LinkDirectory{db: db}
+}
+
+func (d *LinkDirectory) ListLinkCandidates(ctx context.Context) ([]linking.Candidate, error) {
+	var fighters []model.Fighter
+	if err := d.db.WithContext(ctx).
+		Select("id", "name", "name_zh", "nickname").
+		Find(&fighters).Error; err != nil {
+		return nil, err
+	}
+	var events []model.Event
+	if err := d.db.WithContext(ctx).
+		Select("id", "name").
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	candidates := make([]linking.Candidate, 0, len(fighters)+len(events))
+	for _, f := range fighters {
+		candidates = append(candidates, linking.FighterCandidate(f.ID, f.Name, ptrStringValue(f.NameZH), ptrStringValue(f.Nickname)))
+	}
+	for _, e := range events {
+		candidates = append(candidates, linking.EventCandidate(e.ID, e.Name))
+	}
+	return candidates, nil
+}
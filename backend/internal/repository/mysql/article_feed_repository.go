@@ -0,0 +1,69 @@
+package mysqlrepo
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
+)
+
+// feedCardColumns are the columns a feed card shows; the MEDIUMTEXT bodies are left to
+// GetPublished.
+var feedCardColumns = []string{"id", "source_id", "title", "summary", "source_url", "cover_url", "video_url", "published_at"}
+
+func (r *ArticleRepository) ListFeed(ctx context.Context, query review.FeedQuery) ([]review.PendingArticle, error) {
+	db := r.db.WithContext(ctx).Select(feedCardColumns).Where("status = ?", "published")
+	if query.SourceID > 0 {
+		db = db.Where("source_id = ?", query.SourceID)
+	}
+	if query.FighterID > 0 {
+		db = db.Where("id IN (?)", linkedArticleIDs(r.db, linking.EntityFighter, query.FighterID))
+	}
+	if query.Tag != "" {
+		db = db.Where("id IN (?)", taggedArticleIDs(r.db, query.Tag))
+	}
+	if query.After != nil {
+		db = db.Where("(published_at < ? OR (published_at = ? AND id < ?))",
+			query.After.PublishedAt, query.After.PublishedAt, query.After.ID)
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	var rows []model.Article
+	if err := db.Order("published_at DESC, id DESC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]review.PendingArticle, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, publishedArticleFromRow(row))
+	}
+	return items, nil
+}
+
+func (r *ArticleRepository) GetPublished(ctx context.Context, articleID int64) (review.PendingArticle, error) {
+	var row model.Article
+	err := r.db.WithContext(ctx).Where("id = ? AND status = ?", articleID, "published").Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return review.PendingArticle{}, review.ErrArticleNotFound
+	}
+	if err != nil {
+		return review.PendingArticle{}, err
+	}
+
+	item := publishedArticleFromRow(row)
+	item.Content = row.Content
+	item.OriginalTitle = ptrStringValue(row.OriginalTitle)
+	item.OriginalContent = ptrStringValue(row.OriginalContent)
+	if item.Entities, err = r.loadArticleEntities(ctx, articleID); err != nil {
+		return review.PendingArticle{}, err
+	}
+	if item.Tags, err = r.loadArticleTags(ctx, articleID); err != nil {
+		return review.PendingArticle{}, err
+	}
+	return item, nil
+}
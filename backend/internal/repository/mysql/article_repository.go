@@ -3,108 +3,387 @@ package mysqlrepo
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/bajiaozhi/w-mma/backend/internal/model"
 	"github.com/bajiaozhi/w-mma/backend/internal/review"
+	"github.com/bajiaozhi/w-mma/backend/internal/revision"
 )
 
+// nearDuplicateWindow bounds the SimHash scan to stories still likely to be in the queue.
+const nearDuplicateWindow = 7 * 24 * time.Hour
+
 type ArticleRepository struct {
 	db *gorm.DB
 }
 
+// reviewedListLimit caps lists of approved or rejected articles.
+const reviewedListLimit = 200
+
 func NewArticleRepository(db *gorm.DB) *ArticleRepository {
 	return &ArticleRepository{db: db}
 }
 
 func (r *ArticleRepository) GetPending(ctx context.Context, pendingID int64) (review.PendingArticle, error) {
 	var pending model.PendingArticle
-	err := r.db.WithContext(ctx).
-		Where("id = ?", pendingID).
-		Where("status = ?", "pending").
-		Take(&pending).Error
+	err := r.db.WithContext(ctx).Where("id = ?", pendingID).Take(&pending).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return review.PendingArticle{}, review.ErrPendingNotFound
+	}
 	if err != nil {
 		return review.PendingArticle{}, err
 	}
 
-	return review.PendingArticle{
-		ID:        pending.ID,
-		SourceID:  ptrInt64Value(pending.SourceID),
-		Title:     pending.Title,
-		Summary:   pending.Summary,
-		SourceURL: pending.SourceURL,
-	}, nil
+	items, err := r.withPendingDetails(ctx, []model.PendingArticle{pending})
+	if err != nil {
+		return review.PendingArticle{}, err
+	}
+	return items[0], nil
 }
 
-func (r *ArticleRepository) PublishArticle(ctx context.Context, rec review.PendingArticle) error {
+// ApprovePending publishes rec and marks its pending row approved in one transaction. The
+// pending row is locked first, so two reviewers approving together publish only once.
+func (r *ArticleRepository) ApprovePending(ctx context.Context, rec review.PendingArticle, change review.StatusChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockPending(tx, rec.ID, change.Guard); err != nil {
+			return err
+		}
+		if err := publishArticle(tx, rec, change.ReviewerID); err != nil {
+			return err
+		}
+		return applyStatusChange(tx, rec.ID, change)
+	})
+}
+
+// SetStatus moves a pending article between review statuses while change.Guard still holds.
+func (r *ArticleRepository) SetStatus(ctx context.Context, pendingID int64, change review.StatusChange) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockPending(tx, pendingID, change.Guard); err != nil {
+			return err
+		}
+		return applyStatusChange(tx, pendingID, change)
+	})
+}
+
+func (r *ArticleRepository) UpdatePending(ctx context.Context, pendingID int64, edit review.PendingEdit, guard review.Guard) error {
+	updates := map[string]any{"version": gorm.Expr("version + 1")}
+	if edit.Title != nil {
+		updates["title"] = *edit.Title
+	}
+	if edit.Summary != nil {
+		updates["summary"] = *edit.Summary
+	}
+	if edit.CoverURL != nil {
+		updates["cover_url"] = stringOrNil(*edit.CoverURL)
+	}
+	if edit.VideoURL != nil {
+		updates["video_url"] = stringOrNil(*edit.VideoURL)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		row, err := lockPending(tx, pendingID, guard)
+		if err != nil {
+			return err
+		}
+		edited := review.MarkEdited(splitNonEmpty(ptrStringValue(row.EditedFields)), edit)
+		updates["edited_fields"] = stringOrNil(strings.Join(edited, ","))
+		return tx.Model(&model.PendingArticle{}).Where("id = ?", pendingID).Updates(updates).Error
+	})
+}
+
+func (r *ArticleRepository) SetClaim(ctx context.Context, pendingID int64, guard review.Guard, claimant int64, until *time.Time) error {
+	updates := map[string]any{"claimed_by": nil, "claimed_until": nil}
+	if until != nil {
+		updates["claimed_by"] = claimant
+		updates["claimed_until"] = *until
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockPending(tx, pendingID, guard); err != nil {
+			return err
+		}
+		return tx.Model(&model.PendingArticle{}).Where("id = ?", pendingID).Updates(updates).Error
+	})
+}
+
+// lockPending reads a pending row FOR UPDATE and checks the guard against it.
+func lockPending(tx *gorm.DB, pendingID int64, guard review.Guard) (model.PendingArticle, error) {
+	var row model.PendingArticle
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", pendingID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return row, review.ErrPendingNotFound
+	}
+	if err != nil {
+		return row, err
+	}
+	return row, guard.Check(pendingArticleFromRow(row), time.Now())
+}
+
+func applyStatusChange(tx *gorm.DB, pendingID int64, change review.StatusChange) error {
+	updates := map[string]any{
+		"status":        change.To,
+		"reject_reason": stringOrNil(change.Reason),
+		"version":       gorm.Expr("version + 1"),
+		"claimed_by":    nil,
+		"claimed_until": nil,
+	}
+	if change.To != review.StatusPending {
+		updates["reviewer_id"] = ptrInt64(change.ReviewerID)
+		updates["reviewed_at"] = time.Now()
+	}
+	return tx.Model(&model.PendingArticle{}).Where("id = ?", pendingID).Updates(updates).Error
+}
+
+func publishArticle(tx *gorm.DB, rec review.PendingArticle, reviewerID int64) error {
+	content := rec.Content
+	if strings.TrimSpace(content) == "" {
+		content = rec.Summary
+	}
+	publishedAt := time.Now()
+	if rec.PublishedAt != nil && !rec.PublishedAt.IsZero() {
+		publishedAt = *rec.PublishedAt
+	}
 	article := model.Article{
-		Title:         rec.Title,
-		Content:       rec.Summary,
-		SourceURL:     rec.SourceURL,
-		PublishedMode: "manual",
-		Status:        "published",
-		PublishedAt:   time.Now(),
+		SourceID:        ptrInt64(rec.SourceID),
+		GUID:            stringOrNil(rec.GUID),
+		Title:           rec.Title,
+		Summary:         stringOrNil(rec.Summary),
+		Content:         content,
+		Author:          stringOrNil(rec.Author),
+		SourceURL:       rec.SourceURL,
+		CoverURL:        stringOrNil(rec.CoverURL),
+		VideoURL:        stringOrNil(rec.VideoURL),
+		OriginalTitle:   stringOrNil(rec.OriginalTitle),
+		OriginalContent: stringOrNil(rec.OriginalContent),
+		PublishedMode:   "manual",
+		Status:          "published",
+		PublishedAt:     publishedAt,
+		PublishAt:       rec.PublishAt,
+		EmbargoUntil:    rec.EmbargoUntil,
+	}
+	if rec.PublishAt != nil {
+		article.Status = "scheduled"
+	}
+	if err := tx.Create(&article).Error; err != nil {
+		return err
 	}
-	return r.db.WithContext(ctx).Create(&article).Error
+	if _, err := recordRevision(tx, article, reviewerID, revision.ActionPublished); err != nil {
+		return err
+	}
+	if err := publishEntities(tx, rec, article.ID); err != nil {
+		return err
+	}
+	return publishTags(tx, rec.ID, article.ID)
 }
 
-func (r *ArticleRepository) MarkApproved(ctx context.Context, pendingID int64, reviewerID int64) error {
-	reviewedAt := time.Now()
-	result := r.db.WithContext(ctx).Model(&model.PendingArticle{}).
-		Where("id = ?", pendingID).
-		Updates(map[string]any{
-			"status":      "approved",
-			"reviewer_id": reviewerID,
-			"reviewed_at": reviewedAt,
-		})
-	if result.Error != nil {
-		return result.Error
+func (r *ArticleRepository) AddNote(ctx context.Context, pendingID int64, note review.Note) (review.Note, error) {
+	row := model.ReviewNote{
+		PendingArticleID: pendingID,
+		ReviewerID:       ptrInt64(note.ReviewerID),
+		Body:             note.Body,
 	}
-	if result.RowsAffected == 0 {
-		return errors.New("pending article not found")
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return review.Note{}, err
 	}
-	return nil
+	return reviewNoteFromRow(row), nil
 }
 
-func (r *ArticleRepository) ListPending(ctx context.Context) ([]review.PendingArticle, error) {
+// ListPending returns articles in one review status. Decided items are capped to the most
+// recent ones since they accumulate forever.
+func (r *ArticleRepository) ListPending(ctx context.Context, filter review.PendingFilter) ([]review.PendingArticle, error) {
+	query := r.db.WithContext(ctx).Where("status = ?", filter.Status)
+	if filter.SourceID > 0 {
+		query = query.Where("source_id = ?", filter.SourceID)
+	}
+	if filter.From != nil {
+		query = query.Where("published_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("published_at < ?", *filter.To)
+	}
+	if filter.Status == review.StatusPending {
+		query = query.Order("id ASC")
+	} else {
+		query = query.Order("id DESC").Limit(reviewedListLimit)
+	}
 	var rows []model.PendingArticle
-	if err := r.db.WithContext(ctx).
-		Where("status = ?", "pending").
-		Order("id ASC").
-		Find(&rows).Error; err != nil {
+	if err := query.Find(&rows).Error; err != nil {
 		return nil, err
 	}
+	return r.withPendingDetails(ctx, rows)
+}
 
+// withPendingDetails maps rows and attaches their entity links, tags, translation drafts and
+// notes.
+func (r *ArticleRepository) withPendingDetails(ctx context.Context, rows []model.PendingArticle) ([]review.PendingArticle, error) {
+	ids := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	entities, err := r.loadPendingEntities(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := r.loadPendingTags(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	translations, err := r.loadTranslations(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := r.loadReviewNotes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
 	items := make([]review.PendingArticle, 0, len(rows))
 	for _, row := range rows {
-		items = append(items, review.PendingArticle{
-			ID:        row.ID,
-			SourceID:  ptrInt64Value(row.SourceID),
-			Title:     row.Title,
-			Summary:   row.Summary,
-			SourceURL: row.SourceURL,
-		})
+		item := pendingArticleFromRow(row)
+		item.Entities = entities[row.ID]
+		item.Tags = tags[row.ID]
+		item.Translation = translations[row.ID]
+		item.Notes = notes[row.ID]
+		items = append(items, item)
 	}
 	return items, nil
 }
 
+func (r *ArticleRepository) loadReviewNotes(ctx context.Context, pendingIDs []int64) (map[int64][]review.Note, error) {
+	out := make(map[int64][]review.Note, len(pendingIDs))
+	if len(pendingIDs) == 0 {
+		return out, nil
+	}
+	var rows []model.ReviewNote
+	if err := r.db.WithContext(ctx).
+		Where("pending_article_id IN ?", pendingIDs).
+		Order("id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.PendingArticleID] = append(out[row.PendingArticleID], reviewNoteFromRow(row))
+	}
+	return out, nil
+}
+
+func reviewNoteFromRow(row model.ReviewNote) review.Note {
+	return review.Note{
+		ID:         row.ID,
+		ReviewerID: ptrInt64Value(row.ReviewerID),
+		Body:       row.Body,
+		CreatedAt:  row.CreatedAt,
+	}
+}
+
 func (r *ArticleRepository) CreatePending(ctx context.Context, item review.PendingArticle) (review.PendingArticle, error) {
 	row := model.PendingArticle{
-		SourceID:  ptrInt64(item.SourceID),
-		Title:     item.Title,
-		Summary:   item.Summary,
-		SourceURL: item.SourceURL,
-		Status:    "pending",
+		SourceID:    ptrInt64(item.SourceID),
+		GUID:        stringOrNil(item.GUID),
+		Title:       item.Title,
+		Summary:     item.Summary,
+		Content:     stringOrNil(item.Content),
+		Author:      stringOrNil(item.Author),
+		SourceURL:   item.SourceURL,
+		CoverURL:    stringOrNil(item.CoverURL),
+		VideoURL:    stringOrNil(item.VideoURL),
+		PublishedAt: item.PublishedAt,
+		DuplicateOf: ptrInt64(item.DuplicateOf),
+		Status:      review.StatusPending,
 	}
-	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+	if item.SimHash != 0 {
+		simhash := item.SimHash
+		row.SimHash = &simhash
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		if err := savePendingEntities(tx, row.ID, item.Entities); err != nil {
+			return err
+		}
+		return savePendingTags(tx, row.ID, item.Tags)
+	})
+	if err != nil {
 		return review.PendingArticle{}, err
 	}
 	item.ID = row.ID
 	return item, nil
 }
 
+// HasSeen reports whether an item is already pending or published: by GUID within the
+// source, since GUIDs are only unique per feed, or by link from any source.
+func (r *ArticleRepository) HasSeen(ctx context.Context, sourceID int64, guid string, link string) (bool, error) {
+	guid = strings.TrimSpace(guid)
+	link = strings.TrimSpace(link)
+	if guid == "" && link == "" {
+		return false, nil
+	}
+	for _, table := range []any{&model.PendingArticle{}, &model.Article{}} {
+		query := r.db.WithContext(ctx).Model(table)
+		switch {
+		case guid != "" && link != "":
+			query = query.Where("(source_id = ? AND guid = ?) OR source_url = ?", sourceID, guid, link)
+		case guid != "":
+			query = query.Where("source_id = ? AND guid = ?", sourceID, guid)
+		default:
+			query = query.Where("source_url = ?", link)
+		}
+		var count int64
+		if err := query.Limit(1).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// WasQueued reports whether a listing page of the source queued the link since the given time.
+func (r *ArticleRepository) WasQueued(ctx context.Context, sourceID int64, link string, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.CrawlLink{}).
+		Where("source_id = ? AND url = ? AND created_at >= ?", sourceID, link, since).
+		Limit(1).Count(&count).Error
+	return count > 0, err
+}
+
+// MarkQueued remembers a link queued from a listing page of the source, renewing an old mark.
+func (r *ArticleRepository) MarkQueued(ctx context.Context, sourceID int64, link string, at time.Time) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"created_at"})}).
+		Create(&model.CrawlLink{SourceID: sourceID, URL: link, CreatedAt: at}).Error
+}
+
+// PruneQueued forgets the source's links queued before the given time.
+func (r *ArticleRepository) PruneQueued(ctx context.Context, sourceID int64, before time.Time) error {
+	return r.db.WithContext(ctx).
+		Where("source_id = ? AND created_at < ?", sourceID, before).
+		Delete(&model.CrawlLink{}).Error
+}
+
+// FindNearDuplicate returns the oldest recent pending primary whose SimHash is within maxDistance bits.
+func (r *ArticleRepository) FindNearDuplicate(ctx context.Context, simhash uint64, maxDistance int) (int64, bool, error) {
+	var row model.PendingArticle
+	err := r.db.WithContext(ctx).
+		Select("id").
+		Where("status = ?", "pending").
+		Where("duplicate_of IS NULL").
+		Where("simhash IS NOT NULL").
+		Where("created_at >= ?", time.Now().Add(-nearDuplicateWindow)).
+		Where("BIT_COUNT(simhash ^ ?) <= ?", simhash, maxDistance).
+		Order("id ASC").
+		Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return row.ID, true, nil
+}
+
 func (r *ArticleRepository) ListPublished(ctx context.Context) ([]review.PendingArticle, error) {
 	var rows []model.Article
 	if err := r.db.WithContext(ctx).
@@ -116,31 +395,119 @@ func (r *ArticleRepository) ListPublished(ctx context.Context) ([]review.Pending
 
 	items := make([]review.PendingArticle, 0, len(rows))
 	for _, row := range rows {
-		coverURL := ""
-		if row.CoverURL != nil {
-			coverURL = *row.CoverURL
+		items = append(items, publishedArticleFromRow(row))
+	}
+	return items, nil
+}
+
+func (r *ArticleRepository) ListScheduled(ctx context.Context) ([]review.PendingArticle, error) {
+	var rows []model.Article
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", "scheduled").
+		Order("publish_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]review.PendingArticle, 0, len(rows))
+	for _, row := range rows {
+		item := publishedArticleFromRow(row)
+		item.PublishAt = row.PublishAt
+		item.EmbargoUntil = row.EmbargoUntil
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *ArticleRepository) ReleaseDue(ctx context.Context, now time.Time) (int, error) {
+	released := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []model.Article
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND publish_at <= ?", "scheduled", now).
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := setArticleStatus(tx, row, "published", 0, revision.ActionReleased); err != nil {
+				return err
+			}
 		}
-		videoURL := ""
-		if row.VideoURL != nil {
-			videoURL = *row.VideoURL
+		released = len(rows)
+		return nil
+	})
+	return released, err
+}
+
+// OfflineArticle takes a published article down and records who did it. Unknown articles are
+// ignored.
+func (r *ArticleRepository) OfflineArticle(ctx context.Context, articleID int64, editorID int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row model.Article
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", articleID).Take(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
 		}
-		items = append(items, review.PendingArticle{
-			ID:        row.ID,
-			SourceID:  ptrInt64Value(row.SourceID),
-			Title:     row.Title,
-			Summary:   row.Content,
-			SourceURL: row.SourceURL,
-			CoverURL:  coverURL,
-			VideoURL:  videoURL,
-		})
+		if err != nil {
+			return err
+		}
+		return setArticleStatus(tx, row, "offline", editorID, revision.ActionOfflined)
+	})
+}
+
+func publishedArticleFromRow(row model.Article) review.PendingArticle {
+	summary := ptrStringValue(row.Summary)
+	if summary == "" {
+		summary = row.Content
+	}
+	publishedAt := row.PublishedAt
+	return review.PendingArticle{
+		ID:          row.ID,
+		SourceID:    ptrInt64Value(row.SourceID),
+		Title:       row.Title,
+		Summary:     summary,
+		Author:      ptrStringValue(row.Author),
+		SourceURL:   row.SourceURL,
+		CoverURL:    ptrStringValue(row.CoverURL),
+		VideoURL:    ptrStringValue(row.VideoURL),
+		PublishedAt: &publishedAt,
+	}
+}
+
+func pendingArticleFromRow(row model.PendingArticle) review.PendingArticle {
+	return review.PendingArticle{
+		ID:           row.ID,
+		SourceID:     ptrInt64Value(row.SourceID),
+		GUID:         ptrStringValue(row.GUID),
+		Title:        row.Title,
+		Summary:      row.Summary,
+		Content:      ptrStringValue(row.Content),
+		Author:       ptrStringValue(row.Author),
+		SourceURL:    row.SourceURL,
+		CoverURL:     ptrStringValue(row.CoverURL),
+		VideoURL:     ptrStringValue(row.VideoURL),
+		PublishedAt:  row.PublishedAt,
+		DuplicateOf:  ptrInt64Value(row.DuplicateOf),
+		Status:       row.Status,
+		RejectReason: ptrStringValue(row.RejectReason),
+		ReviewerID:   ptrInt64Value(row.ReviewerID),
+		ReviewedAt:   row.ReviewedAt,
+		Version:      row.Version,
+		ClaimedBy:    ptrInt64Value(row.ClaimedBy),
+		ClaimedUntil: row.ClaimedUntil,
+		EditedFields: splitNonEmpty(ptrStringValue(row.EditedFields)),
 	}
-	return items, nil
 }
 
-func (r *ArticleRepository) OfflineArticle(ctx context.Context, articleID int64) error {
-	return r.db.WithContext(ctx).Model(&model.Article{}).
-		Where("id = ?", articleID).
-		Update("status", "offline").Error
+// splitNonEmpty splits a comma list stored in one column.
+func splitNonEmpty(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 func ptrInt64(value int64) *int64 {
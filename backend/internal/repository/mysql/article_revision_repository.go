@@ -0,0 +1,166 @@
+package mysqlrepo
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+	"github.com/bajiaozhi/w-mma/backend/internal/revision"
+)
+
+type ArticleRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewArticleRevisionRepository(db *gorm.DB) *ArticleRevisionRepository {
+	return &ArticleRevisionRepository{db: db}
+}
+
+func (r *ArticleRevisionRepository) Current(ctx context.Context, articleID int64) (revision.Snapshot, error) {
+	var row model.Article
+	err := r.db.WithContext(ctx).Where("id = ?", articleID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return revision.Snapshot{}, revision.ErrArticleNotFound
+	}
+	if err != nil {
+		return revision.Snapshot{}, err
+	}
+	return snapshotFromArticle(row), nil
+}
+
+// Save locks the article row so concurrent edits are applied and recorded one after the other.
+func (r *ArticleRevisionRepository) Save(ctx context.Context, articleID int64, build func(current revision.Snapshot) (revision.Snapshot, error), editorID int64, action string) (revision.Revision, error) {
+	var saved model.ArticleRevision
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row model.Article
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", articleID).Take(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return revision.ErrArticleNotFound
+		}
+		if err != nil {
+			return err
+		}
+		next, err := build(snapshotFromArticle(row))
+		if err != nil {
+			return err
+		}
+		if err := ensureBaselineRevision(tx, row); err != nil {
+			return err
+		}
+
+		row.Title = next.Title
+		row.Summary = stringOrNil(next.Summary)
+		row.Content = next.Content
+		row.CoverURL = stringOrNil(next.CoverURL)
+		row.VideoURL = stringOrNil(next.VideoURL)
+		if err := tx.Model(&model.Article{}).Where("id = ?", articleID).Updates(map[string]any{
+			"title":     row.Title,
+			"summary":   row.Summary,
+			"content":   row.Content,
+			"cover_url": row.CoverURL,
+			"video_url": row.VideoURL,
+		}).Error; err != nil {
+			return err
+		}
+		saved, err = recordRevision(tx, row, editorID, action)
+		return err
+	})
+	if err != nil {
+		return revision.Revision{}, err
+	}
+	return revisionFromRow(saved), nil
+}
+
+func (r *ArticleRevisionRepository) List(ctx context.Context, articleID int64) ([]revision.Revision, error) {
+	var rows []model.ArticleRevision
+	if err := r.db.WithContext(ctx).
+		Where("article_id = ?", articleID).
+		Order("id DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]revision.Revision, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, revisionFromRow(row))
+	}
+	return items, nil
+}
+
+// recordRevision snapshots an article row after a change.
+func recordRevision(tx *gorm.DB, row model.Article, editorID int64, action string) (model.ArticleRevision, error) {
+	rev := model.ArticleRevision{
+		ArticleID: row.ID,
+		EditorID:  ptrInt64(editorID),
+		Action:    action,
+		Title:     row.Title,
+		Summary:   row.Summary,
+		Content:   row.Content,
+		CoverURL:  row.CoverURL,
+		VideoURL:  row.VideoURL,
+		Status:    row.Status,
+	}
+	err := tx.Create(&rev).Error
+	return rev, err
+}
+
+// ensureBaselineRevision records the current state of an article that has no history yet, so
+// articles published before revisions existed can still be rolled back to their original text.
+func ensureBaselineRevision(tx *gorm.DB, row model.Article) error {
+	var count int64
+	if err := tx.Model(&model.ArticleRevision{}).Where("article_id = ?", row.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	_, err := recordRevision(tx, row, 0, revision.ActionOriginal)
+	return err
+}
+
+// setArticleStatus changes an article's status and records the change as a revision.
+func setArticleStatus(tx *gorm.DB, row model.Article, status string, editorID int64, action string) error {
+	if err := ensureBaselineRevision(tx, row); err != nil {
+		return err
+	}
+	if err := tx.Model(&model.Article{}).Where("id = ?", row.ID).Updates(map[string]any{
+		"status":     status,
+		"publish_at": nil,
+	}).Error; err != nil {
+		return err
+	}
+	row.Status = status
+	_, err := recordRevision(tx, row, editorID, action)
+	return err
+}
+
+func snapshotFromArticle(row model.Article) revision.Snapshot {
+	return revision.Snapshot{
+		Title:    row.Title,
+		Summary:  ptrStringValue(row.Summary),
+		Content:  row.Content,
+		CoverURL: ptrStringValue(row.CoverURL),
+		VideoURL: ptrStringValue(row.VideoURL),
+		Status:   row.Status,
+	}
+}
+
+func revisionFromRow(row model.ArticleRevision) revision.Revision {
+	return revision.Revision{
+		ID:        row.ID,
+		ArticleID: row.ArticleID,
+		EditorID:  ptrInt64Value(row.EditorID),
+		Action:    row.Action,
+		Snapshot: revision.Snapshot{
+			Title:    row.Title,
+			Summary:  ptrStringValue(row.Summary),
+			Content:  row.Content,
+			CoverURL: ptrStringValue(row.CoverURL),
+			VideoURL: ptrStringValue(row.VideoURL),
+			Status:   row.Status,
+		},
+		CreatedAt: row.CreatedAt,
+	}
+}
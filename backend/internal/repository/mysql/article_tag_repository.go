@@ -0,0 +1,118 @@
+package mysqlrepo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
+)
+
+// SetPendingTags replaces the tags of a pending article.
+func (r *ArticleRepository) SetPendingTags(ctx context.Context, pendingID int64, slugs []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tagIDs, err := tagIDsBySlug(tx, slugs)
+		if err != nil {
+			return err
+		}
+		if len(tagIDs) != len(slugs) {
+			return review.ErrUnknownTag
+		}
+		if err := tx.Where("pending_article_id = ?", pendingID).Delete(&model.ArticleTag{}).Error; err != nil {
+			return err
+		}
+		return insertPendingTags(tx, pendingID, slugs, tagIDs)
+	})
+}
+
+// savePendingTags stores the tags suggested at ingest. Slugs without a tag are skipped, since
+// a tag may be removed between suggestion and saving.
+func savePendingTags(tx *gorm.DB, pendingID int64, slugs []string) error {
+	if len(slugs) == 0 {
+		return nil
+	}
+	tagIDs, err := tagIDsBySlug(tx, slugs)
+	if err != nil {
+		return err
+	}
+	return insertPendingTags(tx, pendingID, slugs, tagIDs)
+}
+
+func insertPendingTags(tx *gorm.DB, pendingID int64, slugs []string, tagIDs map[string]int64) error {
+	rows := make([]model.ArticleTag, 0, len(slugs))
+	for _, slug := range slugs {
+		if tagID, ok := tagIDs[slug]; ok {
+			rows = append(rows, model.ArticleTag{TagID: tagID, PendingArticleID: &pendingID})
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+// publishTags files the new article under the pending item's tags.
+func publishTags(tx *gorm.DB, pendingID int64, articleID int64) error {
+	return tx.Model(&model.ArticleTag{}).
+		Where("pending_article_id = ?", pendingID).
+		Update("article_id", articleID).Error
+}
+
+func tagIDsBySlug(db *gorm.DB, slugs []string) (map[string]int64, error) {
+	out := make(map[string]int64, len(slugs))
+	if len(slugs) == 0 {
+		return out, nil
+	}
+	var rows []model.Tag
+	if err := db.Select("id", "slug").Where("slug IN ?", slugs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.Slug] = row.ID
+	}
+	return out, nil
+}
+
+// taggedArticleIDs selects the published articles filed under a tag.
+func taggedArticleIDs(db *gorm.DB, slug string) *gorm.DB {
+	return db.Model(&model.ArticleTag{}).
+		Select("article_tags.article_id").
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("tags.slug = ?", slug).
+		Where("article_tags.article_id IS NOT NULL")
+}
+
+func (r *ArticleRepository) loadPendingTags(ctx context.Context, pendingIDs []int64) (map[int64][]string, error) {
+	out := make(map[int64][]string, len(pendingIDs))
+	if len(pendingIDs) == 0 {
+		return out, nil
+	}
+	var rows []struct {
+		PendingArticleID int64
+		Slug             string
+	}
+	if err := r.db.WithContext(ctx).Model(&model.ArticleTag{}).
+		Select("article_tags.pending_article_id, tags.slug").
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("article_tags.pending_article_id IN ?", pendingIDs).
+		Order("article_tags.id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.PendingArticleID] = append(out[row.PendingArticleID], row.Slug)
+	}
+	return out, nil
+}
+
+func (r *ArticleRepository) loadArticleTags(ctx context.Context, articleID int64) ([]string, error) {
+	var slugs []string
+	err := r.db.WithContext(ctx).Model(&model.ArticleTag{}).
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("article_tags.article_id = ?", articleID).
+		Order("article_tags.id ASC").
+		Pluck("tags.slug", &slugs).Error
+	return slugs, err
+}
@@ -2,22 +2,29 @@ package mysqlrepo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/fighter"
 	"github.com/bajiaozhi/w-mma/backend/internal/model"
 )
 
+var noContestResultPattern = regexp.MustCompile(`(?i)\bno[\s-]*contest\b|\bnc\b`)
+
 type EventRepository struct {
 	db *gorm.DB
 }
 
 type UFCLiveTrackableEvent struct {
 	ID          int64
+	Org         string
 	Status      string
 	StartsAt    time.Time
 	ExternalURL string
@@ -33,6 +40,9 @@ type UFCLiveBoutSnapshot struct {
 	Round         int
 	TimeSec       int
 	Result        string
+	LiveState     string
+	CurrentRound  int
+	ResultLocked  bool
 }
 
 func NewEventRepository(db *gorm.DB) *EventRepository {
@@ -77,6 +87,11 @@ func (r *EventRepository) GetEventCard(ctx context.Context, eventID int64) (even
 		}
 	}
 
+	historyByBout, err := r.listResultHistory(ctx, eventID)
+	if err != nil {
+		return event.Card{}, err
+	}
+
 	for _, b := range bouts {
 		winnerID := int64(0)
 		if b.WinnerFighterID != nil {
@@ -98,6 +113,11 @@ func (r *EventRepository) GetEventCard(ctx context.Context, eventID int64) (even
 		if b.TimeSec != nil {
 			timeSec = *b.TimeSec
 		}
+		liveState := boutLiveState(b)
+		currentRound := 0
+		if liveState == event.BoutStateInProgress && b.CurrentRound != nil {
+			currentRound = *b.CurrentRound
+		}
 		card.Bouts = append(card.Bouts, event.Bout{
 			ID:            b.ID,
 			RedFighterID:  b.RedFighterID,
@@ -111,6 +131,8 @@ func (r *EventRepository) GetEventCard(ctx context.Context, eventID int64) (even
 			Method:        method,
 			Round:         round,
 			TimeSec:       timeSec,
+			LiveState:     liveState,
+			CurrentRound:  currentRound,
 		})
 
 		redProfile := fighterByID[b.RedFighterID]
@@ -120,14 +142,17 @@ func (r *EventRepository) GetEventCard(ctx context.Context, eventID int64) (even
 			weightClass = chooseNonEmpty(ptrStringValue(redProfile.WeightClass), ptrStringValue(blueProfile.WeightClass))
 		}
 		detail := event.BoutDetail{
-			ID:          b.ID,
-			CardSegment: ptrStringValue(b.CardSegment),
-			WeightClass: weightClass,
-			Result:      result,
-			WinnerID:    winnerID,
-			Method:      method,
-			Round:       round,
-			TimeSec:     timeSec,
+			ID:            b.ID,
+			CardSegment:   ptrStringValue(b.CardSegment),
+			WeightClass:   weightClass,
+			Result:        result,
+			WinnerID:      winnerID,
+			Method:        method,
+			Round:         round,
+			TimeSec:       timeSec,
+			LiveState:     liveState,
+			CurrentRound:  currentRound,
+			ResultHistory: historyByBout[b.ID],
 			RedFighter: event.FighterSnapshot{
 				ID:          b.RedFighterID,
 				Name:        redProfile.Name,
@@ -236,9 +261,13 @@ func (r *EventRepository) UpsertBoutResult(ctx context.Context, eventID int64, b
 	return nil
 }
 
-func (r *EventRepository) ListUFCLiveTrackableEvents(ctx context.Context) ([]UFCLiveTrackableEvent, error) {
+func (r *EventRepository) ListLiveTrackableEvents(ctx context.Context, orgs []string) ([]UFCLiveTrackableEvent, error) {
+	if len(orgs) == 0 {
+		return []UFCLiveTrackableEvent{}, nil
+	}
 	type row struct {
 		ID          int64
+		Org         string
 		Status      string
 		StartsAt    time.Time
 		ExternalURL string
@@ -246,8 +275,8 @@ func (r *EventRepository) ListUFCLiveTrackableEvents(ctx context.Context) ([]UFC
 	rows := make([]row, 0)
 	if err := r.db.WithContext(ctx).
 		Table("events").
-		Select("id, status, starts_at, external_url").
-		Where("org = ?", "UFC").
+		Select("id, org, status, starts_at, external_url").
+		Where("org IN ?", orgs).
 		Where("external_url IS NOT NULL AND external_url <> ''").
 		Where("status IN ?", []string{"scheduled", "live"}).
 		Order("starts_at ASC").
@@ -259,6 +288,7 @@ func (r *EventRepository) ListUFCLiveTrackableEvents(ctx context.Context) ([]UFC
 	for _, item := range rows {
 		items = append(items, UFCLiveTrackableEvent{
 			ID:          item.ID,
+			Org:         item.Org,
 			Status:      item.Status,
 			StartsAt:    item.StartsAt.UTC(),
 			ExternalURL: item.ExternalURL,
@@ -304,6 +334,9 @@ func (r *EventRepository) ListUFCLiveBoutSnapshots(ctx context.Context, eventID
 			Round:         round,
 			TimeSec:       timeSec,
 			Result:        result,
+			LiveState:     ptrStringValue(row.LiveState),
+			CurrentRound:  ptrIntValue(row.CurrentRound),
+			ResultLocked:  row.ResultLocked,
 		})
 	}
 	return items, nil
@@ -314,17 +347,230 @@ func (r *EventRepository) UpdateEventStatus(ctx context.Context, eventID int64,
 }
 
 func (r *EventRepository) UpsertUFCLiveBoutResult(ctx context.Context, eventID int64, boutID int64, winnerID int64, method string, round int, timeSec int, result string) error {
-	updates := map[string]any{
-		"winner_fighter_id": int64OrNil(winnerID),
+	err := r.RecordBoutResult(ctx, eventID, boutID, event.BoutResultInput{
+		WinnerID: winnerID,
+		Method:   method,
+		Round:    round,
+		TimeSec:  timeSec,
+		Result:   result,
+	}, event.ResultSourceScraper)
+	if errors.Is(err, event.ErrBoutNotFound) {
+		return fmt.Errorf("bout not found for event=%d bout=%d", eventID, boutID)
+	}
+	return err
+}
+
+// RecordBoutResult writes a bout result, appends the change to bout_result_history and
+// moves the outcome in both fighters' records. Unchanged results are a no-op, and so are
+// scraper results for a bout an editor locked, checked under the row lock.
+func (r *EventRepository) RecordBoutResult(ctx context.Context, eventID int64, boutID int64, input event.BoutResultInput, source string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bout, err := lockBout(tx, eventID, boutID)
+		if err != nil {
+			return err
+		}
+		if source == event.ResultSourceScraper && bout.ResultLocked {
+			return nil
+		}
+		return writeBoutResult(tx, bout, input, source, true)
+	})
+}
+
+// RecordManualBoutResult writes an editor's result and locks the bout against scraper writes.
+func (r *EventRepository) RecordManualBoutResult(ctx context.Context, eventID int64, boutID int64, input event.BoutResultInput, editorID int64) error {
+	return r.recordLockedBoutResult(ctx, eventID, boutID, input, event.ResultSourceManual, editorID)
+}
+
+// RecordCorrectedBoutResult writes an editor's correction and locks the bout like manual scoring.
+func (r *EventRepository) RecordCorrectedBoutResult(ctx context.Context, eventID int64, boutID int64, input event.BoutResultInput, editorID int64) error {
+	return r.recordLockedBoutResult(ctx, eventID, boutID, input, event.ResultSourceCorrection, editorID)
+}
+
+func (r *EventRepository) recordLockedBoutResult(ctx context.Context, eventID int64, boutID int64, input event.BoutResultInput, source string, editorID int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		bout, err := lockBout(tx, eventID, boutID)
+		if err != nil {
+			return err
+		}
+		if err := writeBoutResult(tx, bout, input, source, true); err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		return tx.Model(&model.Bout{}).Where("id = ?", boutID).Updates(map[string]any{
+			"result_locked":    true,
+			"result_locked_by": int64OrNil(editorID),
+			"result_locked_at": &now,
+			"live_state":       event.BoutStateFinished,
+			"current_round":    nil,
+		}).Error
+	})
+}
+
+// ReleaseBoutResult hands a manually scored bout back to the scraper.
+func (r *EventRepository) ReleaseBoutResult(ctx context.Context, eventID int64, boutID int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockBout(tx, eventID, boutID); err != nil {
+			return err
+		}
+		return tx.Model(&model.Bout{}).Where("id = ?", boutID).Updates(map[string]any{
+			"result_locked":    false,
+			"result_locked_by": nil,
+			"result_locked_at": nil,
+		}).Error
+	})
+}
+
+func lockBout(tx *gorm.DB, eventID int64, boutID int64) (model.Bout, error) {
+	var bout model.Bout
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ?", eventID).
+		Where("id = ?", boutID).
+		Take(&bout).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Bout{}, event.ErrBoutNotFound
+		}
+		return model.Bout{}, err
+	}
+	return bout, nil
+}
+
+// writeBoutResult records a result change in history and on the bout. With moveRecords the
+// outcome is also moved in both fighters' records; the schedule sync passes false, since
+// the profiles it just stored already count the fight.
+func writeBoutResult(tx *gorm.DB, bout model.Bout, input event.BoutResultInput, source string, moveRecords bool) error {
+	if input.WinnerID != 0 && input.WinnerID != bout.RedFighterID && input.WinnerID != bout.BlueFighterID {
+		return event.ErrInvalidWinner
+	}
+	method := strings.TrimSpace(input.Method)
+	result := strings.TrimSpace(input.Result)
+	prevWinnerID := ptrInt64Value(bout.WinnerFighterID)
+	prevMethod := strings.TrimSpace(ptrStringValue(bout.Method))
+	prevResult := strings.TrimSpace(ptrStringValue(bout.Result))
+	if prevWinnerID == input.WinnerID &&
+		prevMethod == method &&
+		ptrIntValue(bout.Round) == input.Round &&
+		ptrIntValue(bout.TimeSec) == input.TimeSec &&
+		prevResult == result {
+		return nil
+	}
+
+	history := model.BoutResultHistory{
+		EventID:                 bout.EventID,
+		BoutID:                  bout.ID,
+		SequenceNo:              bout.SequenceNo,
+		WinnerFighterID:         int64OrNil(input.WinnerID),
+		Method:                  stringOrNil(method),
+		Round:                   intOrNil(input.Round),
+		TimeSec:                 intOrNil(input.TimeSec),
+		Result:                  stringOrNil(result),
+		PreviousWinnerFighterID: int64OrNil(prevWinnerID),
+		PreviousResult:          stringOrNil(prevResult),
+		Source:                  source,
+		Note:                    stringOrNil(input.Note),
+		CreatedAt:               time.Now().UTC(),
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(&model.Bout{}).Where("id = ?", bout.ID).Updates(map[string]any{
+		"winner_fighter_id": int64OrNil(input.WinnerID),
 		"method":            stringOrNil(method),
-		"round":             intOrNil(round),
-		"time_sec":          intOrNil(timeSec),
+		"round":             intOrNil(input.Round),
+		"time_sec":          intOrNil(input.TimeSec),
 		"result":            stringOrNil(result),
+	}).Error; err != nil {
+		return err
+	}
+
+	if !moveRecords {
+		return nil
+	}
+	for _, fighterID := range []int64{bout.RedFighterID, bout.BlueFighterID} {
+		before := boutOutcomeFor(fighterID, prevWinnerID, prevMethod, prevResult)
+		after := boutOutcomeFor(fighterID, input.WinnerID, method, result)
+		if before == after {
+			continue
+		}
+		if err := adjustFighterRecord(tx, fighterID, before, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *EventRepository) listResultHistory(ctx context.Context, eventID int64) (map[int64][]event.BoutResultChange, error) {
+	var rows []model.BoutResultHistory
+	if err := r.db.WithContext(ctx).
+		Where("event_id = ?", eventID).
+		Order("bout_id ASC, id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make(map[int64][]event.BoutResultChange, len(rows))
+	for _, row := range rows {
+		items[row.BoutID] = append(items[row.BoutID], event.BoutResultChange{
+			ID:               row.ID,
+			WinnerID:         ptrInt64Value(row.WinnerFighterID),
+			Method:           ptrStringValue(row.Method),
+			Round:            ptrIntValue(row.Round),
+			TimeSec:          ptrIntValue(row.TimeSec),
+			Result:           ptrStringValue(row.Result),
+			PreviousWinnerID: ptrInt64Value(row.PreviousWinnerFighterID),
+			PreviousResult:   ptrStringValue(row.PreviousResult),
+			Source:           row.Source,
+			Note:             ptrStringValue(row.Note),
+			CreatedAt:        row.CreatedAt.UTC(),
+		})
+	}
+	return items, nil
+}
+
+// boutOutcomeFor maps a stored result to one fighter's record outcome; empty means no decided outcome yet.
+func boutOutcomeFor(fighterID int64, winnerID int64, method string, result string) string {
+	if winnerID > 0 {
+		if winnerID == fighterID {
+			return fighter.OutcomeWin
+		}
+		return fighter.OutcomeLoss
+	}
+	text := method + " " + result
+	if noContestResultPattern.MatchString(text) {
+		return fighter.OutcomeNoContest
+	}
+	if strings.Contains(strings.ToLower(text), "draw") {
+		return fighter.OutcomeDraw
 	}
+	return ""
+}
+
+func adjustFighterRecord(tx *gorm.DB, fighterID int64, removed string, added string) error {
+	var row model.Fighter
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "record").
+		Where("id = ?", fighterID).
+		Take(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	current := ptrStringValue(row.Record)
+	next := fighter.AdjustRecord(current, removed, added)
+	if next == current {
+		return nil
+	}
+	return tx.Model(&model.Fighter{}).Where("id = ?", fighterID).Update("record", next).Error
+}
+
+func (r *EventRepository) UpdateBoutLiveState(ctx context.Context, eventID int64, boutID int64, state string, currentRound int) error {
 	ret := r.db.WithContext(ctx).Model(&model.Bout{}).
 		Where("event_id = ?", eventID).
 		Where("id = ?", boutID).
-		Updates(updates)
+		Updates(map[string]any{
+			"live_state":    stringOrNil(state),
+			"current_round": intOrNil(currentRound),
+		})
 	if ret.Error != nil {
 		return ret.Error
 	}
@@ -334,6 +580,24 @@ func (r *EventRepository) UpsertUFCLiveBoutResult(ctx context.Context, eventID i
 	return nil
 }
 
+// boutLiveState falls back to the stored result when no live state was recorded.
+func boutLiveState(b model.Bout) string {
+	if state := strings.TrimSpace(ptrStringValue(b.LiveState)); state != "" {
+		return state
+	}
+	if b.WinnerFighterID != nil || strings.TrimSpace(ptrStringValue(b.Method)) != "" {
+		return event.BoutStateFinished
+	}
+	return event.BoutStateUpcoming
+}
+
+func ptrIntValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
+
 func stringOrNil(value string) *string {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
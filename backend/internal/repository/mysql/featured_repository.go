@@ -0,0 +1,104 @@
+package mysqlrepo
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/featured"
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+)
+
+type FeaturedSlotRepository struct {
+	db *gorm.DB
+}
+
+func NewFeaturedSlotRepository(db *gorm.DB) *FeaturedSlotRepository {
+	return &FeaturedSlotRepository{db: db}
+}
+
+func (r *FeaturedSlotRepository) List(ctx context.Context) ([]featured.Slot, error) {
+	var rows []model.FeaturedSlot
+	if err := r.db.WithContext(ctx).Order("kind ASC, position ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	items := make([]featured.Slot, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, featuredSlotFromRow(row))
+	}
+	return items, nil
+}
+
+func (r *FeaturedSlotRepository) Create(ctx context.Context, slot featured.Slot) (featured.Slot, error) {
+	row := featuredSlotRow(slot)
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return featured.Slot{}, err
+	}
+	return featuredSlotFromRow(row), nil
+}
+
+func (r *FeaturedSlotRepository) Update(ctx context.Context, slot featured.Slot) (featured.Slot, error) {
+	var existing model.FeaturedSlot
+	err := r.db.WithContext(ctx).Where("id = ?", slot.ID).Take(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return featured.Slot{}, featured.ErrSlotNotFound
+	}
+	if err != nil {
+		return featured.Slot{}, err
+	}
+
+	row := featuredSlotRow(slot)
+	row.ID = existing.ID
+	row.CreatedAt = existing.CreatedAt
+	if err := r.db.WithContext(ctx).Save(&row).Error; err != nil {
+		return featured.Slot{}, err
+	}
+	return featuredSlotFromRow(row), nil
+}
+
+func (r *FeaturedSlotRepository) Delete(ctx context.Context, slotID int64) error {
+	res := r.db.WithContext(ctx).Where("id = ?", slotID).Delete(&model.FeaturedSlot{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return featured.ErrSlotNotFound
+	}
+	return nil
+}
+
+func featuredSlotRow(slot featured.Slot) model.FeaturedSlot {
+	return model.FeaturedSlot{
+		ID:         slot.ID,
+		Kind:       slot.Kind,
+		TargetType: slot.TargetType,
+		TargetID:   slot.TargetID,
+		Title:      stringOrNil(slot.Title),
+		ImageURL:   stringOrNil(slot.ImageURL),
+		Position:   slot.Position,
+		StartsAt:   slot.StartsAt,
+		EndsAt:     slot.EndsAt,
+	}
+}
+
+func featuredSlotFromRow(row model.FeaturedSlot) featured.Slot {
+	slot := featured.Slot{
+		ID:         row.ID,
+		Kind:       row.Kind,
+		TargetType: row.TargetType,
+		TargetID:   row.TargetID,
+		Title:      ptrStringValue(row.Title),
+		ImageURL:   ptrStringValue(row.ImageURL),
+		Position:   row.Position,
+	}
+	if row.StartsAt != nil {
+		startsAt := row.StartsAt.UTC()
+		slot.StartsAt = &startsAt
+	}
+	if row.EndsAt != nil {
+		endsAt := row.EndsAt.UTC()
+		slot.EndsAt = &endsAt
+	}
+	return slot
+}
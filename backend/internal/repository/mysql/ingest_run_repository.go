@@ -0,0 +1,151 @@
+package mysqlrepo
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/ingest"
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+)
+
+// lastFetchErrorLimit matches data_sources.last_fetch_error VARCHAR(1024).
+const lastFetchErrorLimit = 1024
+
+type IngestRunRepository struct {
+	db *gorm.DB
+}
+
+func NewIngestRunRepository(db *gorm.DB) *IngestRunRepository {
+	return &IngestRunRepository{db: db}
+}
+
+func (r *IngestRunRepository) ListDueSources(ctx context.Context, now time.Time) ([]ingest.DueSource, error) {
+	var rows []model.DataSource
+	if err := r.db.WithContext(ctx).
+		Where("deleted_at IS NULL").
+		Where("enabled = ?", true).
+		Where("source_type = ?", "news").
+		Where("next_fetch_at IS NULL OR next_fetch_at <= ?", now).
+		Order("next_fetch_at ASC").
+		Order("id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]ingest.DueSource, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ingest.DueSource{
+			ID:             row.ID,
+			URL:            row.SourceURL,
+			ParserKind:     row.ParserKind,
+			FetchInterval:  row.FetchInterval,
+			FetchFailCount: row.FetchFailCount,
+		})
+	}
+	return items, nil
+}
+
+func (r *IngestRunRepository) StartRun(ctx context.Context, src ingest.DueSource, staleBefore time.Time) (int64, bool, error) {
+	var runID int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the source row so two schedulers cannot both queue a run for it.
+		var row model.DataSource
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", src.ID).
+			Take(&row).Error; err != nil {
+			return err
+		}
+
+		abandoned := "run abandoned: no result recorded"
+		if err := tx.Model(&model.IngestRun{}).
+			Where("source_id = ?", src.ID).
+			Where("status IN ?", []string{ingest.RunStatusQueued, ingest.RunStatusRunning}).
+			Where("created_at < ?", staleBefore).
+			Updates(map[string]any{
+				"status":      ingest.RunStatusFailed,
+				"error_msg":   abandoned,
+				"finished_at": time.Now(),
+			}).Error; err != nil {
+			return err
+		}
+
+		var inFlight int64
+		if err := tx.Model(&model.IngestRun{}).
+			Where("source_id = ?", src.ID).
+			Where("status IN ?", []string{ingest.RunStatusQueued, ingest.RunStatusRunning}).
+			Count(&inFlight).Error; err != nil {
+			return err
+		}
+		if inFlight > 0 {
+			return nil
+		}
+
+		run := model.IngestRun{
+			SourceID:   src.ID,
+			SourceURL:  src.URL,
+			ParserKind: src.ParserKind,
+			Status:     ingest.RunStatusQueued,
+		}
+		if err := tx.Create(&run).Error; err != nil {
+			return err
+		}
+		runID = run.ID
+		return nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return runID, runID > 0, nil
+}
+
+func (r *IngestRunRepository) MarkRunRunning(ctx context.Context, runID int64) error {
+	return r.db.WithContext(ctx).
+		Model(&model.IngestRun{}).
+		Where("id = ?", runID).
+		Updates(map[string]any{
+			"status":     ingest.RunStatusRunning,
+			"started_at": time.Now(),
+		}).Error
+}
+
+func (r *IngestRunRepository) FinishRun(ctx context.Context, outcome ingest.RunOutcome) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.IngestRun{}).
+			Where("id = ?", outcome.RunID).
+			Updates(map[string]any{
+				"status":        outcome.Status,
+				"fetched_count": outcome.FetchedCount,
+				"error_msg":     stringOrNil(outcome.Error),
+				"finished_at":   outcome.FinishedAt,
+			}).Error; err != nil {
+			return err
+		}
+		if outcome.Error != "" {
+			if err := tx.Create(&model.IngestRunError{RunID: outcome.RunID, ErrorMsg: outcome.Error}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&model.DataSource{}).
+			Where("id = ?", outcome.SourceID).
+			Updates(map[string]any{
+				"last_fetch_at":     outcome.FinishedAt,
+				"last_fetch_status": outcome.Status,
+				"last_fetch_error":  stringOrNil(truncateRunes(outcome.Error, lastFetchErrorLimit)),
+				"fetch_fail_count":  outcome.FailCount,
+				"next_fetch_at":     outcome.NextFetchAt,
+			}).Error
+	})
+}
+
+func truncateRunes(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	return string([]rune(value)[:limit])
+}
@@ -0,0 +1,146 @@
+package mysqlrepo
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/search"
+)
+
+// ngramTokenSize matches the server's default ngram_token_size; shorter terms can only be
+// found as prefixes.
+const ngramTokenSize = 2
+
+// SearchRepository serves search from the ngram FULLTEXT indexes of migration 0027.
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+func (r *SearchRepository) Search(ctx context.Context, q search.Query) ([]search.Hit, error) {
+	against := booleanAgainst(q.Terms)
+	limit := q.Limit
+	if limit <= 0 {
+		limit = search.DefaultLimit
+	}
+
+	hits := make([]search.Hit, 0)
+	if q.Wants(search.TypeFighter) {
+		var rows []struct {
+			ID          int64
+			Name        string
+			NameZH      *string `gorm:"column:name_zh"`
+			Nickname    *string
+			WeightClass *string
+			Score       float64
+		}
+		if err := r.db.WithContext(ctx).Table("fighters").
+			Select("id, name, name_zh, nickname, weight_class, MATCH(name, name_zh, nickname) AGAINST (? IN BOOLEAN MODE) AS score", against).
+			Where("MATCH(name, name_zh, nickname) AGAINST (? IN BOOLEAN MODE)", against).
+			Order("score DESC, id DESC").
+			Limit(limit).
+			Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			hits = append(hits, search.Hit{
+				Type:     search.TypeFighter,
+				ID:       row.ID,
+				Title:    row.Name,
+				Subtitle: ptrStringValue(row.NameZH),
+				Body:     joinNonEmpty(" · ", ptrStringValue(row.Nickname), ptrStringValue(row.WeightClass)),
+				Score:    row.Score,
+			})
+		}
+	}
+
+	if q.Wants(search.TypeEvent) {
+		var rows []struct {
+			ID       int64
+			Org      string
+			Name     string
+			Venue    string
+			StartsAt time.Time
+			Score    float64
+		}
+		if err := r.db.WithContext(ctx).Table("events").
+			Select("id, org, name, venue, starts_at, MATCH(name, venue) AGAINST (? IN BOOLEAN MODE) AS score", against).
+			Where("MATCH(name, venue) AGAINST (? IN BOOLEAN MODE)", against).
+			Order("score DESC, starts_at DESC").
+			Limit(limit).
+			Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			hits = append(hits, search.Hit{
+				Type:     search.TypeEvent,
+				ID:       row.ID,
+				Title:    row.Name,
+				Subtitle: row.Org + " · " + row.StartsAt.UTC().Format("2006-01-02"),
+				Body:     row.Venue,
+				Score:    row.Score,
+			})
+		}
+	}
+
+	if q.Wants(search.TypeArticle) {
+		var rows []struct {
+			ID          int64
+			Title       string
+			Content     string
+			PublishedAt time.Time
+			Score       float64
+		}
+		if err := r.db.WithContext(ctx).Table("articles").
+			Select("id, title, content, published_at, MATCH(title, content) AGAINST (? IN BOOLEAN MODE) AS score", against).
+			Where("status = ?", "published").
+			Where("MATCH(title, content) AGAINST (? IN BOOLEAN MODE)", against).
+			Order("score DESC, published_at DESC").
+			Limit(limit).
+			Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			hits = append(hits, search.Hit{
+				Type:     search.TypeArticle,
+				ID:       row.ID,
+				Title:    row.Title,
+				Subtitle: row.PublishedAt.UTC().Format("2006-01-02"),
+				Body:     row.Content,
+				Score:    row.Score,
+			})
+		}
+	}
+	return hits, nil
+}
+
+// booleanAgainst requires every term: as a phrase, which the ngram parser turns into
+// consecutive tokens, or as a prefix when the term is shorter than one token.
+func booleanAgainst(terms []string) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if utf8.RuneCountInString(term) < ngramTokenSize {
+			parts = append(parts, "+"+term+"*")
+			continue
+		}
+		parts = append(parts, `+"`+term+`"`)
+	}
+	return strings.Join(parts, " ")
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	kept := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return strings.Join(kept, sep)
+}
@@ -21,16 +21,22 @@ func NewSourceRepository(db *gorm.DB) *SourceRepository {
 
 func (r *SourceRepository) Create(ctx context.Context, input source.CreateInput) (source.DataSource, error) {
 	row := model.DataSource{
-		Name:            input.Name,
-		SourceType:      input.SourceType,
-		Platform:        input.Platform,
-		SourceURL:       input.SourceURL,
-		ParserKind:      input.ParserKind,
-		Enabled:         input.Enabled,
-		IsBuiltin:       input.IsBuiltin,
-		RightsDisplay:   input.RightsDisplay,
-		RightsPlayback:  input.RightsPlayback,
-		RightsAISummary: input.RightsAISummary,
+		Name:              input.Name,
+		SourceType:        input.SourceType,
+		Platform:          input.Platform,
+		SourceURL:         input.SourceURL,
+		ParserKind:        input.ParserKind,
+		Enabled:           input.Enabled,
+		IsBuiltin:         input.IsBuiltin,
+		RightsDisplay:     input.RightsDisplay,
+		RightsPlayback:    input.RightsPlayback,
+		RightsAISummary:   input.RightsAISummary,
+		RightsTranslation: input.RightsTranslation,
+		CrawlInclude:      stringOrNil(input.CrawlInclude),
+		CrawlExclude:      stringOrNil(input.CrawlExclude),
+		CrawlMaxDepth:     input.CrawlMaxDepth,
+		CrawlMaxItems:     input.CrawlMaxItems,
+		FetchInterval:     input.FetchInterval,
 	}
 	if input.AccountID != "" {
 		row.AccountID = &input.AccountID
@@ -121,12 +127,30 @@ func (r *SourceRepository) Update(ctx context.Context, sourceID int64, input sou
 	if input.RightsAISummary != nil {
 		updates["rights_ai_summary"] = *input.RightsAISummary
 	}
+	if input.RightsTranslation != nil {
+		updates["rights_translation"] = *input.RightsTranslation
+	}
 	if input.RightsExpiresAt != nil {
 		updates["rights_expires_at"] = input.RightsExpiresAt
 	}
 	if input.RightsProofURL != nil {
 		updates["rights_proof_url"] = input.RightsProofURL
 	}
+	if input.CrawlInclude != nil {
+		updates["crawl_include_pattern"] = stringOrNil(*input.CrawlInclude)
+	}
+	if input.CrawlExclude != nil {
+		updates["crawl_exclude_pattern"] = stringOrNil(*input.CrawlExclude)
+	}
+	if input.CrawlMaxDepth != nil && *input.CrawlMaxDepth > 0 {
+		updates["crawl_max_depth"] = *input.CrawlMaxDepth
+	}
+	if input.CrawlMaxItems != nil && *input.CrawlMaxItems > 0 {
+		updates["crawl_max_items"] = *input.CrawlMaxItems
+	}
+	if input.FetchInterval != nil && *input.FetchInterval > 0 {
+		updates["fetch_interval_sec"] = *input.FetchInterval
+	}
 	if len(updates) == 0 {
 		return nil
 	}
@@ -192,20 +216,28 @@ func (r *SourceRepository) Restore(ctx context.Context, sourceID int64) error {
 
 func mapSourceRow(row model.DataSource) source.DataSource {
 	item := source.DataSource{
-		ID:              row.ID,
-		Name:            row.Name,
-		SourceType:      row.SourceType,
-		Platform:        row.Platform,
-		SourceURL:       row.SourceURL,
-		ParserKind:      row.ParserKind,
-		Enabled:         row.Enabled,
-		IsBuiltin:       row.IsBuiltin,
-		RightsDisplay:   row.RightsDisplay,
-		RightsPlayback:  row.RightsPlayback,
-		RightsAISummary: row.RightsAISummary,
-		RightsExpiresAt: row.RightsExpiresAt,
-		LastFetchAt:     row.LastFetchAt,
-		DeletedAt:       row.DeletedAt,
+		ID:                row.ID,
+		Name:              row.Name,
+		SourceType:        row.SourceType,
+		Platform:          row.Platform,
+		SourceURL:         row.SourceURL,
+		ParserKind:        row.ParserKind,
+		Enabled:           row.Enabled,
+		IsBuiltin:         row.IsBuiltin,
+		RightsDisplay:     row.RightsDisplay,
+		RightsPlayback:    row.RightsPlayback,
+		RightsAISummary:   row.RightsAISummary,
+		RightsTranslation: row.RightsTranslation,
+		RightsExpiresAt:   row.RightsExpiresAt,
+		CrawlInclude:      ptrStringValue(row.CrawlInclude),
+		CrawlExclude:      ptrStringValue(row.CrawlExclude),
+		CrawlMaxDepth:     row.CrawlMaxDepth,
+		CrawlMaxItems:     row.CrawlMaxItems,
+		FetchInterval:     row.FetchInterval,
+		FetchFailCount:    row.FetchFailCount,
+		NextFetchAt:       row.NextFetchAt,
+		LastFetchAt:       row.LastFetchAt,
+		DeletedAt:         row.DeletedAt,
 	}
 	if row.AccountID != nil {
 		item.AccountID = *row.AccountID
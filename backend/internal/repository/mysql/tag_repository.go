@@ -0,0 +1,148 @@
+package mysqlrepo
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
+)
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+func (r *TagRepository) List(ctx context.Context) ([]taxonomy.Tag, error) {
+	var rows []model.Tag
+	if err := r.db.WithContext(ctx).Order("kind ASC, slug ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	var rules []model.TagSourceRule
+	if err := r.db.WithContext(ctx).Order("source_id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	sources := make(map[int64][]int64, len(rows))
+	for _, rule := range rules {
+		sources[rule.TagID] = append(sources[rule.TagID], rule.SourceID)
+	}
+
+	items := make([]taxonomy.Tag, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, tagFromRow(row, sources[row.ID]))
+	}
+	return items, nil
+}
+
+func (r *TagRepository) Get(ctx context.Context, tagID int64) (taxonomy.Tag, error) {
+	var row model.Tag
+	err := r.db.WithContext(ctx).Where("id = ?", tagID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return taxonomy.Tag{}, taxonomy.ErrTagNotFound
+	}
+	if err != nil {
+		return taxonomy.Tag{}, err
+	}
+	var sourceIDs []int64
+	if err := r.db.WithContext(ctx).Model(&model.TagSourceRule{}).
+		Where("tag_id = ?", tagID).
+		Order("source_id ASC").
+		Pluck("source_id", &sourceIDs).Error; err != nil {
+		return taxonomy.Tag{}, err
+	}
+	return tagFromRow(row, sourceIDs), nil
+}
+
+func (r *TagRepository) Create(ctx context.Context, tag taxonomy.Tag) (taxonomy.Tag, error) {
+	row := model.Tag{
+		Slug:     tag.Slug,
+		Name:     tag.Name,
+		Kind:     tag.Kind,
+		Keywords: strings.Join(tag.Keywords, ","),
+	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&model.Tag{}).Where("slug = ?", tag.Slug).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return taxonomy.ErrDuplicateTag
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		return saveTagSourceRules(tx, row.ID, tag.SourceIDs)
+	})
+	if err != nil {
+		return taxonomy.Tag{}, err
+	}
+	tag.ID = row.ID
+	return tag, nil
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag taxonomy.Tag) (taxonomy.Tag, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Tag{}).Where("id = ?", tag.ID).Updates(map[string]any{
+			"name":     tag.Name,
+			"kind":     tag.Kind,
+			"keywords": strings.Join(tag.Keywords, ","),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var count int64
+			if err := tx.Model(&model.Tag{}).Where("id = ?", tag.ID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return taxonomy.ErrTagNotFound
+			}
+		}
+		if err := tx.Where("tag_id = ?", tag.ID).Delete(&model.TagSourceRule{}).Error; err != nil {
+			return err
+		}
+		return saveTagSourceRules(tx, tag.ID, tag.SourceIDs)
+	})
+	if err != nil {
+		return taxonomy.Tag{}, err
+	}
+	return tag, nil
+}
+
+func saveTagSourceRules(tx *gorm.DB, tagID int64, sourceIDs []int64) error {
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+	rows := make([]model.TagSourceRule, 0, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		rows = append(rows, model.TagSourceRule{TagID: tagID, SourceID: sourceID})
+	}
+	return tx.Create(&rows).Error
+}
+
+func tagFromRow(row model.Tag, sourceIDs []int64) taxonomy.Tag {
+	keywords := []string{}
+	for _, keyword := range strings.Split(row.Keywords, ",") {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	if sourceIDs == nil {
+		sourceIDs = []int64{}
+	}
+	return taxonomy.Tag{
+		ID:        row.ID,
+		Slug:      row.Slug,
+		Name:      row.Name,
+		Kind:      row.Kind,
+		Keywords:  keywords,
+		SourceIDs: sourceIDs,
+	}
+}
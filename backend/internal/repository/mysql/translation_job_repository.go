@@ -0,0 +1,186 @@
+package mysqlrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
+	"github.com/bajiaozhi/w-mma/backend/internal/translate"
+)
+
+type TranslationJobRepository struct {
+	db *gorm.DB
+}
+
+func NewTranslationJobRepository(db *gorm.DB) *TranslationJobRepository {
+	return &TranslationJobRepository{db: db}
+}
+
+func (r *TranslationJobRepository) Create(ctx context.Context, input translate.CreateInput) (translate.Job, error) {
+	row := model.TranslationJob{
+		PendingArticleID: input.PendingID,
+		SourceID:         input.SourceID,
+		Status:           input.Status,
+		Provider:         stringOrNil(input.Provider),
+		TargetLang:       input.TargetLang,
+		OriginalTitle:    truncateRunes(input.Original.Title, 255),
+		OriginalBody:     stringOrNil(input.Original.Body),
+		ErrorMsg:         stringOrNil(input.ErrorMsg),
+	}
+	if err := r.db.WithContext(ctx).Create(&row).Error; err != nil {
+		return translate.Job{}, err
+	}
+	return mapTranslationJobRow(row), nil
+}
+
+func (r *TranslationJobRepository) List(ctx context.Context, limit int) ([]translate.Job, error) {
+	var rows []model.TranslationJob
+	query := r.db.WithContext(ctx).Order("id DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]translate.Job, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, mapTranslationJobRow(row))
+	}
+	return items, nil
+}
+
+// ClaimNext locks the oldest pending job with SKIP LOCKED so several workers can share the table.
+// A job running since before staleBefore is claimed again; claiming refreshes updated_at.
+func (r *TranslationJobRepository) ClaimNext(ctx context.Context, staleBefore time.Time) (translate.Job, bool, error) {
+	var row model.TranslationJob
+	found := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", translate.StatusPending, translate.StatusRunning, staleBefore).
+			Order("id ASC").
+			Take(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&model.TranslationJob{}).
+			Where("id = ?", row.ID).
+			Update("status", translate.StatusRunning).Error; err != nil {
+			return err
+		}
+		row.Status = translate.StatusRunning
+		found = true
+		return nil
+	})
+	if err != nil || !found {
+		return translate.Job{}, false, err
+	}
+	return mapTranslationJobRow(row), true, nil
+}
+
+func (r *TranslationJobRepository) Active(ctx context.Context, pendingID int64) (translate.Job, bool, error) {
+	var row model.TranslationJob
+	err := r.db.WithContext(ctx).
+		Where("pending_article_id = ? AND status IN ?", pendingID, []string{translate.StatusPending, translate.StatusRunning}).
+		Order("id DESC").
+		Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return translate.Job{}, false, nil
+	}
+	if err != nil {
+		return translate.Job{}, false, err
+	}
+	return mapTranslationJobRow(row), true, nil
+}
+
+func (r *TranslationJobRepository) Complete(ctx context.Context, jobID int64, draft translate.Text) error {
+	return r.update(ctx, jobID, map[string]any{
+		"status":    translate.StatusDone,
+		"title":     truncateRunes(draft.Title, 255),
+		"body":      draft.Body,
+		"error_msg": nil,
+	})
+}
+
+func (r *TranslationJobRepository) Fail(ctx context.Context, jobID int64, errorMsg string) error {
+	return r.update(ctx, jobID, map[string]any{
+		"status":    translate.StatusFailed,
+		"error_msg": errorMsg,
+	})
+}
+
+func (r *TranslationJobRepository) GetArticle(ctx context.Context, pendingID int64) (translate.Article, error) {
+	var row model.PendingArticle
+	err := r.db.WithContext(ctx).Where("id = ?", pendingID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return translate.Article{}, translate.ErrArticleNotFound
+	}
+	if err != nil {
+		return translate.Article{}, err
+	}
+	return translate.Article{
+		PendingID: row.ID,
+		SourceID:  ptrInt64Value(row.SourceID),
+		Text:      translate.Text{Title: row.Title, Body: ptrStringValue(row.Content)},
+	}, nil
+}
+
+func (r *TranslationJobRepository) update(ctx context.Context, jobID int64, updates map[string]any) error {
+	result := r.db.WithContext(ctx).Model(&model.TranslationJob{}).Where("id = ?", jobID).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return translate.ErrJobNotFound
+	}
+	return nil
+}
+
+// loadTranslations returns the latest translation job of each pending article.
+func (r *ArticleRepository) loadTranslations(ctx context.Context, pendingIDs []int64) (map[int64]*review.Translation, error) {
+	out := make(map[int64]*review.Translation, len(pendingIDs))
+	if len(pendingIDs) == 0 {
+		return out, nil
+	}
+	var rows []model.TranslationJob
+	if err := r.db.WithContext(ctx).
+		Where("pending_article_id IN ?", pendingIDs).
+		Order("id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.PendingArticleID] = &review.Translation{
+			JobID:  row.ID,
+			Status: row.Status,
+			Title:  ptrStringValue(row.Title),
+			Body:   ptrStringValue(row.Body),
+			Error:  ptrStringValue(row.ErrorMsg),
+		}
+	}
+	return out, nil
+}
+
+func mapTranslationJobRow(row model.TranslationJob) translate.Job {
+	return translate.Job{
+		ID:         row.ID,
+		PendingID:  row.PendingArticleID,
+		SourceID:   row.SourceID,
+		Status:     row.Status,
+		Provider:   ptrStringValue(row.Provider),
+		TargetLang: row.TargetLang,
+		Original:   translate.Text{Title: row.OriginalTitle, Body: ptrStringValue(row.OriginalBody)},
+		Draft:      translate.Text{Title: ptrStringValue(row.Title), Body: ptrStringValue(row.Body)},
+		ErrorMsg:   ptrStringValue(row.ErrorMsg),
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}
+}
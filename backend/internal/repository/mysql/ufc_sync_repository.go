@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
 	"github.com/bajiaozhi/w-mma/backend/internal/model"
 	"github.com/bajiaozhi/w-mma/backend/internal/ufc"
 )
@@ -172,35 +174,106 @@ func (r *UFCSyncRepository) updateFighter(ctx context.Context, fighterID int64,
 	return fighterID, nil
 }
 
+// ReplaceEventBouts reconciles the stored card with a scraped one. Bouts are matched by their
+// fighter pair, so ids, live state and result history survive a reordered card, and result
+// changes go through writeBoutResult to keep their history. Fighter records are left alone:
+// the sync stores each fighter's profile record first, and that record is the source of
+// truth, so live and manual adjustments only bridge the time until the next sync. Manually
+// scored bouts keep the operator's result, and a scraped bout without a result never clears
+// a stored one.
 func (r *UFCSyncRepository) ReplaceEventBouts(ctx context.Context, eventID int64, bouts []ufc.BoutRecord) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Where("event_id = ?", eventID).Delete(&model.Bout{}).Error; err != nil {
+		var existing []model.Bout
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("event_id = ?", eventID).Find(&existing).Error; err != nil {
 			return err
 		}
+		byPair := make(map[[2]int64]model.Bout, len(existing))
+		for _, row := range existing {
+			byPair[boutPair(row.RedFighterID, row.BlueFighterID)] = row
+		}
+
+		// Park the current sequence numbers so the card can be renumbered in place without
+		// tripping uk_bouts_event_sequence.
+		if len(existing) > 0 {
+			if err := tx.Model(&model.Bout{}).Where("event_id = ?", eventID).
+				UpdateColumn("sequence_no", gorm.Expr("-sequence_no")).Error; err != nil {
+				return err
+			}
+		}
+
 		for idx, bout := range bouts {
-			row := model.Bout{
-				EventID:         eventID,
-				RedFighterID:    bout.RedFighterID,
-				BlueFighterID:   bout.BlueFighterID,
-				SequenceNo:      idx + 1,
-				CardSegment:     ptrString(bout.CardSegment),
-				WeightClass:     ptrString(bout.WeightClass),
-				RedRanking:      ptrString(bout.RedRanking),
-				BlueRanking:     ptrString(bout.BlueRanking),
-				Result:          ptrString(bout.Result),
-				WinnerFighterID: ptrInt64OrNil(bout.WinnerID),
-				Method:          ptrString(bout.Method),
-				Round:           ptrIntOrNil(bout.Round),
-				TimeSec:         ptrIntOrNil(bout.TimeSec),
+			pair := boutPair(bout.RedFighterID, bout.BlueFighterID)
+			row, found := byPair[pair]
+			if found {
+				delete(byPair, pair)
+				row.RedFighterID = bout.RedFighterID
+				row.BlueFighterID = bout.BlueFighterID
+				row.SequenceNo = idx + 1
+				if err := tx.Model(&model.Bout{}).Where("id = ?", row.ID).Updates(map[string]any{
+					"red_fighter_id":  bout.RedFighterID,
+					"blue_fighter_id": bout.BlueFighterID,
+					"sequence_no":     row.SequenceNo,
+					"card_segment":    ptrString(bout.CardSegment),
+					"weight_class":    ptrString(bout.WeightClass),
+					"red_ranking":     ptrString(bout.RedRanking),
+					"blue_ranking":    ptrString(bout.BlueRanking),
+				}).Error; err != nil {
+					return err
+				}
+			} else {
+				row = model.Bout{
+					EventID:       eventID,
+					RedFighterID:  bout.RedFighterID,
+					BlueFighterID: bout.BlueFighterID,
+					SequenceNo:    idx + 1,
+					CardSegment:   ptrString(bout.CardSegment),
+					WeightClass:   ptrString(bout.WeightClass),
+					RedRanking:    ptrString(bout.RedRanking),
+					BlueRanking:   ptrString(bout.BlueRanking),
+				}
+				if err := tx.Create(&row).Error; err != nil {
+					return err
+				}
+			}
+
+			if row.ResultLocked || !boutRecordHasResult(bout) {
+				continue
 			}
-			if err := tx.Create(&row).Error; err != nil {
+			if err := writeBoutResult(tx, row, event.BoutResultInput{
+				WinnerID: bout.WinnerID,
+				Method:   bout.Method,
+				Round:    bout.Round,
+				TimeSec:  bout.TimeSec,
+				Result:   bout.Result,
+			}, event.ResultSourceScraper, false); err != nil {
 				return err
 			}
 		}
-		return nil
+
+		// Whatever is left was dropped from the card.
+		if len(byPair) == 0 {
+			return nil
+		}
+		dropped := make([]int64, 0, len(byPair))
+		for _, row := range byPair {
+			dropped = append(dropped, row.ID)
+		}
+		return tx.Where("id IN ?", dropped).Delete(&model.Bout{}).Error
 	})
 }
 
+// boutPair identifies a bout on a card regardless of corner.
+func boutPair(redFighterID int64, blueFighterID int64) [2]int64 {
+	if redFighterID > blueFighterID {
+		return [2]int64{blueFighterID, redFighterID}
+	}
+	return [2]int64{redFighterID, blueFighterID}
+}
+
+func boutRecordHasResult(bout ufc.BoutRecord) bool {
+	return bout.WinnerID > 0 || strings.TrimSpace(bout.Method) != "" || strings.TrimSpace(bout.Result) != ""
+}
+
 func ptrIntOrNil(value int) *int {
 	if value <= 0 {
 		return nil
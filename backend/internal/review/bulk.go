@@ -0,0 +1,121 @@
+package review
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// MaxBulkItems bounds how many articles one bulk action may touch.
+const MaxBulkItems = 500
+
+// BulkSelection picks the articles a bulk action applies to: the listed IDs, or every article
+// awaiting review that matches the source and publish-time range.
+type BulkSelection struct {
+	IDs      []int64    `json:"ids"`
+	SourceID int64      `json:"source_id"`
+	From     *time.Time `json:"from"`
+	To       *time.Time `json:"to"`
+}
+
+// BulkResult is the outcome of a bulk action on one article.
+type BulkResult struct {
+	ID    int64  `json:"id"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// BulkApprove publishes each selected article in its own transaction and refreshes the articles
+// cache once at the end.
+func (s *Service) BulkApprove(ctx context.Context, selection BulkSelection, reviewerID int64) ([]BulkResult, error) {
+	published := false
+	results, err := s.bulk(ctx, selection, func(id int64) error {
+		live, err := s.approve(ctx, id, reviewerID, 0, Schedule{})
+		published = published || live
+		return err
+	})
+	if published {
+		s.invalidateArticlesCache(ctx)
+	}
+	return results, err
+}
+
+// BulkReject rejects each selected article with the same reason.
+func (s *Service) BulkReject(ctx context.Context, selection BulkSelection, reviewerID int64, reason string) ([]BulkResult, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrRejectReasonRequired
+	}
+	return s.bulk(ctx, selection, func(id int64) error {
+		return s.transition(ctx, id, StatusRejected, reason, reviewerID, 0)
+	})
+}
+
+// BulkReassign hands each selected article to another reviewer.
+func (s *Service) BulkReassign(ctx context.Context, selection BulkSelection, reviewerID int64, assigneeID int64) ([]BulkResult, error) {
+	if reviewerID <= 0 || assigneeID <= 0 {
+		return nil, ErrReviewerRequired
+	}
+	return s.bulk(ctx, selection, func(id int64) error {
+		return s.Reassign(ctx, id, reviewerID, assigneeID)
+	})
+}
+
+// bulk runs apply on every selected article. A failing article is reported in its result and
+// does not stop the rest.
+func (s *Service) bulk(ctx context.Context, selection BulkSelection, apply func(id int64) error) ([]BulkResult, error) {
+	ids, err := s.selectIDs(ctx, selection)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]BulkResult, 0, len(ids))
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result := BulkResult{ID: id, OK: true}
+		if err := apply(id); err != nil {
+			result.OK, result.Error = false, err.Error()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (s *Service) selectIDs(ctx context.Context, selection BulkSelection) ([]int64, error) {
+	if len(selection.IDs) > 0 {
+		if len(selection.IDs) > MaxBulkItems {
+			return nil, ErrBulkTooLarge
+		}
+		seen := make(map[int64]bool, len(selection.IDs))
+		ids := make([]int64, 0, len(selection.IDs))
+		for _, id := range selection.IDs {
+			if id > 0 && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+	if selection.SourceID <= 0 && selection.From == nil && selection.To == nil {
+		return nil, ErrEmptySelection
+	}
+
+	items, err := s.repo.ListPending(ctx, PendingFilter{
+		Status:   StatusPending,
+		SourceID: selection.SourceID,
+		From:     selection.From,
+		To:       selection.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > MaxBulkItems {
+		return nil, ErrBulkTooLarge
+	}
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,98 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type countingCache struct {
+	invalidations int
+}
+
+func (c *countingCache) InvalidateArticlesList(context.Context) error {
+	c.invalidations++
+	return nil
+}
+
+func seedBulkRepo(t *testing.T) *MemoryRepository {
+	t.Helper()
+	repo := NewMemoryRepository()
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for i, sourceID := range []int64{2, 2, 3} {
+		publishedAt := day.Add(time.Duration(i) * 24 * time.Hour)
+		if _, err := repo.CreatePending(context.Background(), PendingArticle{
+			SourceID:    sourceID,
+			Title:       "bulk item",
+			SourceURL:   "https://example.com/bulk/" + string(rune('a'+i)),
+			PublishedAt: &publishedAt,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return repo
+}
+
+func TestBulkApprove_ReportsPerItemAndInvalidatesOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := seedBulkRepo(t)
+	cache := &countingCache{}
+	svc := NewService(repo, cache)
+	if _, err := svc.Claim(ctx, 3, 8); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+
+	results, err := svc.BulkApprove(ctx, BulkSelection{IDs: []int64{2, 3, 3, 99}}, 7)
+	if err != nil {
+		t.Fatalf("bulk approve: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected duplicate ids collapsed, got %+v", results)
+	}
+	if !results[0].OK || results[1].OK || results[1].Error != ErrClaimedByOther.Error() || results[2].Error != ErrPendingNotFound.Error() {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if cache.invalidations != 1 {
+		t.Fatalf("expected a single cache invalidation, got %d", cache.invalidations)
+	}
+	published, _ := repo.ListPublished(ctx)
+	if len(published) != 1 {
+		t.Fatalf("expected one published article, got %d", len(published))
+	}
+}
+
+func TestBulkRejectAndReassign_SelectByFilter(t *testing.T) {
+	ctx := context.Background()
+	repo := seedBulkRepo(t)
+	cache := &countingCache{}
+	svc := NewService(repo, cache)
+
+	if _, err := svc.BulkReject(ctx, BulkSelection{}, 7, "重复"); !errors.Is(err, ErrEmptySelection) {
+		t.Fatalf("expected empty selection rejected, got %v", err)
+	}
+	if _, err := svc.BulkReject(ctx, BulkSelection{SourceID: 2}, 7, " "); !errors.Is(err, ErrRejectReasonRequired) {
+		t.Fatalf("expected reason required, got %v", err)
+	}
+
+	from := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+	results, err := svc.BulkReject(ctx, BulkSelection{SourceID: 2, From: &from}, 7, "重复")
+	if err != nil || len(results) != 1 || results[0].ID != 3 || !results[0].OK {
+		t.Fatalf("expected only item 3 rejected, got %+v, %v", results, err)
+	}
+	if cache.invalidations != 0 {
+		t.Fatalf("expected rejection to leave the articles cache alone")
+	}
+
+	results, err = svc.BulkReassign(ctx, BulkSelection{SourceID: 2}, 7, 9)
+	if err != nil || len(results) != 1 || results[0].ID != 2 {
+		t.Fatalf("expected item 2 reassigned, got %+v, %v", results, err)
+	}
+	rec, _ := repo.GetPending(ctx, 2)
+	if rec.ClaimedBy != 9 || rec.ClaimedUntil == nil {
+		t.Fatalf("expected item claimed for the assignee, got %+v", rec)
+	}
+	if err := svc.Approve(ctx, 2, 7, 0); !errors.Is(err, ErrClaimedByOther) {
+		t.Fatalf("expected reassigned item blocked for the previous reviewer, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package review
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+	"github.com/bajiaozhi/w-mma/backend/internal/media"
+)
+
+const (
+	DefaultFeedLimit = 20
+	MaxFeedLimit     = 50
+
+	relatedArticlesLimit = 5
+)
+
+// FeedCursor marks the last article of a page. The feed is ordered by publish time and then
+// id, newest first, so articles published in the same second are neither skipped nor repeated.
+type FeedCursor struct {
+	PublishedAt time.Time
+	ID          int64
+}
+
+// Encode returns the opaque cursor handed to clients.
+func (c FeedCursor) Encode() string {
+	raw := c.PublishedAt.UTC().Format(time.RFC3339Nano) + "|" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Before reports whether an article sorts after the cursor, i.e. belongs on a later page.
+func (c FeedCursor) Before(publishedAt time.Time, id int64) bool {
+	return publishedAt.Before(c.PublishedAt) || (publishedAt.Equal(c.PublishedAt) && id < c.ID)
+}
+
+func ParseFeedCursor(encoded string) (FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return FeedCursor{}, ErrInvalidCursor
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return FeedCursor{}, ErrInvalidCursor
+	}
+	publishedAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return FeedCursor{}, ErrInvalidCursor
+	}
+	articleID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || articleID <= 0 {
+		return FeedCursor{}, ErrInvalidCursor
+	}
+	return FeedCursor{PublishedAt: publishedAt, ID: articleID}, nil
+}
+
+// FeedQuery selects one page of the public article feed. Zero filters match everything.
+type FeedQuery struct {
+	SourceID  int64
+	FighterID int64
+	// Tag is a tag slug.
+	Tag   string
+	After *FeedCursor
+	Limit int
+}
+
+// FeedPage is one page of the feed. NextCursor is empty on the last page.
+type FeedPage struct {
+	Items      []PendingArticle `json:"items"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// ArticleDetail is a published article with its media and related articles.
+type ArticleDetail struct {
+	PendingArticle
+	Media   []media.Asset    `json:"media"`
+	Related []PendingArticle `json:"related"`
+}
+
+// FeedCache keeps feed pages. Every query, including its tag and cursor, has its own entry;
+// entries are dropped whenever published articles change.
+type FeedCache interface {
+	GetFeed(ctx context.Context, query FeedQuery) (FeedPage, bool, error)
+	SetFeed(ctx context.Context, query FeedQuery, page FeedPage) error
+}
+
+// MediaLister returns the media assets attached to an article.
+type MediaLister interface {
+	ListByOwner(ctx context.Context, ownerType string, ownerID int64) ([]media.Asset, error)
+}
+
+// ListFeedPage reads one page, asking for one extra article to tell whether another page follows.
+func ListFeedPage(ctx context.Context, repo PublishedRepository, query FeedQuery) (FeedPage, error) {
+	if query.Limit <= 0 {
+		query.Limit = DefaultFeedLimit
+	}
+	query.Limit = min(query.Limit, MaxFeedLimit)
+	limit := query.Limit
+	query.Limit++
+
+	items, err := repo.ListFeed(ctx, query)
+	if err != nil {
+		return FeedPage{}, err
+	}
+	page := FeedPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		last := page.Items[limit-1]
+		cursor := FeedCursor{ID: last.ID}
+		if last.PublishedAt != nil {
+			cursor.PublishedAt = *last.PublishedAt
+		}
+		page.NextCursor = cursor.Encode()
+	}
+	return page, nil
+}
+
+// cachedFeedPage serves a page from feeds when it holds one, filling it on a miss. Cache
+// errors fall through to the repository.
+func cachedFeedPage(ctx context.Context, repo PublishedRepository, feeds FeedCache, query FeedQuery) (FeedPage, error) {
+	if feeds == nil {
+		return ListFeedPage(ctx, repo, query)
+	}
+	if page, ok, err := feeds.GetFeed(ctx, query); err == nil && ok {
+		return page, nil
+	}
+	page, err := ListFeedPage(ctx, repo, query)
+	if err != nil {
+		return FeedPage{}, err
+	}
+	_ = feeds.SetFeed(ctx, query, page)
+	return page, nil
+}
+
+// relatedArticles picks recent articles sharing a fighter or event with the article, falling
+// back to the same source.
+func relatedArticles(ctx context.Context, repo PublishedRepository, article PendingArticle) ([]PendingArticle, error) {
+	related := make([]PendingArticle, 0, relatedArticlesLimit)
+	seen := map[int64]bool{article.ID: true}
+	add := func(items []PendingArticle) {
+		for _, item := range items {
+			if len(related) < relatedArticlesLimit && !seen[item.ID] {
+				seen[item.ID] = true
+				related = append(related, item)
+			}
+		}
+	}
+
+	if entityRepo, ok := repo.(EntityArticleRepository); ok {
+		for _, link := range article.Entities {
+			if link.Status == linking.StatusRejected || len(related) >= relatedArticlesLimit {
+				continue
+			}
+			items, err := entityRepo.ListArticlesByEntity(ctx, link.EntityType, link.EntityID, relatedArticlesLimit+1)
+			if err != nil {
+				return nil, err
+			}
+			add(items)
+		}
+	}
+	if len(related) < relatedArticlesLimit && article.SourceID > 0 {
+		items, err := repo.ListFeed(ctx, FeedQuery{SourceID: article.SourceID, Limit: relatedArticlesLimit + 1})
+		if err != nil {
+			return nil, err
+		}
+		add(items)
+	}
+	return related, nil
+}
@@ -1,34 +1,357 @@
 package review
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
 )
 
 func RegisterAdminReviewRoutes(r *gin.Engine, svc *Service) {
 	r.GET("/admin/review/pending", func(c *gin.Context) {
-		items, err := svc.ListPending(c.Request.Context())
+		filter := PendingFilter{Status: c.Query("status")}
+		if raw := c.Query("source_id"); raw != "" {
+			sourceID, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil || sourceID <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid source id"})
+				return
+			}
+			filter.SourceID = sourceID
+		}
+		var ok bool
+		if filter.From, ok = timeFromQuery(c, "from"); !ok {
+			return
+		}
+		if filter.To, ok = timeFromQuery(c, "to"); !ok {
+			return
+		}
+
+		items, err := svc.ListPending(c.Request.Context(), filter)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			writeReviewError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"items": items})
 	})
 
+	r.GET("/admin/review/scheduled", func(c *gin.Context) {
+		items, err := svc.ListScheduled(c.Request.Context())
+		if err != nil {
+			writeReviewError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": items})
+	})
+
+	r.POST("/admin/review/bulk/approve", func(c *gin.Context) {
+		var selection BulkSelection
+		if err := c.ShouldBindJSON(&selection); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		results, err := svc.BulkApprove(c.Request.Context(), selection, reviewerFromContext(c))
+		writeBulkResults(c, results, err)
+	})
+
+	r.POST("/admin/review/bulk/reject", func(c *gin.Context) {
+		var req struct {
+			BulkSelection
+			Reason string `json:"reason"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		results, err := svc.BulkReject(c.Request.Context(), req.BulkSelection, reviewerFromContext(c), req.Reason)
+		writeBulkResults(c, results, err)
+	})
+
+	r.POST("/admin/review/bulk/reassign", func(c *gin.Context) {
+		var req struct {
+			BulkSelection
+			AssigneeID int64 `json:"assignee_id"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		results, err := svc.BulkReassign(c.Request.Context(), req.BulkSelection, reviewerFromContext(c), req.AssigneeID)
+		writeBulkResults(c, results, err)
+	})
+
+	r.PUT("/admin/review/:id", func(c *gin.Context) {
+		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
+			return
+		}
+		version, ok := versionFromQuery(c)
+		if !ok {
+			return
+		}
+		var edit PendingEdit
+		if err := c.ShouldBindJSON(&edit); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		item, err := svc.Edit(c.Request.Context(), pendingID, reviewerFromContext(c), version, edit)
+		if err != nil {
+			writeReviewError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, item)
+	})
+
 	r.POST("/admin/review/:id/approve", func(c *gin.Context) {
 		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
 			return
 		}
-		reviewerID, _ := strconv.ParseInt(c.Query("reviewer_id"), 10, 64)
+		reviewerID := reviewerFromContext(c)
+		version, ok := versionFromQuery(c)
+		if !ok {
+			return
+		}
+
+		// The body is optional; without one the article is published right away.
+		var schedule Schedule
+		if c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&schedule); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		if err := svc.ApproveScheduled(c.Request.Context(), pendingID, reviewerID, version, schedule); err != nil {
+			writeReviewError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	r.POST("/admin/review/:id/reject", func(c *gin.Context) {
+		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
+			return
+		}
+		reviewerID := reviewerFromContext(c)
+		version, ok := versionFromQuery(c)
+		if !ok {
+			return
+		}
+		var req struct {
+			Reason string `json:"reason"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := svc.Reject(c.Request.Context(), pendingID, reviewerID, version, req.Reason); err != nil {
+			writeReviewError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	r.POST("/admin/review/:id/reopen", func(c *gin.Context) {
+		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
+			return
+		}
+		reviewerID := reviewerFromContext(c)
+		version, ok := versionFromQuery(c)
+		if !ok {
+			return
+		}
+
+		if err := svc.Reopen(c.Request.Context(), pendingID, reviewerID, version); err != nil {
+			writeReviewError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	r.POST("/admin/review/:id/claim", func(c *gin.Context) {
+		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
+			return
+		}
+
+		item, err := svc.Claim(c.Request.Context(), pendingID, reviewerFromContext(c))
+		if err != nil {
+			writeReviewError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, item)
+	})
+
+	r.DELETE("/admin/review/:id/claim", func(c *gin.Context) {
+		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
+			return
+		}
 
-		if err := svc.Approve(c.Request.Context(), pendingID, reviewerID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if err := svc.Release(c.Request.Context(), pendingID, reviewerFromContext(c)); err != nil {
+			writeReviewError(c, err)
 			return
 		}
 		c.JSON(http.StatusOK, gin.H{"ok": true})
 	})
+
+	r.POST("/admin/review/:id/notes", func(c *gin.Context) {
+		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
+			return
+		}
+		reviewerID := reviewerFromContext(c)
+		var req struct {
+			Body string `json:"body"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		note, err := svc.AddNote(c.Request.Context(), pendingID, reviewerID, req.Body)
+		if err != nil {
+			writeReviewError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, note)
+	})
+	r.POST("/admin/review/:id/entities", func(c *gin.Context) {
+		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
+			return
+		}
+		var input EntityLinkInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		link, err := svc.SetPendingEntity(c.Request.Context(), pendingID, input)
+		if err != nil {
+			writeReviewError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, link)
+	})
+	r.PUT("/admin/review/:id/tags", func(c *gin.Context) {
+		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
+			return
+		}
+		var req struct {
+			Tags []string `json:"tags"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		tags, err := svc.SetPendingTags(c.Request.Context(), pendingID, req.Tags)
+		if err != nil {
+			writeReviewError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"tags": tags})
+	})
+}
+
+func writeReviewError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidStatus),
+		errors.Is(err, ErrRejectReasonRequired),
+		errors.Is(err, ErrInvalidEdit),
+		errors.Is(err, ErrEmptyNote),
+		errors.Is(err, ErrReviewerRequired),
+		errors.Is(err, ErrSchedulingNotEnabled),
+		errors.Is(err, ErrEmptySelection),
+		errors.Is(err, ErrBulkTooLarge),
+		errors.Is(err, ErrInvalidEntityLink),
+		errors.Is(err, taxonomy.ErrInvalidTag):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrPendingNotFound), errors.Is(err, ErrUnknownEntity), errors.Is(err, ErrUnknownTag):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrInvalidTransition),
+		errors.Is(err, ErrNotEditable),
+		errors.Is(err, ErrVersionConflict),
+		errors.Is(err, ErrClaimedByOther):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// writeBulkResults reports each article's outcome. Per-item failures still return 200; only
+// an invalid selection fails the whole request.
+func writeBulkResults(c *gin.Context, results []BulkResult, err error) {
+	if err != nil {
+		writeReviewError(c, err)
+		return
+	}
+	succeeded := 0
+	for _, result := range results {
+		if result.OK {
+			succeeded++
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"items": results, "succeeded": succeeded, "failed": len(results) - succeeded})
+}
+
+// reviewerFromContext returns the signed-in admin, falling back to the reviewer_id query
+// parameter when the routes run without admin auth.
+func reviewerFromContext(c *gin.Context) int64 {
+	if adminID := c.GetInt64("admin_user_id"); adminID > 0 {
+		return adminID
+	}
+	reviewerID, _ := strconv.ParseInt(c.Query("reviewer_id"), 10, 64)
+	return reviewerID
+}
+
+// versionFromQuery reads the version the reviewer loaded. A missing version skips the
+// optimistic concurrency check.
+func versionFromQuery(c *gin.Context) (int, bool) {
+	raw := c.Query("version")
+	if raw == "" {
+		return 0, true
+	}
+	version, err := strconv.Atoi(raw)
+	if err != nil || version <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid version"})
+		return 0, false
+	}
+	return version, true
+}
+
+// timeFromQuery reads an optional RFC 3339 time parameter.
+func timeFromQuery(c *gin.Context, name string) (*time.Time, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	at, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return nil, false
+	}
+	return &at, true
 }
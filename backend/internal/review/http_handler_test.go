@@ -1,8 +1,10 @@
 package review
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -30,3 +32,165 @@ func TestAdminReviewHandlers(t *testing.T) {
 		t.Fatalf("expected 200, got %d", w2.Code)
 	}
 }
+
+func TestAdminReviewEntityHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterAdminReviewRoutes(r, NewService(NewMemoryRepository()))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/review/1/entities", strings.NewReader(`{"entity_type":"fighter","entity_id":7,"status":"confirmed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/admin/review/1/entities", strings.NewReader(`{"entity_type":"fighter","entity_id":7,"status":"maybe"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown status, got %d", w.Code)
+	}
+}
+
+func TestAdminReviewTagHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterAdminReviewRoutes(r, NewService(NewMemoryRepository()))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/admin/review/1/tags", strings.NewReader(`{"tags":["news","UFC"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"tags":["news","ufc"]`) {
+		t.Fatalf("expected normalized tags, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPut, "/admin/review/1/tags", strings.NewReader(`{"tags":["no spaces"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid tag, got %d", w.Code)
+	}
+}
+
+func TestAdminReviewWorkflowHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterAdminReviewRoutes(r, NewService(NewMemoryRepository()))
+
+	cases := []struct {
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{http.MethodGet, "/admin/review/pending?status=archived", "", http.StatusBadRequest},
+		{http.MethodGet, "/admin/review/pending?source_id=x", "", http.StatusBadRequest},
+		{http.MethodPut, "/admin/review/1", `{"summary":"改写摘要"}`, http.StatusOK},
+		{http.MethodPost, "/admin/review/1/notes", `{"body":"需要核实"}`, http.StatusCreated},
+		{http.MethodPost, "/admin/review/1/reject", `{"reason":""}`, http.StatusBadRequest},
+		{http.MethodPost, "/admin/review/1/reject", `{"reason":"重复报道"}`, http.StatusOK},
+		{http.MethodPost, "/admin/review/1/reject", `{"reason":"重复报道"}`, http.StatusConflict},
+		{http.MethodGet, "/admin/review/pending?status=rejected&source_id=1", "", http.StatusOK},
+		{http.MethodPost, "/admin/review/1/reopen", "", http.StatusOK},
+		{http.MethodPost, "/admin/review/404/approve", "", http.StatusNotFound},
+		{http.MethodPost, "/admin/review/1/claim?reviewer_id=7", "", http.StatusOK},
+		{http.MethodPost, "/admin/review/1/claim?reviewer_id=8", "", http.StatusConflict},
+		{http.MethodPost, "/admin/review/1/approve?reviewer_id=7&version=abc", "", http.StatusBadRequest},
+		{http.MethodPost, "/admin/review/1/approve?reviewer_id=7&version=1", "", http.StatusConflict},
+		{http.MethodDelete, "/admin/review/1/claim?reviewer_id=7", "", http.StatusOK},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Fatalf("%s %s: expected %d, got %d: %s", tc.method, tc.path, tc.want, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestAdminReviewHandlers_UseSignedInReviewer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := NewMemoryRepository()
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("admin_user_id", int64(42))
+		c.Next()
+	})
+	RegisterAdminReviewRoutes(r, NewService(repo))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/review/1/claim?reviewer_id=7", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	rec, _ := repo.GetPending(context.Background(), 1)
+	if rec.ClaimedBy != 42 {
+		t.Fatalf("expected claim by signed-in admin, got %+v", rec)
+	}
+}
+
+func TestAdminReviewScheduleHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterAdminReviewRoutes(r, NewService(NewMemoryRepository()))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/review/1/approve?reviewer_id=7", strings.NewReader(`{"publish_at":"not-a-time"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for bad publish time, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/admin/review/1/approve?reviewer_id=7", strings.NewReader(`{"embargo_until":"2999-01-01T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/review/scheduled", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"publish_at":"2999-01-01T00:00:00Z"`) {
+		t.Fatalf("expected scheduled item, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAdminReviewBulkHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterAdminReviewRoutes(r, NewService(NewMemoryRepository()))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/review/bulk/approve?reviewer_id=7", strings.NewReader(`{"ids":[1,42]}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if body := w.Body.String(); !strings.Contains(body, `"succeeded":1`) || !strings.Contains(body, `"failed":1`) {
+		t.Fatalf("expected per-item results, got %s", body)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/admin/review/bulk/reject?reviewer_id=7", strings.NewReader(`{"reason":"重复"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for empty selection, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/review/pending?from=yesterday", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for bad from, got %d", w.Code)
+	}
+}
@@ -2,9 +2,12 @@ package review
 
 import (
 	"context"
-	"errors"
+	"slices"
 	"sort"
 	"sync"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
 )
 
 // MemoryRepository is an in-memory implementation used for local MVP linking.
@@ -12,13 +15,16 @@ type MemoryRepository struct {
 	mu sync.Mutex
 
 	nextPendingID int64
+	nextNoteID    int64
 	pending       map[int64]PendingArticle
 	published     []PendingArticle
+	scheduled     []PendingArticle
 }
 
 func NewMemoryRepository() *MemoryRepository {
 	return &MemoryRepository{
-		nextPendingID: 1,
+		nextPendingID: 2,
+		nextNoteID:    1,
 		pending: map[int64]PendingArticle{
 			1: {
 				ID:        1,
@@ -26,9 +32,12 @@ func NewMemoryRepository() *MemoryRepository {
 				Title:     "UFC 300 主赛前瞻",
 				Summary:   "主赛阵容与战术看点速览",
 				SourceURL: "https://www.ufc.com",
+				Status:    StatusPending,
+				Version:   1,
 			},
 		},
 		published: []PendingArticle{},
+		scheduled: []PendingArticle{},
 	}
 }
 
@@ -38,7 +47,7 @@ func (m *MemoryRepository) GetPending(_ context.Context, pendingID int64) (Pendi
 
 	item, ok := m.pending[pendingID]
 	if !ok {
-		return PendingArticle{}, errors.New("pending item not found")
+		return PendingArticle{}, ErrPendingNotFound
 	}
 	return item, nil
 }
@@ -47,24 +56,108 @@ func (m *MemoryRepository) PublishArticle(_ context.Context, rec PendingArticle)
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.published = append(m.published, rec)
+	m.publish(rec)
 	return nil
 }
 
-func (m *MemoryRepository) MarkApproved(_ context.Context, pendingID int64, _ int64) error {
+func (m *MemoryRepository) ApprovePending(_ context.Context, rec PendingArticle, change StatusChange) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	delete(m.pending, pendingID)
+	item, err := m.guarded(rec.ID, change.Guard)
+	if err != nil {
+		return err
+	}
+	m.publish(rec)
+	m.pending[rec.ID] = applyStatusChange(item, change)
+	return nil
+}
+
+func (m *MemoryRepository) SetStatus(_ context.Context, pendingID int64, change StatusChange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, err := m.guarded(pendingID, change.Guard)
+	if err != nil {
+		return err
+	}
+	m.pending[pendingID] = applyStatusChange(item, change)
+	return nil
+}
+
+func (m *MemoryRepository) SetClaim(_ context.Context, pendingID int64, guard Guard, claimant int64, until *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, err := m.guarded(pendingID, guard)
+	if err != nil {
+		return err
+	}
+	item.ClaimedBy, item.ClaimedUntil = 0, nil
+	if until != nil {
+		item.ClaimedBy, item.ClaimedUntil = claimant, until
+	}
+	m.pending[pendingID] = item
+	return nil
+}
+
+func (m *MemoryRepository) UpdatePending(_ context.Context, pendingID int64, edit PendingEdit, guard Guard) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, err := m.guarded(pendingID, guard)
+	if err != nil {
+		return err
+	}
+	item.Version++
+	item.EditedFields = MarkEdited(item.EditedFields, edit)
+	if edit.Title != nil {
+		item.Title = *edit.Title
+	}
+	if edit.Summary != nil {
+		item.Summary = *edit.Summary
+	}
+	if edit.CoverURL != nil {
+		item.CoverURL = *edit.CoverURL
+	}
+	if edit.VideoURL != nil {
+		item.VideoURL = *edit.VideoURL
+	}
+	m.pending[pendingID] = item
 	return nil
 }
 
-func (m *MemoryRepository) ListPending(context.Context) ([]PendingArticle, error) {
+func (m *MemoryRepository) AddNote(_ context.Context, pendingID int64, note Note) (Note, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.pending[pendingID]
+	if !ok {
+		return Note{}, ErrPendingNotFound
+	}
+	note.ID = m.nextNoteID
+	note.CreatedAt = time.Now()
+	m.nextNoteID++
+	item.Notes = append(item.Notes, note)
+	m.pending[pendingID] = item
+	return note, nil
+}
+
+func (m *MemoryRepository) ListPending(_ context.Context, filter PendingFilter) ([]PendingArticle, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	items := make([]PendingArticle, 0, len(m.pending))
 	for _, item := range m.pending {
+		if item.Status != filter.Status {
+			continue
+		}
+		if filter.SourceID > 0 && item.SourceID != filter.SourceID {
+			continue
+		}
+		if !publishedWithin(item.PublishedAt, filter.From, filter.To) {
+			continue
+		}
 		items = append(items, item)
 	}
 	sort.Slice(items, func(i, j int) bool {
@@ -78,6 +171,8 @@ func (m *MemoryRepository) CreatePending(_ context.Context, item PendingArticle)
 	defer m.mu.Unlock()
 
 	item.ID = m.nextPendingID
+	item.Status = StatusPending
+	item.Version = 1
 	m.nextPendingID++
 	m.pending[item.ID] = item
 	return item, nil
@@ -91,3 +186,195 @@ func (m *MemoryRepository) ListPublished(context.Context) ([]PendingArticle, err
 	copy(items, m.published)
 	return items, nil
 }
+
+func (m *MemoryRepository) ListFeed(_ context.Context, query FeedQuery) ([]PendingArticle, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := make([]PendingArticle, 0, len(m.published))
+	for _, item := range m.published {
+		if query.SourceID > 0 && item.SourceID != query.SourceID {
+			continue
+		}
+		if query.FighterID > 0 && !linkedTo(item, linking.EntityFighter, query.FighterID) {
+			continue
+		}
+		if query.Tag != "" && !slices.Contains(item.Tags, query.Tag) {
+			continue
+		}
+		if query.After != nil && !query.After.Before(publishedTime(item), item.ID) {
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		left, right := publishedTime(items[i]), publishedTime(items[j])
+		if !left.Equal(right) {
+			return left.After(right)
+		}
+		return items[i].ID > items[j].ID
+	})
+	if query.Limit > 0 && len(items) > query.Limit {
+		items = items[:query.Limit]
+	}
+	return items, nil
+}
+
+func (m *MemoryRepository) GetPublished(_ context.Context, articleID int64) (PendingArticle, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, item := range m.published {
+		if item.ID == articleID {
+			return item, nil
+		}
+	}
+	return PendingArticle{}, ErrArticleNotFound
+}
+
+func (m *MemoryRepository) ListScheduled(context.Context) ([]PendingArticle, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := make([]PendingArticle, len(m.scheduled))
+	copy(items, m.scheduled)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].PublishAt.Before(*items[j].PublishAt)
+	})
+	return items, nil
+}
+
+func (m *MemoryRepository) ReleaseDue(_ context.Context, now time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	waiting := m.scheduled[:0]
+	released := 0
+	for _, item := range m.scheduled {
+		if item.PublishAt.After(now) {
+			waiting = append(waiting, item)
+			continue
+		}
+		item.PublishAt = nil
+		m.published = append(m.published, item)
+		released++
+	}
+	m.scheduled = waiting
+	return released, nil
+}
+
+func (m *MemoryRepository) SavePendingEntity(_ context.Context, pendingID int64, link linking.Link) (linking.Link, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.pending[pendingID]
+	if !ok {
+		return linking.Link{}, ErrPendingNotFound
+	}
+	entities := make([]linking.Link, 0, len(item.Entities)+1)
+	for _, existing := range item.Entities {
+		if existing.EntityType == link.EntityType && existing.EntityID == link.EntityID {
+			link.Name = existing.Name
+			link.MatchedText = existing.MatchedText
+			continue
+		}
+		entities = append(entities, existing)
+	}
+	item.Entities = append(entities, link)
+	m.pending[pendingID] = item
+	return link, nil
+}
+
+func (m *MemoryRepository) SetPendingTags(_ context.Context, pendingID int64, slugs []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item, ok := m.pending[pendingID]
+	if !ok {
+		return ErrPendingNotFound
+	}
+	item.Tags = append([]string(nil), slugs...)
+	m.pending[pendingID] = item
+	return nil
+}
+
+func (m *MemoryRepository) ListArticlesByEntity(_ context.Context, entityType string, entityID int64, limit int) ([]PendingArticle, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	items := make([]PendingArticle, 0)
+	for idx := len(m.published) - 1; idx >= 0 && len(items) < limit; idx-- {
+		for _, link := range m.published[idx].Entities {
+			if link.EntityType == entityType && link.EntityID == entityID && link.Status != linking.StatusRejected {
+				items = append(items, m.published[idx])
+				break
+			}
+		}
+	}
+	return items, nil
+}
+
+// guarded returns the pending item when guard still holds. Callers hold m.mu.
+func (m *MemoryRepository) guarded(pendingID int64, guard Guard) (PendingArticle, error) {
+	item, ok := m.pending[pendingID]
+	if !ok {
+		return PendingArticle{}, ErrPendingNotFound
+	}
+	if err := guard.Check(item, time.Now()); err != nil {
+		return PendingArticle{}, err
+	}
+	return item, nil
+}
+
+// publish appends rec to the published list, or holds it back while it has a publish time.
+// Callers hold m.mu.
+func (m *MemoryRepository) publish(rec PendingArticle) {
+	rec.Status = ""
+	rec.Notes = nil
+	rec.Version = 0
+	rec.ClaimedBy, rec.ClaimedUntil = 0, nil
+	if rec.PublishAt != nil {
+		m.scheduled = append(m.scheduled, rec)
+		return
+	}
+	m.published = append(m.published, rec)
+}
+
+func publishedTime(item PendingArticle) time.Time {
+	if item.PublishedAt == nil {
+		return time.Time{}
+	}
+	return *item.PublishedAt
+}
+
+func linkedTo(item PendingArticle, entityType string, entityID int64) bool {
+	for _, link := range item.Entities {
+		if link.EntityType == entityType && link.EntityID == entityID && link.Status != linking.StatusRejected {
+			return true
+		}
+	}
+	return false
+}
+
+func publishedWithin(at *time.Time, from *time.Time, to *time.Time) bool {
+	if from == nil && to == nil {
+		return true
+	}
+	if at == nil {
+		return false
+	}
+	return (from == nil || !at.Before(*from)) && (to == nil || at.Before(*to))
+}
+
+func applyStatusChange(item PendingArticle, change StatusChange) PendingArticle {
+	item.Status = change.To
+	item.RejectReason = change.Reason
+	item.Version++
+	item.ClaimedBy, item.ClaimedUntil = 0, nil
+	if change.To != StatusPending {
+		now := time.Now()
+		item.ReviewerID = change.ReviewerID
+		item.ReviewedAt = &now
+	}
+	return item
+}
@@ -2,47 +2,214 @@ package review
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+	"github.com/bajiaozhi/w-mma/backend/internal/media"
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
+)
+
+const (
+	defaultEntityArticlesLimit = 20
+	maxEntityArticlesLimit     = 50
 )
 
 type PublishedRepository interface {
-	ListPublished(ctx context.Context) ([]PendingArticle, error)
+	// ListFeed returns published articles newest first, starting after query.After.
+	ListFeed(ctx context.Context, query FeedQuery) ([]PendingArticle, error)
+	// GetPublished returns a published article with its body and entity links, or
+	// ErrArticleNotFound.
+	GetPublished(ctx context.Context, articleID int64) (PendingArticle, error)
+}
+
+// EntityArticleRepository lists published articles linked to a fighter or event.
+// Rejected links are left out.
+type EntityArticleRepository interface {
+	ListArticlesByEntity(ctx context.Context, entityType string, entityID int64, limit int) ([]PendingArticle, error)
 }
 
 type PlaybackPolicy interface {
 	CanPlay(ctx context.Context, sourceID int64) bool
 }
 
-func RegisterPublicContentRoutes(r *gin.Engine, repo PublishedRepository, policy ...PlaybackPolicy) {
+// RegisterPublicContentRoutes serves the article feed and article pages. assets and feeds may
+// be nil when media or the cache is not configured.
+func RegisterPublicContentRoutes(r *gin.Engine, repo PublishedRepository, assets MediaLister, feeds FeedCache, policy ...PlaybackPolicy) {
 	var playbackPolicy PlaybackPolicy
 	if len(policy) > 0 {
 		playbackPolicy = policy[0]
 	}
 
 	r.GET("/api/articles", func(c *gin.Context) {
-		items, err := repo.ListPublished(c.Request.Context())
+		query, ok := feedQueryFromRequest(c)
+		if !ok {
+			return
+		}
+
+		page, err := cachedFeedPage(c.Request.Context(), repo, feeds, query)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		applyPlaybackPolicy(c.Request.Context(), page.Items, playbackPolicy)
+		c.JSON(http.StatusOK, page)
+	})
+
+	r.GET("/api/articles/:id", func(c *gin.Context) {
+		articleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || articleID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article id"})
+			return
+		}
+		ctx := c.Request.Context()
 
-		for idx := range items {
-			if items[idx].VideoURL == "" {
-				items[idx].CanPlay = false
-				continue
+		article, err := repo.GetPublished(ctx, articleID)
+		if errors.Is(err, ErrArticleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		detail := ArticleDetail{PendingArticle: article, Media: []media.Asset{}}
+		if assets != nil {
+			if detail.Media, err = assets.ListByOwner(ctx, "article", articleID); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
 			}
+		}
+		if detail.Related, err = relatedArticles(ctx, repo, article); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
 
-			canPlay := false
-			if playbackPolicy != nil {
-				canPlay = playbackPolicy.CanPlay(c.Request.Context(), items[idx].SourceID)
+		items := []PendingArticle{detail.PendingArticle}
+		applyPlaybackPolicy(ctx, items, playbackPolicy)
+		detail.PendingArticle = items[0]
+		detail.Media = filterPlayableMedia(ctx, detail.Media, article.SourceID, playbackPolicy)
+		applyPlaybackPolicy(ctx, detail.Related, playbackPolicy)
+		c.JSON(http.StatusOK, detail)
+	})
+}
+
+func feedQueryFromRequest(c *gin.Context) (FeedQuery, bool) {
+	query := FeedQuery{Limit: DefaultFeedLimit}
+	for _, param := range []struct {
+		name   string
+		target *int64
+	}{
+		{"source_id", &query.SourceID},
+		{"fighter_id", &query.FighterID},
+	} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param.name})
+			return FeedQuery{}, false
+		}
+		*param.target = parsed
+	}
+	if raw := c.Query("tag"); raw != "" {
+		tags, err := taxonomy.NormalizeSlugs([]string{raw})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag"})
+			return FeedQuery{}, false
+		}
+		query.Tag = tags[0]
+	}
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return FeedQuery{}, false
+		}
+		query.Limit = min(parsed, MaxFeedLimit)
+	}
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := ParseFeedCursor(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return FeedQuery{}, false
+		}
+		query.After = &cursor
+	}
+	return query, true
+}
+
+// RegisterEntityArticleRoutes serves the related news shown on fighter and event pages.
+func RegisterEntityArticleRoutes(r *gin.Engine, repo EntityArticleRepository, policy ...PlaybackPolicy) {
+	var playbackPolicy PlaybackPolicy
+	if len(policy) > 0 {
+		playbackPolicy = policy[0]
+	}
+
+	handler := func(entityType string, invalidIDMessage string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			entityID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": invalidIDMessage})
+				return
+			}
+			limit := defaultEntityArticlesLimit
+			if raw := c.Query("limit"); raw != "" {
+				parsed, err := strconv.Atoi(raw)
+				if err != nil || parsed <= 0 {
+					c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+					return
+				}
+				limit = min(parsed, maxEntityArticlesLimit)
 			}
-			items[idx].CanPlay = canPlay
-			if !canPlay {
-				items[idx].VideoURL = ""
+
+			items, err := repo.ListArticlesByEntity(c.Request.Context(), entityType, entityID, limit)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
 			}
+			applyPlaybackPolicy(c.Request.Context(), items, playbackPolicy)
+			c.JSON(http.StatusOK, gin.H{"items": items})
 		}
-		c.JSON(http.StatusOK, gin.H{"items": items})
-	})
+	}
+
+	r.GET("/api/fighters/:id/articles", handler(linking.EntityFighter, "invalid fighter id"))
+	r.GET("/api/events/:id/articles", handler(linking.EntityEvent, "invalid event id"))
+}
+
+// filterPlayableMedia drops video assets of sources that may not be played in the app.
+func filterPlayableMedia(ctx context.Context, assets []media.Asset, sourceID int64, policy PlaybackPolicy) []media.Asset {
+	canPlay := policy != nil && policy.CanPlay(ctx, sourceID)
+	out := make([]media.Asset, 0, len(assets))
+	for _, asset := range assets {
+		if asset.MediaType == "video" && !canPlay {
+			continue
+		}
+		out = append(out, asset)
+	}
+	return out
+}
+
+// applyPlaybackPolicy hides video links of sources that may not be played in the app.
+func applyPlaybackPolicy(ctx context.Context, items []PendingArticle, policy PlaybackPolicy) {
+	for idx := range items {
+		if items[idx].VideoURL == "" {
+			items[idx].CanPlay = false
+			continue
+		}
+
+		canPlay := false
+		if policy != nil {
+			canPlay = policy.CanPlay(ctx, items[idx].SourceID)
+		}
+		items[idx].CanPlay = canPlay
+		if !canPlay {
+			items[idx].VideoURL = ""
+		}
+	}
 }
@@ -6,18 +6,31 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+	"github.com/bajiaozhi/w-mma/backend/internal/media"
 )
 
 type fakePublishedRepo struct {
 	items []PendingArticle
 }
 
-func (f *fakePublishedRepo) ListPublished(context.Context) ([]PendingArticle, error) {
+func (f *fakePublishedRepo) ListFeed(context.Context, FeedQuery) ([]PendingArticle, error) {
 	return f.items, nil
 }
 
+func (f *fakePublishedRepo) GetPublished(_ context.Context, articleID int64) (PendingArticle, error) {
+	for _, item := range f.items {
+		if item.ID == articleID {
+			return item, nil
+		}
+	}
+	return PendingArticle{}, ErrArticleNotFound
+}
+
 type fakePlaybackPolicy struct {
 	canPlay bool
 }
@@ -40,7 +53,7 @@ func TestPublicResponse_FiltersPlaybackWhenNoRights(t *testing.T) {
 			},
 		},
 	}
-	RegisterPublicContentRoutes(r, repo, &fakePlaybackPolicy{canPlay: false})
+	RegisterPublicContentRoutes(r, repo, nil, nil, &fakePlaybackPolicy{canPlay: false})
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodGet, "/api/articles", nil)
@@ -65,3 +78,211 @@ func TestPublicResponse_FiltersPlaybackWhenNoRights(t *testing.T) {
 		t.Fatalf("expected video url removed when no playback rights")
 	}
 }
+
+func TestEntityArticleRoutes_ListLinkedArticles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 1, Title: "a", Entities: []linking.Link{{EntityType: linking.EntityFighter, EntityID: 7, Status: linking.StatusSuggested}}})
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 2, Title: "b", Entities: []linking.Link{{EntityType: linking.EntityFighter, EntityID: 7, Status: linking.StatusRejected}}})
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 3, Title: "c", VideoURL: "https://video.example.com/c.mp4", Entities: []linking.Link{
+		{EntityType: linking.EntityFighter, EntityID: 7, Status: linking.StatusConfirmed},
+		{EntityType: linking.EntityEvent, EntityID: 7, Status: linking.StatusConfirmed},
+	}})
+
+	r := gin.New()
+	RegisterEntityArticleRoutes(r, repo, &fakePlaybackPolicy{canPlay: false})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/fighters/7/articles", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var payload struct {
+		Items []PendingArticle `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload.Items) != 2 || payload.Items[0].ID != 3 || payload.Items[1].ID != 1 {
+		t.Fatalf("expected linked articles newest first without rejected links, got %+v", payload.Items)
+	}
+	if payload.Items[0].VideoURL != "" {
+		t.Fatalf("expected playback policy applied")
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/events/x/articles", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for bad event id, got %d", w.Code)
+	}
+}
+
+func TestPublicFeed_PagesWithCursorAndFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	for id := int64(1); id <= 5; id++ {
+		// Articles 4 and 5 share a publish time, so the cursor has to fall back to the id.
+		publishedAt := base.Add(time.Duration(min(id, 4)) * time.Minute)
+		item := PendingArticle{ID: id, SourceID: 1 + id%2, Title: "a", PublishedAt: &publishedAt}
+		if id == 2 {
+			item.Entities = []linking.Link{{EntityType: linking.EntityFighter, EntityID: 9, Status: linking.StatusConfirmed}}
+		}
+		_ = repo.PublishArticle(ctx, item)
+	}
+	r := gin.New()
+	RegisterPublicContentRoutes(r, repo, nil, nil)
+
+	get := func(url string) FeedPage {
+		t.Helper()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d: %s", url, w.Code, w.Body.String())
+		}
+		var page FeedPage
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return page
+	}
+
+	var ids []int64
+	page := get("/api/articles?limit=2")
+	for {
+		for _, item := range page.Items {
+			ids = append(ids, item.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		page = get("/api/articles?limit=2&cursor=" + page.NextCursor)
+	}
+	if len(ids) != 5 || ids[0] != 5 || ids[1] != 4 || ids[2] != 3 || ids[3] != 2 || ids[4] != 1 {
+		t.Fatalf("expected every article once newest first, got %v", ids)
+	}
+
+	if page := get("/api/articles?source_id=1"); len(page.Items) != 2 || page.Items[0].ID != 4 || page.Items[1].ID != 2 {
+		t.Fatalf("expected source filter to keep articles 4 and 2, got %+v", page.Items)
+	}
+	if page := get("/api/articles?fighter_id=9"); len(page.Items) != 1 || page.Items[0].ID != 2 {
+		t.Fatalf("expected fighter filter to keep article 2, got %+v", page.Items)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/articles?cursor=bogus", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad cursor, got %d", w.Code)
+	}
+}
+
+func TestPublicArticleDetail_GatesMediaAndListsRelated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+	fighter := []linking.Link{{EntityType: linking.EntityFighter, EntityID: 7, Status: linking.StatusConfirmed}}
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 1, SourceID: 3, Title: "main", Content: "body", VideoURL: "https://video.example.com/a.mp4", Entities: fighter})
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 2, SourceID: 4, Title: "same fighter", Entities: fighter})
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 3, SourceID: 3, Title: "same source"})
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 4, SourceID: 5, Title: "unrelated"})
+
+	assets := media.NewService(media.NewInMemoryRepository())
+	_, _ = assets.Attach(ctx, media.AttachInput{OwnerType: "article", OwnerID: 1, MediaType: "image", URL: "https://img.example.com/a.jpg"})
+	_, _ = assets.Attach(ctx, media.AttachInput{OwnerType: "article", OwnerID: 1, MediaType: "video", URL: "https://video.example.com/a.mp4"})
+
+	r := gin.New()
+	RegisterPublicContentRoutes(r, repo, assets, nil, &fakePlaybackPolicy{canPlay: false})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/articles/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var detail ArticleDetail
+	if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if detail.Content != "body" || detail.VideoURL != "" || detail.CanPlay {
+		t.Fatalf("expected full body with playback removed, got %+v", detail.PendingArticle)
+	}
+	if len(detail.Media) != 1 || detail.Media[0].MediaType != "image" {
+		t.Fatalf("expected video asset dropped without playback rights, got %+v", detail.Media)
+	}
+	if len(detail.Related) != 2 || detail.Related[0].ID != 2 || detail.Related[1].ID != 3 {
+		t.Fatalf("expected fighter then source related articles, got %+v", detail.Related)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/articles/99", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown article, got %d", w.Code)
+	}
+}
+
+type fakeFeedCache struct {
+	pages map[FeedQuery]FeedPage
+}
+
+// key drops the cursor pointer so equal queries share an entry.
+func (f *fakeFeedCache) key(query FeedQuery) FeedQuery {
+	query.After = nil
+	return query
+}
+
+func (f *fakeFeedCache) GetFeed(_ context.Context, query FeedQuery) (FeedPage, bool, error) {
+	page, ok := f.pages[f.key(query)]
+	return page, ok, nil
+}
+
+func (f *fakeFeedCache) SetFeed(_ context.Context, query FeedQuery, page FeedPage) error {
+	f.pages[f.key(query)] = page
+	return nil
+}
+
+func TestPublicFeed_FiltersByTagAndCachesPerTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := NewMemoryRepository()
+	ctx := context.Background()
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 1, Title: "fight night", Tags: []string{"results", "ufc"}})
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 2, Title: "sit-down", Tags: []string{"interviews"}})
+	feeds := &fakeFeedCache{pages: map[FeedQuery]FeedPage{}}
+	r := gin.New()
+	RegisterPublicContentRoutes(r, repo, nil, feeds)
+
+	get := func(url string) FeedPage {
+		t.Helper()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: expected 200, got %d", url, w.Code)
+		}
+		var page FeedPage
+		if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return page
+	}
+
+	if page := get("/api/articles?tag=UFC"); len(page.Items) != 1 || page.Items[0].ID != 1 {
+		t.Fatalf("expected ufc feed to hold article 1, got %+v", page.Items)
+	}
+	if page := get("/api/articles?tag=interviews"); len(page.Items) != 1 || page.Items[0].ID != 2 {
+		t.Fatalf("expected interviews feed to hold article 2, got %+v", page.Items)
+	}
+	if len(feeds.pages) != 2 {
+		t.Fatalf("expected one cache entry per tag, got %d", len(feeds.pages))
+	}
+
+	_ = repo.PublishArticle(ctx, PendingArticle{ID: 3, Title: "another", Tags: []string{"ufc"}})
+	if page := get("/api/articles?tag=ufc"); len(page.Items) != 1 {
+		t.Fatalf("expected the cached ufc page to be served, got %+v", page.Items)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/articles?tag=not+a+slug", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad tag, got %d", w.Code)
+	}
+}
@@ -0,0 +1,44 @@
+package review
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// DefaultReleaseTick is how often the worker looks for scheduled articles that are due.
+const DefaultReleaseTick = time.Minute
+
+// StartScheduledPublisher publishes scheduled articles as their publish time passes.
+func StartScheduledPublisher(ctx context.Context, svc *Service, tick time.Duration) {
+	if svc == nil {
+		return
+	}
+	if tick <= 0 {
+		tick = DefaultReleaseTick
+	}
+	releaseDue(ctx, svc)
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			releaseDue(ctx, svc)
+		}
+	}
+}
+
+func releaseDue(ctx context.Context, svc *Service) {
+	released, err := svc.ReleaseDue(ctx)
+	if err != nil {
+		log.Printf("release scheduled articles failed: %v", err)
+		return
+	}
+	if released > 0 {
+		log.Printf("released %d scheduled articles", released)
+	}
+}
@@ -2,32 +2,297 @@ package review
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
+)
+
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// DefaultClaimTTL is how long a claim keeps other reviewers off an item.
+const DefaultClaimTTL = 10 * time.Minute
+
+var (
+	ErrPendingNotFound       = errors.New("pending article not found")
+	ErrInvalidStatus         = errors.New("invalid review status")
+	ErrInvalidTransition     = errors.New("invalid review status transition")
+	ErrRejectReasonRequired  = errors.New("reject reason is required")
+	ErrNotEditable           = errors.New("only pending articles can be edited")
+	ErrInvalidEdit           = errors.New("title must not be empty")
+	ErrEmptyNote             = errors.New("note must not be empty")
+	ErrReviewerRequired      = errors.New("reviewer id is required")
+	ErrClaimedByOther        = errors.New("pending article is claimed by another reviewer")
+	ErrVersionConflict       = errors.New("pending article has changed, reload and retry")
+	ErrSchedulingNotEnabled  = errors.New("scheduled publishing is not supported by this repository")
+	ErrEmptySelection        = errors.New("bulk selection needs ids, a source or a date range")
+	ErrBulkTooLarge          = errors.New("bulk selection matches too many articles")
+	ErrArticleNotFound       = errors.New("article not found")
+	ErrInvalidCursor         = errors.New("invalid cursor")
+	ErrInvalidEntityLink     = errors.New("invalid entity link")
+	ErrUnknownEntity         = errors.New("entity not found")
+	ErrEntityLinksNotEnabled = errors.New("entity links are not supported by this repository")
+	ErrUnknownTag            = errors.New("tag not found")
+	ErrTagsNotEnabled        = errors.New("article tags are not supported by this repository")
 )
 
+// transitions is the review state machine. Approved articles are published, so taking them
+// back goes through a takedown rather than a re-open.
+var transitions = map[string][]string{
+	StatusPending:  {StatusApproved, StatusRejected},
+	StatusRejected: {StatusPending},
+}
+
+// CanTransition reports whether a pending article may move from one review status to another.
+func CanTransition(from string, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidStatus reports whether status is a known review status.
+func ValidStatus(status string) bool {
+	switch status {
+	case StatusPending, StatusApproved, StatusRejected:
+		return true
+	default:
+		return false
+	}
+}
+
 // PendingArticle represents one article awaiting moderation.
 type PendingArticle struct {
-	ID        int64  `json:"id"`
-	SourceID  int64  `json:"source_id"`
-	Title     string `json:"title"`
-	Summary   string `json:"summary"`
-	SourceURL string `json:"source_url"`
-	CoverURL  string `json:"cover_url,omitempty"`
-	VideoURL  string `json:"video_url,omitempty"`
-	CanPlay   bool   `json:"can_play"`
+	ID          int64      `json:"id"`
+	SourceID    int64      `json:"source_id"`
+	GUID        string     `json:"guid,omitempty"`
+	Title       string     `json:"title"`
+	Summary     string     `json:"summary"`
+	Content     string     `json:"content,omitempty"`
+	Author      string     `json:"author,omitempty"`
+	SourceURL   string     `json:"source_url"`
+	CoverURL    string     `json:"cover_url,omitempty"`
+	VideoURL    string     `json:"video_url,omitempty"`
+	CanPlay     bool       `json:"can_play"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	SimHash     uint64     `json:"-"`
+	// Status is the review status; empty on published articles.
+	Status       string     `json:"status,omitempty"`
+	RejectReason string     `json:"reject_reason,omitempty"`
+	ReviewerID   int64      `json:"reviewer_id,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	// Version increases with every edit or status change, for optimistic concurrency.
+	Version      int        `json:"version,omitempty"`
+	ClaimedBy    int64      `json:"claimed_by,omitempty"`
+	ClaimedUntil *time.Time `json:"claimed_until,omitempty"`
+	// PublishAt holds an approved article back until then; it is set only for future times.
+	PublishAt    *time.Time `json:"publish_at,omitempty"`
+	EmbargoUntil *time.Time `json:"embargo_until,omitempty"`
+	Notes        []Note     `json:"notes,omitempty"`
+	// DuplicateOf points a near-duplicate at the primary item of its cluster.
+	DuplicateOf  int64            `json:"duplicate_of,omitempty"`
+	Alternatives []PendingArticle `json:"alternatives,omitempty"`
+	// Entities are the fighters and events the article is linked to. Suggested links come
+	// from name detection; editors confirm or reject them before or after approval.
+	Entities []linking.Link `json:"entities,omitempty"`
+	// Tags are the slugs of the article's categories and tags. Ingest pre-selects suggested
+	// tags; editors settle them before approval.
+	Tags []string `json:"tags,omitempty"`
+	// Translation is the latest machine translation draft of a foreign-language article.
+	Translation *Translation `json:"translation,omitempty"`
+	// OriginalTitle and OriginalContent keep the source text once a translation is published.
+	OriginalTitle   string `json:"original_title,omitempty"`
+	OriginalContent string `json:"original_content,omitempty"`
+	// EditedFields lists the fields a reviewer changed before approval; a translation draft
+	// does not overwrite them.
+	EditedFields []string `json:"edited_fields,omitempty"`
+}
+
+// Fields a reviewer can edit before approval that a translation draft would also fill.
+const (
+	FieldTitle   = "title"
+	FieldSummary = "summary"
+)
+
+// Edited reports whether a reviewer changed field before approval.
+func (p PendingArticle) Edited(field string) bool {
+	for _, item := range p.EditedFields {
+		if item == field {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkEdited adds the translatable fields touched by edit to fields.
+func MarkEdited(fields []string, edit PendingEdit) []string {
+	marked := PendingArticle{EditedFields: fields}
+	for field, value := range map[string]*string{FieldTitle: edit.Title, FieldSummary: edit.Summary} {
+		if value != nil && !marked.Edited(field) {
+			marked.EditedFields = append(marked.EditedFields, field)
+		}
+	}
+	sort.Strings(marked.EditedFields)
+	return marked.EditedFields
+}
+
+// TranslationDone is the status of a translation draft that is ready to publish.
+const TranslationDone = "done"
+
+// summaryExcerptRunes is the length of the summary cut from a translated body.
+const summaryExcerptRunes = 140
+
+// Translation is a Chinese draft of a pending article.
+type Translation struct {
+	JobID  int64  `json:"job_id"`
+	Status string `json:"status"`
+	Title  string `json:"title,omitempty"`
+	Body   string `json:"body,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Note is a reviewer's remark on a pending article.
+type Note struct {
+	ID         int64     `json:"id"`
+	ReviewerID int64     `json:"reviewer_id,omitempty"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// PendingFilter narrows the review list. An empty status lists items awaiting review. From
+// and To bound the source's publish time.
+type PendingFilter struct {
+	Status   string
+	SourceID int64
+	From     *time.Time
+	To       *time.Time
+}
+
+// PendingEdit changes an article before approval; nil fields are left as they are.
+type PendingEdit struct {
+	Title    *string `json:"title"`
+	Summary  *string `json:"summary"`
+	CoverURL *string `json:"cover_url"`
+	VideoURL *string `json:"video_url"`
+}
+
+// ClaimedByOther reports whether another reviewer holds an unexpired claim at now.
+func (p PendingArticle) ClaimedByOther(reviewerID int64, now time.Time) bool {
+	return p.ClaimedBy != 0 && p.ClaimedBy != reviewerID && p.ClaimedUntil != nil && p.ClaimedUntil.After(now)
+}
+
+// Guard is what a reviewer saw when acting on a pending article. Repositories apply a change
+// only while the guard still holds, checking it under the same lock as the write.
+type Guard struct {
+	Status     string
+	ReviewerID int64
+	// Version is the version the reviewer loaded; 0 skips the check.
+	Version int
+}
+
+// Check reports why rec no longer matches the guard.
+func (g Guard) Check(rec PendingArticle, now time.Time) error {
+	if rec.Status != g.Status {
+		return ErrInvalidTransition
+	}
+	if g.Version > 0 && rec.Version != g.Version {
+		return ErrVersionConflict
+	}
+	if rec.ClaimedByOther(g.ReviewerID, now) {
+		return ErrClaimedByOther
+	}
+	return nil
+}
+
+// StatusChange moves a pending article out of Guard.Status into To. Applying it bumps the
+// version and releases any claim.
+type StatusChange struct {
+	Guard
+	To     string
+	Reason string
 }
 
 // Repository defines persistence for review flow.
 type Repository interface {
+	// GetPending returns a pending article in any review status, or ErrPendingNotFound.
 	GetPending(ctx context.Context, pendingID int64) (PendingArticle, error)
-	PublishArticle(ctx context.Context, rec PendingArticle) error
-	MarkApproved(ctx context.Context, pendingID int64, reviewerID int64) error
-	ListPending(ctx context.Context) ([]PendingArticle, error)
+	// ApprovePending publishes rec and marks the pending article approved in one transaction.
+	ApprovePending(ctx context.Context, rec PendingArticle, change StatusChange) error
+	SetStatus(ctx context.Context, pendingID int64, change StatusChange) error
+	UpdatePending(ctx context.Context, pendingID int64, edit PendingEdit, guard Guard) error
+	// SetClaim hands the article to claimant until the given time, or releases the claim when
+	// until is nil. The guard is checked for the reviewer making the change.
+	SetClaim(ctx context.Context, pendingID int64, guard Guard, claimant int64, until *time.Time) error
+	AddNote(ctx context.Context, pendingID int64, note Note) (Note, error)
+	ListPending(ctx context.Context, filter PendingFilter) ([]PendingArticle, error)
+}
+
+// ScheduleStore is implemented by repositories that hold approved articles until their
+// publish time.
+type ScheduleStore interface {
+	// ListScheduled returns articles waiting to be published, soonest first.
+	ListScheduled(ctx context.Context) ([]PendingArticle, error)
+	// ReleaseDue publishes scheduled articles whose time has come and reports how many.
+	ReleaseDue(ctx context.Context, now time.Time) (int, error)
+}
+
+// Schedule delays publication of an approved article. The article goes out at the later of
+// PublishAt, the editor's choice, and EmbargoUntil, the source's release time.
+type Schedule struct {
+	PublishAt    *time.Time `json:"publish_at"`
+	EmbargoUntil *time.Time `json:"embargo_until"`
+}
+
+// releaseAt returns when the article may be published, or nil for right away.
+func (s Schedule) releaseAt(now time.Time) *time.Time {
+	var release *time.Time
+	for _, at := range []*time.Time{s.PublishAt, s.EmbargoUntil} {
+		if at != nil && at.After(now) && (release == nil || at.After(*release)) {
+			release = at
+		}
+	}
+	return release
+}
+
+// EntityLinkStore is implemented by repositories that keep article–entity links.
+type EntityLinkStore interface {
+	// SavePendingEntity adds or updates one link of a pending article and returns it with
+	// the entity's display name. Unknown entities yield ErrUnknownEntity.
+	SavePendingEntity(ctx context.Context, pendingID int64, link linking.Link) (linking.Link, error)
+}
+
+// TagStore is implemented by repositories that keep article tags.
+type TagStore interface {
+	// SetPendingTags replaces the tags of a pending article. Unknown slugs yield ErrUnknownTag.
+	SetPendingTags(ctx context.Context, pendingID int64, slugs []string) error
+}
+
+// EntityDetector finds the fighters and events an article mentions.
+type EntityDetector interface {
+	Detect(ctx context.Context, title string, body string) ([]linking.Link, error)
+}
+
+// EntityLinkInput is an editor's decision on one link.
+type EntityLinkInput struct {
+	EntityType string `json:"entity_type"`
+	EntityID   int64  `json:"entity_id"`
+	Status     string `json:"status"`
 }
 
 type Service struct {
-	repo  Repository
-	cache ArticlesCache
+	repo     Repository
+	cache    ArticlesCache
+	entities EntityDetector
+	now      func() time.Time
 }
 
 type ArticlesCache interface {
@@ -35,31 +300,333 @@ type ArticlesCache interface {
 }
 
 func NewService(repo Repository, cache ...ArticlesCache) *Service {
-	s := &Service{repo: repo}
+	s := &Service{repo: repo, now: time.Now}
 	if len(cache) > 0 {
 		s.cache = cache[0]
 	}
 	return s
 }
 
-func (s *Service) Approve(ctx context.Context, pendingID int64, reviewerID int64) error {
+// SetEntityDetector makes approval re-run name detection, so fighters and events added
+// after the article was ingested are linked too.
+func (s *Service) SetEntityDetector(detector EntityDetector) {
+	s.entities = detector
+}
+
+// Approve publishes a pending article. version is the version the reviewer saw; 0 skips the
+// check.
+func (s *Service) Approve(ctx context.Context, pendingID int64, reviewerID int64, version int) error {
+	return s.ApproveScheduled(ctx, pendingID, reviewerID, version, Schedule{})
+}
+
+// ApproveScheduled approves a pending article and publishes it when the schedule allows,
+// right away if its times have already passed.
+func (s *Service) ApproveScheduled(ctx context.Context, pendingID int64, reviewerID int64, version int, schedule Schedule) error {
+	published, err := s.approve(ctx, pendingID, reviewerID, version, schedule)
+	if err != nil || !published {
+		return err
+	}
+
+	s.invalidateArticlesCache(ctx)
+	return nil
+}
+
+// approve publishes or schedules one article in its own transaction and reports whether it
+// went live, leaving cache invalidation to the caller.
+func (s *Service) approve(ctx context.Context, pendingID int64, reviewerID int64, version int, schedule Schedule) (bool, error) {
+	release := schedule.releaseAt(s.now())
+	if release != nil {
+		if _, ok := s.repo.(ScheduleStore); !ok {
+			return false, ErrSchedulingNotEnabled
+		}
+	}
 	rec, err := s.repo.GetPending(ctx, pendingID)
 	if err != nil {
-		return err
+		return false, err
+	}
+	if !CanTransition(rec.Status, StatusApproved) {
+		return false, ErrInvalidTransition
+	}
+	guard := Guard{Status: rec.Status, ReviewerID: reviewerID, Version: version}
+	if err := guard.Check(rec, s.now()); err != nil {
+		return false, err
 	}
-	if err := s.repo.PublishArticle(ctx, rec); err != nil {
+	// The published article is built from this read, so pin its version even when the caller
+	// sent none: an edit committed before the approve takes the lock must not go out stale.
+	guard.Version = rec.Version
+	if s.entities != nil {
+		body := rec.Content
+		if strings.TrimSpace(body) == "" {
+			body = rec.Summary
+		}
+		detected, err := s.entities.Detect(ctx, rec.Title, body)
+		if err != nil {
+			return false, err
+		}
+		rec.Entities = linking.Merge(rec.Entities, detected)
+	}
+	rec = applyTranslation(rec)
+	if release != nil {
+		rec.PublishAt = release
+		rec.PublishedAt = release
+	}
+	rec.EmbargoUntil = schedule.EmbargoUntil
+	if err := s.repo.ApprovePending(ctx, rec, StatusChange{Guard: guard, To: StatusApproved}); err != nil {
+		return false, err
+	}
+	return release == nil, nil
+}
+
+// ListScheduled returns approved articles waiting for their publish time.
+func (s *Service) ListScheduled(ctx context.Context) ([]PendingArticle, error) {
+	store, ok := s.repo.(ScheduleStore)
+	if !ok {
+		return []PendingArticle{}, nil
+	}
+	return store.ListScheduled(ctx)
+}
+
+// ReleaseDue publishes scheduled articles whose time has come and refreshes the articles cache.
+func (s *Service) ReleaseDue(ctx context.Context) (int, error) {
+	store, ok := s.repo.(ScheduleStore)
+	if !ok {
+		return 0, nil
+	}
+	released, err := store.ReleaseDue(ctx, s.now())
+	if err != nil || released == 0 {
+		return released, err
+	}
+	s.invalidateArticlesCache(ctx)
+	return released, nil
+}
+
+// Reject takes a pending article out of the queue. The reason is shown to other reviewers.
+func (s *Service) Reject(ctx context.Context, pendingID int64, reviewerID int64, version int, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrRejectReasonRequired
+	}
+	return s.transition(ctx, pendingID, StatusRejected, reason, reviewerID, version)
+}
+
+// Reopen puts a rejected article back into the queue.
+func (s *Service) Reopen(ctx context.Context, pendingID int64, reviewerID int64, version int) error {
+	return s.transition(ctx, pendingID, StatusPending, "", reviewerID, version)
+}
+
+func (s *Service) transition(ctx context.Context, pendingID int64, to string, reason string, reviewerID int64, version int) error {
+	rec, err := s.repo.GetPending(ctx, pendingID)
+	if err != nil {
 		return err
 	}
-	if err := s.repo.MarkApproved(ctx, pendingID, reviewerID); err != nil {
+	if !CanTransition(rec.Status, to) {
+		return ErrInvalidTransition
+	}
+	guard := Guard{Status: rec.Status, ReviewerID: reviewerID, Version: version}
+	if err := guard.Check(rec, s.now()); err != nil {
 		return err
 	}
+	return s.repo.SetStatus(ctx, pendingID, StatusChange{Guard: guard, To: to, Reason: reason})
+}
 
-	s.invalidateArticlesCache(ctx)
-	return nil
+// Edit changes the title, summary, cover or video of an article before it is approved.
+func (s *Service) Edit(ctx context.Context, pendingID int64, reviewerID int64, version int, edit PendingEdit) (PendingArticle, error) {
+	edit = normalizeEdit(edit)
+	if edit.Title != nil && *edit.Title == "" {
+		return PendingArticle{}, ErrInvalidEdit
+	}
+	rec, err := s.repo.GetPending(ctx, pendingID)
+	if err != nil {
+		return PendingArticle{}, err
+	}
+	if rec.Status != StatusPending {
+		return PendingArticle{}, ErrNotEditable
+	}
+	guard := Guard{Status: StatusPending, ReviewerID: reviewerID, Version: version}
+	if err := guard.Check(rec, s.now()); err != nil {
+		return PendingArticle{}, err
+	}
+	if err := s.repo.UpdatePending(ctx, pendingID, edit, guard); err != nil {
+		return PendingArticle{}, err
+	}
+	return s.repo.GetPending(ctx, pendingID)
 }
 
-func (s *Service) ListPending(ctx context.Context) ([]PendingArticle, error) {
-	return s.repo.ListPending(ctx)
+// Claim keeps other reviewers from acting on a pending article for DefaultClaimTTL. Claiming
+// again extends the claim; an expired claim can be taken over.
+func (s *Service) Claim(ctx context.Context, pendingID int64, reviewerID int64) (PendingArticle, error) {
+	if reviewerID <= 0 {
+		return PendingArticle{}, ErrReviewerRequired
+	}
+	until := s.now().Add(DefaultClaimTTL)
+	if err := s.repo.SetClaim(ctx, pendingID, Guard{Status: StatusPending, ReviewerID: reviewerID}, reviewerID, &until); err != nil {
+		return PendingArticle{}, err
+	}
+	return s.repo.GetPending(ctx, pendingID)
+}
+
+// Release gives up the reviewer's claim on a pending article.
+func (s *Service) Release(ctx context.Context, pendingID int64, reviewerID int64) error {
+	if reviewerID <= 0 {
+		return ErrReviewerRequired
+	}
+	return s.repo.SetClaim(ctx, pendingID, Guard{Status: StatusPending, ReviewerID: reviewerID}, 0, nil)
+}
+
+// Reassign hands a pending article the reviewer holds, or nobody holds, to another reviewer
+// for a fresh claim period.
+func (s *Service) Reassign(ctx context.Context, pendingID int64, reviewerID int64, assigneeID int64) error {
+	if reviewerID <= 0 || assigneeID <= 0 {
+		return ErrReviewerRequired
+	}
+	until := s.now().Add(DefaultClaimTTL)
+	return s.repo.SetClaim(ctx, pendingID, Guard{Status: StatusPending, ReviewerID: reviewerID}, assigneeID, &until)
+}
+
+func normalizeEdit(edit PendingEdit) PendingEdit {
+	for _, field := range []*string{edit.Title, edit.Summary, edit.CoverURL, edit.VideoURL} {
+		if field != nil {
+			*field = strings.TrimSpace(*field)
+		}
+	}
+	return edit
+}
+
+// AddNote records a reviewer's remark on a pending article in any status.
+func (s *Service) AddNote(ctx context.Context, pendingID int64, reviewerID int64, body string) (Note, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return Note{}, ErrEmptyNote
+	}
+	if _, err := s.repo.GetPending(ctx, pendingID); err != nil {
+		return Note{}, err
+	}
+	return s.repo.AddNote(ctx, pendingID, Note{ReviewerID: reviewerID, Body: body})
+}
+
+// applyTranslation publishes a finished translation draft in place of the original text and
+// keeps the original alongside it. A title or summary the reviewer edited stays as written.
+func applyTranslation(rec PendingArticle) PendingArticle {
+	draft := rec.Translation
+	if draft == nil || draft.Status != TranslationDone || strings.TrimSpace(draft.Title) == "" {
+		return rec
+	}
+	if !rec.Edited(FieldTitle) {
+		rec.OriginalTitle = rec.Title
+		rec.Title = strings.TrimSpace(draft.Title)
+	}
+	if body := strings.TrimSpace(draft.Body); body != "" {
+		rec.OriginalContent = rec.Content
+		rec.Content = body
+		if !rec.Edited(FieldSummary) {
+			rec.Summary = excerpt(body, summaryExcerptRunes)
+		}
+	}
+	return rec
+}
+
+func excerpt(text string, limit int) string {
+	text = strings.TrimSpace(text)
+	if idx := strings.Index(text, "\n\n"); idx > 0 {
+		text = text[:idx]
+	}
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:limit])) + "…"
+}
+
+// SetPendingEntity records an editor's decision on a link of a pending article. Editors can
+// also add a link detection missed by sending a fighter or event it did not suggest.
+func (s *Service) SetPendingEntity(ctx context.Context, pendingID int64, input EntityLinkInput) (linking.Link, error) {
+	input.EntityType = strings.TrimSpace(input.EntityType)
+	input.Status = strings.TrimSpace(input.Status)
+	if input.Status == "" {
+		input.Status = linking.StatusConfirmed
+	}
+	if !linking.ValidEntityType(input.EntityType) || input.EntityID <= 0 || !linking.ValidStatus(input.Status) {
+		return linking.Link{}, ErrInvalidEntityLink
+	}
+	store, ok := s.repo.(EntityLinkStore)
+	if !ok {
+		return linking.Link{}, ErrEntityLinksNotEnabled
+	}
+	if _, err := s.repo.GetPending(ctx, pendingID); err != nil {
+		return linking.Link{}, err
+	}
+	return store.SavePendingEntity(ctx, pendingID, linking.Link{
+		EntityType: input.EntityType,
+		EntityID:   input.EntityID,
+		Status:     input.Status,
+	})
+}
+
+// SetPendingTags replaces the tags of a pending article and returns them. Tags are copied to
+// the article on approval, so decided items can no longer be changed.
+func (s *Service) SetPendingTags(ctx context.Context, pendingID int64, slugs []string) ([]string, error) {
+	slugs, err := taxonomy.NormalizeSlugs(slugs)
+	if err != nil {
+		return nil, err
+	}
+	store, ok := s.repo.(TagStore)
+	if !ok {
+		return nil, ErrTagsNotEnabled
+	}
+	item, err := s.repo.GetPending(ctx, pendingID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != StatusPending {
+		return nil, ErrNotEditable
+	}
+	if err := store.SetPendingTags(ctx, pendingID, slugs); err != nil {
+		return nil, err
+	}
+	return slugs, nil
+}
+
+// ListPending returns the review queue with near-duplicates nested under their primary item.
+func (s *Service) ListPending(ctx context.Context, filter PendingFilter) ([]PendingArticle, error) {
+	filter.Status = strings.TrimSpace(filter.Status)
+	if filter.Status == "" {
+		filter.Status = StatusPending
+	}
+	if !ValidStatus(filter.Status) {
+		return nil, ErrInvalidStatus
+	}
+	items, err := s.repo.ListPending(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return clusterDuplicates(items), nil
+}
+
+// clusterDuplicates nests items under their primary. Items whose primary has left the queue
+// are shown on their own.
+func clusterDuplicates(items []PendingArticle) []PendingArticle {
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	primaries := make([]PendingArticle, 0, len(items))
+	index := make(map[int64]int, len(items))
+	for _, item := range items {
+		if item.DuplicateOf == 0 {
+			index[item.ID] = len(primaries)
+			primaries = append(primaries, item)
+		}
+	}
+	for _, item := range items {
+		if item.DuplicateOf == 0 {
+			continue
+		}
+		if idx, ok := index[item.DuplicateOf]; ok {
+			primaries[idx].Alternatives = append(primaries[idx].Alternatives, item)
+			continue
+		}
+		primaries = append(primaries, item)
+	}
+	sort.SliceStable(primaries, func(i, j int) bool { return primaries[i].ID < primaries[j].ID })
+	return primaries
 }
 
 func (s *Service) invalidateArticlesCache(ctx context.Context) {
@@ -2,13 +2,19 @@ package review
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+	"github.com/bajiaozhi/w-mma/backend/internal/taxonomy"
 )
 
 type fakeReviewRepo struct {
 	pending map[int64]PendingArticle
 
 	articlePublished bool
+	publishedRec     PendingArticle
 	approved         bool
 	approvedBy       int64
 }
@@ -21,6 +27,7 @@ func newFakeReviewRepo() *fakeReviewRepo {
 				Title:     "news-a",
 				Summary:   "summary-a",
 				SourceURL: "https://example.com/a",
+				Status:    StatusPending,
 			},
 		},
 	}
@@ -30,18 +37,31 @@ func (r *fakeReviewRepo) GetPending(_ context.Context, pendingID int64) (Pending
 	return r.pending[pendingID], nil
 }
 
-func (r *fakeReviewRepo) PublishArticle(context.Context, PendingArticle) error {
+func (r *fakeReviewRepo) ApprovePending(_ context.Context, rec PendingArticle, change StatusChange) error {
 	r.articlePublished = true
+	r.publishedRec = rec
+	r.approved = true
+	r.approvedBy = change.ReviewerID
 	return nil
 }
 
-func (r *fakeReviewRepo) MarkApproved(_ context.Context, _ int64, reviewerID int64) error {
-	r.approved = true
-	r.approvedBy = reviewerID
+func (r *fakeReviewRepo) SetStatus(context.Context, int64, StatusChange) error {
+	return nil
+}
+
+func (r *fakeReviewRepo) UpdatePending(context.Context, int64, PendingEdit, Guard) error {
 	return nil
 }
 
-func (r *fakeReviewRepo) ListPending(context.Context) ([]PendingArticle, error) {
+func (r *fakeReviewRepo) SetClaim(context.Context, int64, Guard, int64, *time.Time) error {
+	return nil
+}
+
+func (r *fakeReviewRepo) AddNote(_ context.Context, _ int64, note Note) (Note, error) {
+	return note, nil
+}
+
+func (r *fakeReviewRepo) ListPending(context.Context, PendingFilter) ([]PendingArticle, error) {
 	items := make([]PendingArticle, 0, len(r.pending))
 	for _, p := range r.pending {
 		items = append(items, p)
@@ -62,7 +82,7 @@ func TestApprove_PublishesArticle(t *testing.T) {
 	repo := newFakeReviewRepo()
 	cache := &fakeArticlesCache{}
 	svc := NewService(repo, cache)
-	err := svc.Approve(context.Background(), 101, 9001)
+	err := svc.Approve(context.Background(), 101, 9001, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -73,3 +93,418 @@ func TestApprove_PublishesArticle(t *testing.T) {
 		t.Fatalf("expected cache invalidation on approval")
 	}
 }
+
+func TestListPending_NestsNearDuplicates(t *testing.T) {
+	repo := newFakeReviewRepo()
+	repo.pending[102] = PendingArticle{ID: 102, Title: "news-a (other outlet)", SourceURL: "https://other.example.com/a", DuplicateOf: 101}
+	repo.pending[103] = PendingArticle{ID: 103, Title: "news-b", SourceURL: "https://example.com/b"}
+	repo.pending[104] = PendingArticle{ID: 104, Title: "news-c (orphan)", SourceURL: "https://example.com/c", DuplicateOf: 90}
+
+	items, err := NewService(repo).ListPending(context.Background(), PendingFilter{})
+	if err != nil {
+		t.Fatalf("list pending: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 queue entries, got %+v", items)
+	}
+	if items[0].ID != 101 || len(items[0].Alternatives) != 1 || items[0].Alternatives[0].ID != 102 {
+		t.Fatalf("expected 102 nested under 101, got %+v", items[0])
+	}
+	if items[2].ID != 104 {
+		t.Fatalf("expected orphaned duplicate listed on its own, got %+v", items[2])
+	}
+}
+
+type fakeEntityDetector []linking.Link
+
+func (d fakeEntityDetector) Detect(context.Context, string, string) ([]linking.Link, error) {
+	return d, nil
+}
+
+func TestApprove_MergesDetectedEntities(t *testing.T) {
+	repo := newFakeReviewRepo()
+	rec := repo.pending[101]
+	rec.Entities = []linking.Link{{EntityType: linking.EntityFighter, EntityID: 7, Status: linking.StatusRejected}}
+	repo.pending[101] = rec
+
+	svc := NewService(repo)
+	svc.SetEntityDetector(fakeEntityDetector{
+		{EntityType: linking.EntityFighter, EntityID: 7, Status: linking.StatusSuggested},
+		{EntityType: linking.EntityEvent, EntityID: 3, Status: linking.StatusSuggested},
+	})
+	if err := svc.Approve(context.Background(), 101, 9001, 0); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	links := repo.publishedRec.Entities
+	if len(links) != 2 || links[0].Status != linking.StatusRejected || links[1].EntityType != linking.EntityEvent {
+		t.Fatalf("expected editor decision kept and new event link added, got %+v", links)
+	}
+}
+
+func TestApprove_PublishesTranslationAndKeepsOriginal(t *testing.T) {
+	repo := newFakeReviewRepo()
+	rec := repo.pending[101]
+	rec.Content = "Original body"
+	rec.Translation = &Translation{JobID: 5, Status: TranslationDone, Title: "译文标题", Body: "译文第一段\n\n译文第二段"}
+	repo.pending[101] = rec
+
+	svc := NewService(repo)
+	if err := svc.Approve(context.Background(), 101, 9001, 0); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	got := repo.publishedRec
+	if got.Title != "译文标题" || got.Content != "译文第一段\n\n译文第二段" || got.Summary != "译文第一段" {
+		t.Fatalf("expected translation published, got %+v", got)
+	}
+	if got.OriginalTitle != "news-a" || got.OriginalContent != "Original body" {
+		t.Fatalf("expected original text kept, got %+v", got)
+	}
+}
+
+func TestApprove_TranslationKeepsReviewerEdits(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	svc := NewService(repo)
+	pending, err := repo.CreatePending(ctx, PendingArticle{Title: "Original title", Summary: "Original summary", Content: "Original body", SourceURL: "https://example.com/edited"})
+	if err != nil {
+		t.Fatalf("create pending: %v", err)
+	}
+
+	title := "编辑改写的标题"
+	edited, err := svc.Edit(ctx, pending.ID, 9001, 0, PendingEdit{Title: &title})
+	if err != nil || !edited.Edited(FieldTitle) || edited.Edited(FieldSummary) {
+		t.Fatalf("expected the title marked edited, got %+v %v", edited.EditedFields, err)
+	}
+
+	// The translation job finishes after the edit.
+	repo.mu.Lock()
+	item := repo.pending[pending.ID]
+	item.Translation = &Translation{JobID: 5, Status: TranslationDone, Title: "机翻标题", Body: "译文第一段\n\n译文第二段"}
+	repo.pending[pending.ID] = item
+	repo.mu.Unlock()
+
+	if err := svc.Approve(ctx, pending.ID, 9001, 0); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	feed, err := repo.ListFeed(ctx, FeedQuery{Limit: 10})
+	if err != nil || len(feed) != 1 {
+		t.Fatalf("expected one published article, got %+v %v", feed, err)
+	}
+	got := feed[0]
+	if got.Title != title || got.OriginalTitle != "" {
+		t.Fatalf("expected the reviewer's title kept, got %+v", got)
+	}
+	if got.Content != "译文第一段\n\n译文第二段" || got.Summary != "译文第一段" || got.OriginalContent != "Original body" {
+		t.Fatalf("expected the translated body and summary published, got %+v", got)
+	}
+}
+
+func TestApprove_IgnoresUnfinishedTranslation(t *testing.T) {
+	repo := newFakeReviewRepo()
+	rec := repo.pending[101]
+	rec.Translation = &Translation{JobID: 5, Status: "failed", Error: "timeout"}
+	repo.pending[101] = rec
+
+	svc := NewService(repo)
+	if err := svc.Approve(context.Background(), 101, 9001, 0); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if got := repo.publishedRec; got.Title != "news-a" || got.OriginalTitle != "" {
+		t.Fatalf("expected original published, got %+v", got)
+	}
+}
+
+func TestReviewStateMachine(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	svc := NewService(repo)
+
+	if err := svc.Reject(ctx, 1, 9001, 0, "  "); !errors.Is(err, ErrRejectReasonRequired) {
+		t.Fatalf("expected reason required, got %v", err)
+	}
+	if err := svc.Reopen(ctx, 1, 9001, 0); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected pending item not to reopen, got %v", err)
+	}
+	if err := svc.Reject(ctx, 1, 9001, 0, "标题党"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	rec, _ := repo.GetPending(ctx, 1)
+	if rec.Status != StatusRejected || rec.RejectReason != "标题党" || rec.ReviewerID != 9001 {
+		t.Fatalf("unexpected rejected item: %+v", rec)
+	}
+	if err := svc.Approve(ctx, 1, 9001, 0); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected rejected item not to be approved, got %v", err)
+	}
+	if _, err := svc.Edit(ctx, 1, 9001, 0, PendingEdit{}); !errors.Is(err, ErrNotEditable) {
+		t.Fatalf("expected rejected item not to be editable, got %v", err)
+	}
+
+	rejected, err := svc.ListPending(ctx, PendingFilter{Status: StatusRejected})
+	if err != nil || len(rejected) != 1 {
+		t.Fatalf("expected one rejected item, got %d (%v)", len(rejected), err)
+	}
+	if queue, _ := svc.ListPending(ctx, PendingFilter{}); len(queue) != 0 {
+		t.Fatalf("expected empty queue, got %+v", queue)
+	}
+
+	if err := svc.Reopen(ctx, 1, 9001, 0); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if err := svc.Approve(ctx, 1, 9001, 0); err != nil {
+		t.Fatalf("approve reopened item: %v", err)
+	}
+	if err := svc.Reopen(ctx, 1, 9001, 0); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected approved item not to reopen, got %v", err)
+	}
+}
+
+func TestEdit_UpdatesFieldsBeforeApproval(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	svc := NewService(repo)
+
+	empty := " "
+	if _, err := svc.Edit(ctx, 1, 9001, 0, PendingEdit{Title: &empty}); !errors.Is(err, ErrInvalidEdit) {
+		t.Fatalf("expected empty title rejected, got %v", err)
+	}
+	title, video := " UFC 300 全卡前瞻 ", "https://example.com/v.mp4"
+	item, err := svc.Edit(ctx, 1, 9001, 0, PendingEdit{Title: &title, VideoURL: &video})
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if item.Title != "UFC 300 全卡前瞻" || item.VideoURL != video || item.Summary != "主赛阵容与战术看点速览" {
+		t.Fatalf("unexpected edited item: %+v", item)
+	}
+
+	if _, err := svc.AddNote(ctx, 1, 9001, ""); !errors.Is(err, ErrEmptyNote) {
+		t.Fatalf("expected empty note rejected, got %v", err)
+	}
+	if _, err := svc.AddNote(ctx, 1, 9001, "等官方确认再发"); err != nil {
+		t.Fatalf("add note: %v", err)
+	}
+	if err := svc.Approve(ctx, 1, 9001, 0); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	published, _ := repo.ListPublished(ctx)
+	if len(published) != 1 || published[0].Title != "UFC 300 全卡前瞻" || published[0].VideoURL != video {
+		t.Fatalf("expected edits published, got %+v", published)
+	}
+}
+
+func TestClaimAndVersionConflicts(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	svc := NewService(repo)
+
+	if _, err := svc.Claim(ctx, 1, 0); !errors.Is(err, ErrReviewerRequired) {
+		t.Fatalf("expected reviewer required, got %v", err)
+	}
+	item, err := svc.Claim(ctx, 1, 7)
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if item.ClaimedBy != 7 || item.ClaimedUntil == nil || item.Version != 1 {
+		t.Fatalf("unexpected claimed item: %+v", item)
+	}
+	if _, err := svc.Claim(ctx, 1, 8); !errors.Is(err, ErrClaimedByOther) {
+		t.Fatalf("expected claim conflict, got %v", err)
+	}
+	if err := svc.Approve(ctx, 1, 8, 0); !errors.Is(err, ErrClaimedByOther) {
+		t.Fatalf("expected other reviewer blocked, got %v", err)
+	}
+
+	title := "改过的标题"
+	if _, err := svc.Edit(ctx, 1, 7, 1, PendingEdit{Title: &title}); err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if err := svc.Approve(ctx, 1, 7, 1); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected stale version rejected, got %v", err)
+	}
+	if err := svc.Approve(ctx, 1, 7, 2); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	rec, _ := repo.GetPending(ctx, 1)
+	if rec.Status != StatusApproved || rec.ClaimedBy != 0 || rec.Version != 3 {
+		t.Fatalf("expected approval to bump version and release claim, got %+v", rec)
+	}
+}
+
+func TestClaim_ExpiredClaimCanBeTakenOver(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	svc := NewService(repo)
+	svc.now = func() time.Time { return time.Now().Add(-2 * DefaultClaimTTL) }
+	if _, err := svc.Claim(ctx, 1, 7); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+
+	svc.now = time.Now
+	item, err := svc.Claim(ctx, 1, 8)
+	if err != nil {
+		t.Fatalf("expected expired claim to be taken over, got %v", err)
+	}
+	if item.ClaimedBy != 8 {
+		t.Fatalf("expected new claimant, got %+v", item)
+	}
+	if err := svc.Release(ctx, 1, 7); !errors.Is(err, ErrClaimedByOther) {
+		t.Fatalf("expected previous claimant unable to release, got %v", err)
+	}
+	if err := svc.Release(ctx, 1, 8); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+}
+
+func TestApproveScheduled_HoldsArticleUntilRelease(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	cache := &fakeArticlesCache{}
+	svc := NewService(repo, cache)
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	publishAt := now.Add(time.Hour)
+	embargo := now.Add(2 * time.Hour)
+	if err := svc.ApproveScheduled(ctx, 1, 7, 0, Schedule{PublishAt: &publishAt, EmbargoUntil: &embargo}); err != nil {
+		t.Fatalf("approve scheduled: %v", err)
+	}
+	if cache.invalidated {
+		t.Fatalf("expected no cache invalidation before release")
+	}
+	published, _ := repo.ListPublished(ctx)
+	scheduled, _ := svc.ListScheduled(ctx)
+	if len(published) != 0 || len(scheduled) != 1 {
+		t.Fatalf("expected article held back, got %d published and %d scheduled", len(published), len(scheduled))
+	}
+	if !scheduled[0].PublishAt.Equal(embargo) {
+		t.Fatalf("expected embargo to win over earlier publish time, got %v", scheduled[0].PublishAt)
+	}
+
+	now = publishAt
+	if released, err := svc.ReleaseDue(ctx); err != nil || released != 0 {
+		t.Fatalf("expected nothing released under embargo, got %d, %v", released, err)
+	}
+	now = embargo
+	if released, err := svc.ReleaseDue(ctx); err != nil || released != 1 {
+		t.Fatalf("expected one release, got %d, %v", released, err)
+	}
+	if !cache.invalidated {
+		t.Fatalf("expected cache invalidation on release")
+	}
+	published, _ = repo.ListPublished(ctx)
+	if len(published) != 1 || !published[0].PublishedAt.Equal(embargo) {
+		t.Fatalf("expected article published at embargo time, got %+v", published)
+	}
+}
+
+func TestApproveScheduled_PastTimePublishesNow(t *testing.T) {
+	repo := newFakeReviewRepo()
+	svc := NewService(repo)
+	past := time.Now().Add(-time.Hour)
+	if err := svc.ApproveScheduled(context.Background(), 101, 7, 0, Schedule{PublishAt: &past}); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if repo.publishedRec.PublishAt != nil {
+		t.Fatalf("expected past publish time to publish right away")
+	}
+
+	future := time.Now().Add(time.Hour)
+	if err := svc.ApproveScheduled(context.Background(), 101, 7, 0, Schedule{PublishAt: &future}); !errors.Is(err, ErrSchedulingNotEnabled) {
+		t.Fatalf("expected scheduling unsupported, got %v", err)
+	}
+}
+
+func TestSetPendingEntity_ValidatesAndStores(t *testing.T) {
+	repo := NewMemoryRepository()
+	svc := NewService(repo)
+
+	_, err := svc.SetPendingEntity(context.Background(), 1, EntityLinkInput{EntityType: "venue", EntityID: 1})
+	if !errors.Is(err, ErrInvalidEntityLink) {
+		t.Fatalf("expected invalid entity link, got %v", err)
+	}
+	if _, err := NewService(newFakeReviewRepo()).SetPendingEntity(context.Background(), 101, EntityLinkInput{EntityType: linking.EntityFighter, EntityID: 1}); !errors.Is(err, ErrEntityLinksNotEnabled) {
+		t.Fatalf("expected unsupported repository error, got %v", err)
+	}
+
+	link, err := svc.SetPendingEntity(context.Background(), 1, EntityLinkInput{EntityType: linking.EntityFighter, EntityID: 7})
+	if err != nil {
+		t.Fatalf("set entity: %v", err)
+	}
+	if link.Status != linking.StatusConfirmed {
+		t.Fatalf("expected manual link to default to confirmed, got %+v", link)
+	}
+	if _, err := svc.SetPendingEntity(context.Background(), 1, EntityLinkInput{EntityType: linking.EntityFighter, EntityID: 7, Status: linking.StatusRejected}); err != nil {
+		t.Fatalf("reject entity: %v", err)
+	}
+	rec, _ := repo.GetPending(context.Background(), 1)
+	if len(rec.Entities) != 1 || rec.Entities[0].Status != linking.StatusRejected {
+		t.Fatalf("expected one rejected link, got %+v", rec.Entities)
+	}
+}
+
+func TestSetPendingTags_NormalizesAndCarriesTagsOnApproval(t *testing.T) {
+	repo := NewMemoryRepository()
+	svc := NewService(repo)
+	ctx := context.Background()
+
+	if _, err := svc.SetPendingTags(ctx, 1, []string{"bad tag"}); !errors.Is(err, taxonomy.ErrInvalidTag) {
+		t.Fatalf("expected invalid tag, got %v", err)
+	}
+	if _, err := NewService(newFakeReviewRepo()).SetPendingTags(ctx, 101, []string{"news"}); !errors.Is(err, ErrTagsNotEnabled) {
+		t.Fatalf("expected unsupported repository error, got %v", err)
+	}
+
+	tags, err := svc.SetPendingTags(ctx, 1, []string{" News", "ufc", "news"})
+	if err != nil {
+		t.Fatalf("set tags: %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "news" || tags[1] != "ufc" {
+		t.Fatalf("expected normalized tags, got %v", tags)
+	}
+	if err := svc.Approve(ctx, 1, 7, 0); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	published, _ := repo.ListPublished(ctx)
+	if len(published) != 1 || len(published[0].Tags) != 2 {
+		t.Fatalf("expected tags published with the article, got %+v", published)
+	}
+	if _, err := svc.SetPendingTags(ctx, 1, []string{"results"}); !errors.Is(err, ErrNotEditable) {
+		t.Fatalf("expected approved item to keep its tags, got %v", err)
+	}
+}
+
+// racingEditRepo commits an edit right after the approve reads the article.
+type racingEditRepo struct {
+	*MemoryRepository
+	raced bool
+}
+
+func (r *racingEditRepo) GetPending(ctx context.Context, pendingID int64) (PendingArticle, error) {
+	rec, err := r.MemoryRepository.GetPending(ctx, pendingID)
+	if err == nil && !r.raced {
+		r.raced = true
+		title := "并发改过的标题"
+		err = r.UpdatePending(ctx, pendingID, PendingEdit{Title: &title}, Guard{Status: StatusPending})
+	}
+	return rec, err
+}
+
+func TestApprove_WithoutVersionRejectsEditCommittedMeanwhile(t *testing.T) {
+	ctx := context.Background()
+	repo := &racingEditRepo{MemoryRepository: NewMemoryRepository()}
+	svc := NewService(repo)
+	pending, err := repo.CreatePending(ctx, PendingArticle{Title: "news", Summary: "summary", SourceURL: "https://example.com/race"})
+	if err != nil {
+		t.Fatalf("create pending: %v", err)
+	}
+
+	if err := svc.Approve(ctx, pending.ID, 7, 0); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected the stale snapshot refused, got %v", err)
+	}
+	if err := svc.Approve(ctx, pending.ID, 7, 0); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	feed, _ := repo.ListFeed(ctx, FeedQuery{Limit: 10})
+	if len(feed) != 1 || feed[0].Title != "并发改过的标题" {
+		t.Fatalf("expected the edited title published, got %+v", feed)
+	}
+}
@@ -0,0 +1,118 @@
+package revision
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdminRevisionRoutes(r *gin.Engine, svc *Service) {
+	r.PUT("/admin/articles/:id", func(c *gin.Context) {
+		articleID, ok := articleIDFromPath(c)
+		if !ok {
+			return
+		}
+		var edit Edit
+		if err := c.ShouldBindJSON(&edit); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		rev, err := svc.Edit(c.Request.Context(), articleID, c.GetInt64("admin_user_id"), edit)
+		if err != nil {
+			writeRevisionError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, rev)
+	})
+
+	r.GET("/admin/articles/:id/revisions", func(c *gin.Context) {
+		articleID, ok := articleIDFromPath(c)
+		if !ok {
+			return
+		}
+
+		items, err := svc.List(c.Request.Context(), articleID)
+		if err != nil {
+			writeRevisionError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": items})
+	})
+
+	r.GET("/admin/articles/:id/revisions/:rev/diff", func(c *gin.Context) {
+		articleID, ok := articleIDFromPath(c)
+		if !ok {
+			return
+		}
+		revisionID, ok := revisionIDFromPath(c)
+		if !ok {
+			return
+		}
+		var againstID int64
+		if raw := c.Query("against"); raw != "" {
+			parsed, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil || parsed <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid against revision"})
+				return
+			}
+			againstID = parsed
+		}
+
+		diff, err := svc.Diff(c.Request.Context(), articleID, revisionID, againstID)
+		if err != nil {
+			writeRevisionError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, diff)
+	})
+
+	r.POST("/admin/articles/:id/revisions/:rev/revert", func(c *gin.Context) {
+		articleID, ok := articleIDFromPath(c)
+		if !ok {
+			return
+		}
+		revisionID, ok := revisionIDFromPath(c)
+		if !ok {
+			return
+		}
+
+		rev, err := svc.Revert(c.Request.Context(), articleID, revisionID, c.GetInt64("admin_user_id"))
+		if err != nil {
+			writeRevisionError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, rev)
+	})
+}
+
+func articleIDFromPath(c *gin.Context) (int64, bool) {
+	articleID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || articleID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid article id"})
+		return 0, false
+	}
+	return articleID, true
+}
+
+func revisionIDFromPath(c *gin.Context) (int64, bool) {
+	revisionID, err := strconv.ParseInt(c.Param("rev"), 10, 64)
+	if err != nil || revisionID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision id"})
+		return 0, false
+	}
+	return revisionID, true
+}
+
+func writeRevisionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidEdit), errors.Is(err, ErrNoChanges):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrArticleNotFound), errors.Is(err, ErrRevisionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,45 @@
+package revision
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminRevisionHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("admin_user_id", int64(7))
+	})
+	RegisterAdminRevisionRoutes(r, NewService(newFakeRepo(), nil))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/admin/articles/1", strings.NewReader(`{"title":"主赛改期"}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"editor_id":7`) {
+		t.Fatalf("expected edit recorded for the signed-in editor, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/articles/1/revisions/2/diff", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"field":"title"`) {
+		t.Fatalf("expected title diff, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/articles/1/revisions/1/revert", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"action":"reverted"`) {
+		t.Fatalf("expected revert, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/articles/2/revisions", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown article, got %d", w.Code)
+	}
+}
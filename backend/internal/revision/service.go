@@ -0,0 +1,224 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// ActionOriginal is the baseline recorded for articles published before revisions existed.
+	ActionOriginal  = "original"
+	ActionPublished = "published"
+	ActionReleased  = "released"
+	ActionEdited    = "edited"
+	ActionOfflined  = "offlined"
+	ActionReverted  = "reverted"
+)
+
+var (
+	ErrArticleNotFound  = errors.New("article not found")
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrInvalidEdit      = errors.New("article title cannot be empty")
+	ErrNoChanges        = errors.New("nothing to change")
+)
+
+// Snapshot is what a published article looked like after one change.
+type Snapshot struct {
+	Title    string `json:"title"`
+	Summary  string `json:"summary"`
+	Content  string `json:"content"`
+	CoverURL string `json:"cover_url"`
+	VideoURL string `json:"video_url"`
+	Status   string `json:"status"`
+}
+
+// Revision records who changed an article, how, and the result.
+type Revision struct {
+	ID        int64     `json:"id"`
+	ArticleID int64     `json:"article_id"`
+	EditorID  int64     `json:"editor_id,omitempty"`
+	Action    string    `json:"action"`
+	Snapshot  Snapshot  `json:"snapshot"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Edit changes a published article; nil fields are left as they are.
+type Edit struct {
+	Title    *string `json:"title"`
+	Summary  *string `json:"summary"`
+	Content  *string `json:"content"`
+	CoverURL *string `json:"cover_url"`
+	VideoURL *string `json:"video_url"`
+}
+
+// Change is one field that differs between two revisions.
+type Change struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Diff compares a revision with an earlier one. Against is nil for an article's first revision.
+type Diff struct {
+	Revision Revision  `json:"revision"`
+	Against  *Revision `json:"against,omitempty"`
+	Changes  []Change  `json:"changes"`
+}
+
+type Repository interface {
+	// Current returns the article as it is now, or ErrArticleNotFound.
+	Current(ctx context.Context, articleID int64) (Snapshot, error)
+	// Save locks the article, builds its next state from the locked current one with next,
+	// writes it and records it as a revision in one transaction; an error from next aborts
+	// the save. An article without history first gets an ActionOriginal revision of its
+	// previous state.
+	Save(ctx context.Context, articleID int64, next func(current Snapshot) (Snapshot, error), editorID int64, action string) (Revision, error)
+	// List returns an article's revisions, newest first.
+	List(ctx context.Context, articleID int64) ([]Revision, error)
+}
+
+type CacheInvalidator interface {
+	InvalidateArticlesList(ctx context.Context) error
+}
+
+type Service struct {
+	repo  Repository
+	cache CacheInvalidator
+}
+
+func NewService(repo Repository, cache CacheInvalidator) *Service {
+	return &Service{repo: repo, cache: cache}
+}
+
+// List returns an article's revisions, newest first.
+func (s *Service) List(ctx context.Context, articleID int64) ([]Revision, error) {
+	if _, err := s.repo.Current(ctx, articleID); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, articleID)
+}
+
+// Edit changes a published article and records the result as a revision by editorID. The
+// edit is applied to the article as it is when saved, so concurrent edits of other fields
+// are kept.
+func (s *Service) Edit(ctx context.Context, articleID int64, editorID int64, edit Edit) (Revision, error) {
+	if edit.Title != nil && strings.TrimSpace(*edit.Title) == "" {
+		return Revision{}, ErrInvalidEdit
+	}
+	return s.save(ctx, articleID, func(current Snapshot) Snapshot {
+		return applyEdit(current, edit)
+	}, editorID, ActionEdited)
+}
+
+// Revert restores an article's text and media to an earlier revision. The status is kept, so
+// reverting never brings back an article that was taken offline.
+func (s *Service) Revert(ctx context.Context, articleID int64, revisionID int64, editorID int64) (Revision, error) {
+	target, _, err := s.find(ctx, articleID, revisionID)
+	if err != nil {
+		return Revision{}, err
+	}
+	return s.save(ctx, articleID, func(current Snapshot) Snapshot {
+		next := target.Snapshot
+		next.Status = current.Status
+		return next
+	}, editorID, ActionReverted)
+}
+
+// Diff compares a revision with againstID, or with the revision before it when againstID is 0.
+func (s *Service) Diff(ctx context.Context, articleID int64, revisionID int64, againstID int64) (Diff, error) {
+	rev, previous, err := s.find(ctx, articleID, revisionID)
+	if err != nil {
+		return Diff{}, err
+	}
+	if againstID > 0 {
+		against, _, err := s.find(ctx, articleID, againstID)
+		if err != nil {
+			return Diff{}, err
+		}
+		previous = &against
+	}
+
+	before := Snapshot{}
+	if previous != nil {
+		before = previous.Snapshot
+	}
+	return Diff{Revision: rev, Against: previous, Changes: Compare(before, rev.Snapshot)}, nil
+}
+
+// Compare lists the fields that differ between two snapshots.
+func Compare(before Snapshot, after Snapshot) []Change {
+	fields := []struct {
+		name          string
+		before, after string
+	}{
+		{"title", before.Title, after.Title},
+		{"summary", before.Summary, after.Summary},
+		{"content", before.Content, after.Content},
+		{"cover_url", before.CoverURL, after.CoverURL},
+		{"video_url", before.VideoURL, after.VideoURL},
+		{"status", before.Status, after.Status},
+	}
+	changes := make([]Change, 0, len(fields))
+	for _, field := range fields {
+		if field.before != field.after {
+			changes = append(changes, Change{Field: field.name, Before: field.before, After: field.after})
+		}
+	}
+	return changes
+}
+
+func (s *Service) save(ctx context.Context, articleID int64, change func(current Snapshot) Snapshot, editorID int64, action string) (Revision, error) {
+	rev, err := s.repo.Save(ctx, articleID, func(current Snapshot) (Snapshot, error) {
+		next := change(current)
+		if len(Compare(current, next)) == 0 {
+			return Snapshot{}, ErrNoChanges
+		}
+		return next, nil
+	}, editorID, action)
+	if err != nil {
+		return Revision{}, err
+	}
+	if s.cache != nil {
+		_ = s.cache.InvalidateArticlesList(ctx)
+	}
+	return rev, nil
+}
+
+// find returns a revision of the article and the one recorded just before it, if any.
+func (s *Service) find(ctx context.Context, articleID int64, revisionID int64) (Revision, *Revision, error) {
+	revisions, err := s.repo.List(ctx, articleID)
+	if err != nil {
+		return Revision{}, nil, err
+	}
+	for idx, rev := range revisions {
+		if rev.ID != revisionID {
+			continue
+		}
+		if idx+1 < len(revisions) {
+			return rev, &revisions[idx+1], nil
+		}
+		return rev, nil, nil
+	}
+	return Revision{}, nil, ErrRevisionNotFound
+}
+
+func applyEdit(current Snapshot, edit Edit) Snapshot {
+	next := current
+	for _, field := range []struct {
+		value  *string
+		target *string
+	}{
+		{edit.Title, &next.Title},
+		{edit.Summary, &next.Summary},
+		{edit.Content, &next.Content},
+		{edit.CoverURL, &next.CoverURL},
+		{edit.VideoURL, &next.VideoURL},
+	} {
+		if field.value != nil {
+			*field.target = strings.TrimSpace(*field.value)
+		}
+	}
+	return next
+}
@@ -0,0 +1,157 @@
+package revision
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type fakeRepo struct {
+	articles  map[int64]Snapshot
+	revisions []Revision
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{articles: map[int64]Snapshot{
+		1: {Title: "UFC 300 主赛前瞻", Summary: "看点速览", Content: "正文", Status: "published"},
+	}}
+}
+
+func (r *fakeRepo) Current(_ context.Context, articleID int64) (Snapshot, error) {
+	current, ok := r.articles[articleID]
+	if !ok {
+		return Snapshot{}, ErrArticleNotFound
+	}
+	return current, nil
+}
+
+func (r *fakeRepo) Save(_ context.Context, articleID int64, build func(Snapshot) (Snapshot, error), editorID int64, action string) (Revision, error) {
+	current, ok := r.articles[articleID]
+	if !ok {
+		return Revision{}, ErrArticleNotFound
+	}
+	next, err := build(current)
+	if err != nil {
+		return Revision{}, err
+	}
+	if len(r.revisions) == 0 {
+		r.append(articleID, r.articles[articleID], 0, ActionOriginal)
+	}
+	r.articles[articleID] = next
+	return r.append(articleID, next, editorID, action), nil
+}
+
+func (r *fakeRepo) append(articleID int64, snapshot Snapshot, editorID int64, action string) Revision {
+	rev := Revision{ID: int64(len(r.revisions) + 1), ArticleID: articleID, EditorID: editorID, Action: action, Snapshot: snapshot}
+	r.revisions = append(r.revisions, rev)
+	return rev
+}
+
+func (r *fakeRepo) List(_ context.Context, articleID int64) ([]Revision, error) {
+	items := make([]Revision, 0, len(r.revisions))
+	for idx := len(r.revisions) - 1; idx >= 0; idx-- {
+		if r.revisions[idx].ArticleID == articleID {
+			items = append(items, r.revisions[idx])
+		}
+	}
+	return items, nil
+}
+
+type fakeCache struct {
+	invalidations int
+}
+
+func (c *fakeCache) InvalidateArticlesList(context.Context) error {
+	c.invalidations++
+	return nil
+}
+
+func TestEditDiffAndRevert(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeRepo()
+	cache := &fakeCache{}
+	svc := NewService(repo, cache)
+
+	title := " 主赛改期 "
+	edited, err := svc.Edit(ctx, 1, 7, Edit{Title: &title})
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if edited.Action != ActionEdited || edited.EditorID != 7 || edited.Snapshot.Title != "主赛改期" {
+		t.Fatalf("unexpected revision: %+v", edited)
+	}
+
+	diff, err := svc.Diff(ctx, 1, edited.ID, 0)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if diff.Against == nil || diff.Against.Action != ActionOriginal {
+		t.Fatalf("expected diff against the original, got %+v", diff.Against)
+	}
+	if len(diff.Changes) != 1 || diff.Changes[0] != (Change{Field: "title", Before: "UFC 300 主赛前瞻", After: "主赛改期"}) {
+		t.Fatalf("unexpected changes: %+v", diff.Changes)
+	}
+
+	repo.articles[1] = Snapshot{Title: "主赛改期", Summary: "看点速览", Content: "正文", Status: "offline"}
+	reverted, err := svc.Revert(ctx, 1, diff.Against.ID, 8)
+	if err != nil {
+		t.Fatalf("revert: %v", err)
+	}
+	if reverted.Snapshot.Title != "UFC 300 主赛前瞻" || reverted.Snapshot.Status != "offline" || reverted.EditorID != 8 {
+		t.Fatalf("expected title restored and status kept, got %+v", reverted)
+	}
+	if cache.invalidations != 2 {
+		t.Fatalf("expected cache invalidated per change, got %d", cache.invalidations)
+	}
+}
+
+func TestEditAndRevertErrors(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(newFakeRepo(), nil)
+
+	empty := " "
+	if _, err := svc.Edit(ctx, 1, 7, Edit{Title: &empty}); !errors.Is(err, ErrInvalidEdit) {
+		t.Fatalf("expected invalid edit, got %v", err)
+	}
+	same := "UFC 300 主赛前瞻"
+	if _, err := svc.Edit(ctx, 1, 7, Edit{Title: &same}); !errors.Is(err, ErrNoChanges) {
+		t.Fatalf("expected no changes, got %v", err)
+	}
+	if _, err := svc.Edit(ctx, 2, 7, Edit{Title: &same}); !errors.Is(err, ErrArticleNotFound) {
+		t.Fatalf("expected article not found, got %v", err)
+	}
+	if _, err := svc.Revert(ctx, 1, 99, 7); !errors.Is(err, ErrRevisionNotFound) {
+		t.Fatalf("expected revision not found, got %v", err)
+	}
+}
+
+// racingRepo commits another editor's summary change just before the first save, like an
+// edit that lands between reading and writing the article.
+type racingRepo struct {
+	*fakeRepo
+	raced bool
+}
+
+func (r *racingRepo) Save(ctx context.Context, articleID int64, build func(Snapshot) (Snapshot, error), editorID int64, action string) (Revision, error) {
+	if !r.raced {
+		r.raced = true
+		current := r.articles[articleID]
+		current.Summary = "另一位编辑改的摘要"
+		r.articles[articleID] = current
+	}
+	return r.fakeRepo.Save(ctx, articleID, build, editorID, action)
+}
+
+func TestEdit_KeepsFieldsChangedByAConcurrentEdit(t *testing.T) {
+	repo := &racingRepo{fakeRepo: newFakeRepo()}
+	svc := NewService(repo, nil)
+
+	title := "主赛改期"
+	edited, err := svc.Edit(context.Background(), 1, 7, Edit{Title: &title})
+	if err != nil {
+		t.Fatalf("edit: %v", err)
+	}
+	if edited.Snapshot.Title != "主赛改期" || edited.Snapshot.Summary != "另一位编辑改的摘要" {
+		t.Fatalf("expected the title edit applied on top of the other edit, got %+v", edited.Snapshot)
+	}
+}
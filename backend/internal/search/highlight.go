@@ -0,0 +1,101 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// snippetRunes is how much body text a snippet shows around the first match.
+const snippetRunes = 80
+
+// Highlight escapes text for HTML and wraps every occurrence of the terms in <em>.
+func Highlight(text string, terms []string) string {
+	runes := []rune(text)
+	return markup(runes, matchMask(runes, terms))
+}
+
+// Snippet cuts a window of the body around its first match and highlights it. A body
+// without matches yields its opening words, and an empty body an empty snippet.
+func Snippet(body string, terms []string) string {
+	runes := []rune(strings.Join(strings.Fields(body), " "))
+	if len(runes) == 0 {
+		return ""
+	}
+	mask := matchMask(runes, terms)
+
+	first := 0
+	for i, hit := range mask {
+		if hit {
+			first = i
+			break
+		}
+	}
+	start := first - snippetRunes/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetRunes
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	out := markup(runes[start:end], mask[start:end])
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(runes) {
+		out += "…"
+	}
+	return out
+}
+
+func matchMask(runes []rune, terms []string) []bool {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	mask := make([]bool, len(runes))
+	for _, term := range terms {
+		needle := []rune(term)
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if !equalRunes(lower[i:i+len(needle)], needle) {
+				continue
+			}
+			for j := range needle {
+				mask[i+j] = true
+			}
+		}
+	}
+	return mask
+}
+
+func equalRunes(a []rune, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func markup(runes []rune, mask []bool) string {
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j < len(runes) && mask[j] == mask[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if mask[i] {
+			b.WriteString("<em>" + segment + "</em>")
+		} else {
+			b.WriteString(segment)
+		}
+		i = j
+	}
+	return b.String()
+}
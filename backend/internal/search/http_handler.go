@@ -0,0 +1,40 @@
+package search
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterSearchRoutes serves one search box over fighters, events and published articles.
+func RegisterSearchRoutes(r *gin.Engine, svc *Service) {
+	r.GET("/api/search", func(c *gin.Context) {
+		limit := 0
+		if raw := c.Query("limit"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+				return
+			}
+			limit = parsed
+		}
+		var types []string
+		if raw := strings.TrimSpace(c.Query("type")); raw != "" {
+			types = strings.Split(raw, ",")
+		}
+
+		items, err := svc.Search(c.Request.Context(), c.Query("q"), types, limit)
+		if err != nil {
+			if errors.Is(err, ErrEmptyQuery) || errors.Is(err, ErrInvalidType) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": items})
+	})
+}
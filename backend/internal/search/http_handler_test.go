@@ -0,0 +1,42 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSearchHandler_ReturnsTypedResults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	RegisterSearchRoutes(r, NewService(seededIndex()))
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		return w
+	}
+
+	w := get("/api/search?q=pereira&type=fighter,event")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var payload struct {
+		Items []Result `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(payload.Items) != 2 || payload.Items[0].Type != TypeFighter || payload.Items[1].Type != TypeEvent {
+		t.Fatalf("expected fighter then event, got %+v", payload.Items)
+	}
+
+	for _, url := range []string{"/api/search", "/api/search?q=pereira&type=venue", "/api/search?q=pereira&limit=x"} {
+		if w := get(url); w.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %s, got %d", url, w.Code)
+		}
+	}
+}
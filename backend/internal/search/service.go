@@ -0,0 +1,277 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	TypeFighter = "fighter"
+	TypeEvent   = "event"
+	TypeArticle = "article"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 50
+
+	maxTerms     = 8
+	maxTermRunes = 32
+)
+
+var (
+	ErrEmptyQuery  = errors.New("search query is required")
+	ErrInvalidType = errors.New("type must be fighter, event or article")
+)
+
+// Query is one search request after the terms have been split out.
+type Query struct {
+	Terms []string
+	// Types limits the search to these result types; empty searches all of them.
+	Types []string
+	// Limit caps the hits per type.
+	Limit int
+}
+
+// Wants reports whether the query covers the result type.
+func (q Query) Wants(kind string) bool {
+	if len(q.Types) == 0 {
+		return true
+	}
+	for _, want := range q.Types {
+		if want == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Hit is one document an index matched. Scores only compare hits of the same type.
+type Hit struct {
+	Type     string
+	ID       int64
+	Title    string
+	Subtitle string
+	Body     string
+	Score    float64
+}
+
+// Index finds documents matching every query term. MySQL FULLTEXT backs it in production;
+// an embedded index can stand in by implementing the same method.
+type Index interface {
+	Search(ctx context.Context, q Query) ([]Hit, error)
+}
+
+// Result is one ranked search result. Highlight and Snippet are HTML-escaped with the
+// matched terms wrapped in <em>.
+type Result struct {
+	Type      string  `json:"type"`
+	ID        int64   `json:"id"`
+	Title     string  `json:"title"`
+	Subtitle  string  `json:"subtitle,omitempty"`
+	Highlight string  `json:"highlight"`
+	Snippet   string  `json:"snippet,omitempty"`
+	Score     float64 `json:"score"`
+}
+
+type Service struct {
+	index Index
+}
+
+func NewService(index Index) *Service {
+	return &Service{index: index}
+}
+
+// Search runs the query against the index and merges fighters, events and articles into one
+// ranking. Index scores are normalized per type first, since FULLTEXT relevance is only
+// comparable within a table, and hits whose title carries every term rank above body matches.
+func (s *Service) Search(ctx context.Context, text string, types []string, limit int) ([]Result, error) {
+	terms := Terms(text)
+	if len(terms) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	for _, kind := range types {
+		if kind != TypeFighter && kind != TypeEvent && kind != TypeArticle {
+			return nil, ErrInvalidType
+		}
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	hits, err := s.index.Search(ctx, Query{Terms: terms, Types: types, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	best := map[string]float64{}
+	for _, hit := range hits {
+		if hit.Score > best[hit.Type] {
+			best[hit.Type] = hit.Score
+		}
+	}
+	results := make([]Result, 0, len(hits))
+	for _, hit := range hits {
+		score := 0.0
+		if best[hit.Type] > 0 {
+			score = hit.Score / best[hit.Type]
+		}
+		if containsAll(hit.Title, terms) {
+			score++
+		}
+		results = append(results, Result{
+			Type:      hit.Type,
+			ID:        hit.ID,
+			Title:     hit.Title,
+			Subtitle:  hit.Subtitle,
+			Highlight: Highlight(hit.Title, terms),
+			Snippet:   Snippet(hit.Body, terms),
+			Score:     score,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Type != results[j].Type {
+			return typeRank(results[i].Type) < typeRank(results[j].Type)
+		}
+		return results[i].ID > results[j].ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// Terms splits a query into lowercase terms, dropping FULLTEXT boolean operators and
+// duplicates.
+func Terms(text string) []string {
+	cleaned := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`+-<>()~*"@`, r) {
+			return ' '
+		}
+		return r
+	}, strings.ToLower(text))
+
+	terms := make([]string, 0)
+	seen := map[string]bool{}
+	for _, term := range strings.Fields(cleaned) {
+		if utf8.RuneCountInString(term) > maxTermRunes {
+			term = string([]rune(term)[:maxTermRunes])
+		}
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		terms = append(terms, term)
+		if len(terms) == maxTerms {
+			break
+		}
+	}
+	return terms
+}
+
+func containsAll(text string, terms []string) bool {
+	text = strings.ToLower(text)
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func typeRank(kind string) int {
+	switch kind {
+	case TypeFighter:
+		return 0
+	case TypeEvent:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// MemoryIndex is an embedded index over documents added at runtime. It matches terms as
+// substrings like the ngram parser does, and scores title matches above body matches.
+type MemoryIndex struct {
+	mu   sync.RWMutex
+	docs map[string]map[int64]Hit
+}
+
+func NewMemoryIndex() *MemoryIndex {
+	return &MemoryIndex{docs: map[string]map[int64]Hit{}}
+}
+
+// Put adds or replaces one document; its Score is ignored.
+func (m *MemoryIndex) Put(doc Hit) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.docs[doc.Type] == nil {
+		m.docs[doc.Type] = map[int64]Hit{}
+	}
+	doc.Score = 0
+	m.docs[doc.Type][doc.ID] = doc
+}
+
+// Remove drops one document.
+func (m *MemoryIndex) Remove(kind string, id int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.docs[kind], id)
+}
+
+func (m *MemoryIndex) Search(_ context.Context, q Query) ([]Hit, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	hits := make([]Hit, 0)
+	for kind, docs := range m.docs {
+		if !q.Wants(kind) {
+			continue
+		}
+		matched := make([]Hit, 0)
+		for _, doc := range docs {
+			if score := memoryScore(doc, q.Terms); score > 0 {
+				doc.Score = score
+				matched = append(matched, doc)
+			}
+		}
+		sort.Slice(matched, func(i, j int) bool {
+			if matched[i].Score != matched[j].Score {
+				return matched[i].Score > matched[j].Score
+			}
+			return matched[i].ID > matched[j].ID
+		})
+		if q.Limit > 0 && len(matched) > q.Limit {
+			matched = matched[:q.Limit]
+		}
+		hits = append(hits, matched...)
+	}
+	return hits, nil
+}
+
+func memoryScore(doc Hit, terms []string) float64 {
+	title := strings.ToLower(doc.Title + " " + doc.Subtitle)
+	body := strings.ToLower(doc.Body)
+	score := 0.0
+	for _, term := range terms {
+		inTitle := strings.Count(title, term)
+		inBody := strings.Count(body, term)
+		if inTitle+inBody == 0 {
+			return 0
+		}
+		score += float64(2*inTitle + inBody)
+	}
+	return score
+}
@@ -0,0 +1,72 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func seededIndex() *MemoryIndex {
+	index := NewMemoryIndex()
+	index.Put(Hit{Type: TypeFighter, ID: 20, Title: "Alex Pereira", Subtitle: "亚历克斯 佩雷拉", Body: "Poatan · Light Heavyweight"})
+	index.Put(Hit{Type: TypeEvent, ID: 10, Title: "UFC 300: Pereira vs Hill", Subtitle: "UFC", Body: "T-Mobile Arena"})
+	index.Put(Hit{Type: TypeArticle, ID: 7, Title: "Title fight recap", Body: "Pereira knocked out Hill in the first round. Pereira stays champion."})
+	index.Put(Hit{Type: TypeArticle, ID: 8, Title: "佩雷拉卫冕成功", Body: "佩雷拉在第一回合击倒对手。"})
+	return index
+}
+
+func TestService_SearchRanksTitleMatchesAcrossTypes(t *testing.T) {
+	svc := NewService(seededIndex())
+
+	items, err := svc.Search(context.Background(), "  PEREIRA ", nil, 0)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("expected 3 results, got %+v", items)
+	}
+	if items[0].Type != TypeFighter || items[1].Type != TypeEvent || items[2].Type != TypeArticle {
+		t.Fatalf("expected title matches first, fighters before events, got %+v", items)
+	}
+	if items[0].Highlight != "Alex <em>Pereira</em>" {
+		t.Fatalf("unexpected highlight %q", items[0].Highlight)
+	}
+	if items[2].Snippet != "<em>Pereira</em> knocked out Hill in the first round. <em>Pereira</em> stays champion." {
+		t.Fatalf("unexpected snippet %q", items[2].Snippet)
+	}
+
+	items, err = svc.Search(context.Background(), "佩雷拉", []string{TypeArticle}, 0)
+	if err != nil || len(items) != 1 || items[0].ID != 8 || items[0].Highlight != "<em>佩雷拉</em>卫冕成功" {
+		t.Fatalf("expected the chinese article only, got %+v %v", items, err)
+	}
+
+	items, _ = svc.Search(context.Background(), "pereira hill", nil, 1)
+	if len(items) != 1 || items[0].Type != TypeEvent {
+		t.Fatalf("expected every term required and the limit applied, got %+v", items)
+	}
+}
+
+func TestService_SearchRejectsBadQueries(t *testing.T) {
+	svc := NewService(seededIndex())
+
+	if _, err := svc.Search(context.Background(), ` + "" ~ `, nil, 0); !errors.Is(err, ErrEmptyQuery) {
+		t.Fatalf("expected empty query error, got %v", err)
+	}
+	if _, err := svc.Search(context.Background(), "pereira", []string{"venue"}, 0); !errors.Is(err, ErrInvalidType) {
+		t.Fatalf("expected invalid type error, got %v", err)
+	}
+}
+
+func TestSnippet_CutsAroundFirstMatchAndEscapes(t *testing.T) {
+	body := "Intro lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor incididunt " +
+		"<b>Pereira</b> & co ut labore et dolore magna aliqua ut enim ad minim veniam quis nostrud"
+
+	got := Snippet(body, []string{"pereira"})
+	want := "…empor incididunt &lt;b&gt;<em>Pereira</em>&lt;/b&gt; &amp; co ut labore et dolore magna aliqua ut enim ad…"
+	if got != want {
+		t.Fatalf("unexpected snippet\n got %q\nwant %q", got, want)
+	}
+	if got := Snippet("", []string{"x"}); got != "" {
+		t.Fatalf("expected empty snippet, got %q", got)
+	}
+}
@@ -10,32 +10,44 @@ import (
 )
 
 type createRequest struct {
-	Name            string `json:"name"`
-	SourceType      string `json:"source_type"`
-	Platform        string `json:"platform"`
-	AccountID       string `json:"account_id"`
-	SourceURL       string `json:"source_url"`
-	ParserKind      string `json:"parser_kind"`
-	Enabled         bool   `json:"enabled"`
-	IsBuiltin       bool   `json:"is_builtin"`
-	RightsDisplay   bool   `json:"rights_display"`
-	RightsPlayback  bool   `json:"rights_playback"`
-	RightsAISummary bool   `json:"rights_ai_summary"`
-	RightsExpiresAt string `json:"rights_expires_at"`
-	RightsProofURL  string `json:"rights_proof_url"`
+	Name              string `json:"name"`
+	SourceType        string `json:"source_type"`
+	Platform          string `json:"platform"`
+	AccountID         string `json:"account_id"`
+	SourceURL         string `json:"source_url"`
+	ParserKind        string `json:"parser_kind"`
+	Enabled           bool   `json:"enabled"`
+	IsBuiltin         bool   `json:"is_builtin"`
+	RightsDisplay     bool   `json:"rights_display"`
+	RightsPlayback    bool   `json:"rights_playback"`
+	RightsAISummary   bool   `json:"rights_ai_summary"`
+	RightsTranslation bool   `json:"rights_translation"`
+	RightsExpiresAt   string `json:"rights_expires_at"`
+	RightsProofURL    string `json:"rights_proof_url"`
+	CrawlInclude      string `json:"crawl_include_pattern"`
+	CrawlExclude      string `json:"crawl_exclude_pattern"`
+	CrawlMaxDepth     int    `json:"crawl_max_depth"`
+	CrawlMaxItems     int    `json:"crawl_max_items"`
+	FetchInterval     int    `json:"fetch_interval_sec"`
 }
 
 type updateRequest struct {
-	Name            string  `json:"name"`
-	Platform        string  `json:"platform"`
-	AccountID       *string `json:"account_id"`
-	SourceURL       string  `json:"source_url"`
-	ParserKind      string  `json:"parser_kind"`
-	RightsDisplay   *bool   `json:"rights_display"`
-	RightsPlayback  *bool   `json:"rights_playback"`
-	RightsAISummary *bool   `json:"rights_ai_summary"`
-	RightsExpiresAt *string `json:"rights_expires_at"`
-	RightsProofURL  *string `json:"rights_proof_url"`
+	Name              string  `json:"name"`
+	Platform          string  `json:"platform"`
+	AccountID         *string `json:"account_id"`
+	SourceURL         string  `json:"source_url"`
+	ParserKind        string  `json:"parser_kind"`
+	RightsDisplay     *bool   `json:"rights_display"`
+	RightsPlayback    *bool   `json:"rights_playback"`
+	RightsAISummary   *bool   `json:"rights_ai_summary"`
+	RightsTranslation *bool   `json:"rights_translation"`
+	RightsExpiresAt   *string `json:"rights_expires_at"`
+	RightsProofURL    *string `json:"rights_proof_url"`
+	CrawlInclude      *string `json:"crawl_include_pattern"`
+	CrawlExclude      *string `json:"crawl_exclude_pattern"`
+	CrawlMaxDepth     *int    `json:"crawl_max_depth"`
+	CrawlMaxItems     *int    `json:"crawl_max_items"`
+	FetchInterval     *int    `json:"fetch_interval_sec"`
 }
 
 func RegisterAdminSourceRoutes(r *gin.Engine, svc *Service) {
@@ -93,18 +105,24 @@ func RegisterAdminSourceRoutes(r *gin.Engine, svc *Service) {
 		}
 
 		input := CreateInput{
-			Name:            req.Name,
-			SourceType:      req.SourceType,
-			Platform:        req.Platform,
-			AccountID:       req.AccountID,
-			SourceURL:       req.SourceURL,
-			ParserKind:      req.ParserKind,
-			Enabled:         req.Enabled,
-			IsBuiltin:       req.IsBuiltin,
-			RightsDisplay:   req.RightsDisplay,
-			RightsPlayback:  req.RightsPlayback,
-			RightsAISummary: req.RightsAISummary,
-			RightsProofURL:  req.RightsProofURL,
+			Name:              req.Name,
+			SourceType:        req.SourceType,
+			Platform:          req.Platform,
+			AccountID:         req.AccountID,
+			SourceURL:         req.SourceURL,
+			ParserKind:        req.ParserKind,
+			Enabled:           req.Enabled,
+			IsBuiltin:         req.IsBuiltin,
+			RightsDisplay:     req.RightsDisplay,
+			RightsPlayback:    req.RightsPlayback,
+			RightsAISummary:   req.RightsAISummary,
+			RightsTranslation: req.RightsTranslation,
+			RightsProofURL:    req.RightsProofURL,
+			CrawlInclude:      req.CrawlInclude,
+			CrawlExclude:      req.CrawlExclude,
+			CrawlMaxDepth:     req.CrawlMaxDepth,
+			CrawlMaxItems:     req.CrawlMaxItems,
+			FetchInterval:     req.FetchInterval,
 		}
 		if req.RightsExpiresAt != "" {
 			expiresAt, err := time.Parse(time.RFC3339, req.RightsExpiresAt)
@@ -117,7 +135,7 @@ func RegisterAdminSourceRoutes(r *gin.Engine, svc *Service) {
 
 		item, err := svc.Create(c.Request.Context(), input)
 		if err != nil {
-			if errors.Is(err, ErrInvalidSourceType) {
+			if errors.Is(err, ErrInvalidSourceType) || errors.Is(err, ErrInvalidCrawlConfig) || errors.Is(err, ErrInvalidFetchInterval) {
 				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 				return
 			}
@@ -141,15 +159,21 @@ func RegisterAdminSourceRoutes(r *gin.Engine, svc *Service) {
 		}
 
 		input := UpdateInput{
-			Name:            req.Name,
-			Platform:        req.Platform,
-			AccountID:       req.AccountID,
-			SourceURL:       req.SourceURL,
-			ParserKind:      req.ParserKind,
-			RightsDisplay:   req.RightsDisplay,
-			RightsPlayback:  req.RightsPlayback,
-			RightsAISummary: req.RightsAISummary,
-			RightsProofURL:  req.RightsProofURL,
+			Name:              req.Name,
+			Platform:          req.Platform,
+			AccountID:         req.AccountID,
+			SourceURL:         req.SourceURL,
+			ParserKind:        req.ParserKind,
+			RightsDisplay:     req.RightsDisplay,
+			RightsPlayback:    req.RightsPlayback,
+			RightsAISummary:   req.RightsAISummary,
+			RightsTranslation: req.RightsTranslation,
+			RightsProofURL:    req.RightsProofURL,
+			CrawlInclude:      req.CrawlInclude,
+			CrawlExclude:      req.CrawlExclude,
+			CrawlMaxDepth:     req.CrawlMaxDepth,
+			CrawlMaxItems:     req.CrawlMaxItems,
+			FetchInterval:     req.FetchInterval,
 		}
 		if req.RightsExpiresAt != nil {
 			expiresAt, err := time.Parse(time.RFC3339, *req.RightsExpiresAt)
@@ -165,6 +189,10 @@ func RegisterAdminSourceRoutes(r *gin.Engine, svc *Service) {
 				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 				return
 			}
+			if errors.Is(err, ErrInvalidCrawlConfig) || errors.Is(err, ErrInvalidFetchInterval) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
@@ -3,64 +3,103 @@ package source
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 var (
-	ErrInvalidSourceType = errors.New("invalid source type")
-	ErrSourceNotFound    = errors.New("source not found")
+	ErrInvalidSourceType    = errors.New("invalid source type")
+	ErrSourceNotFound       = errors.New("source not found")
+	ErrInvalidCrawlConfig   = errors.New("invalid crawl config")
+	ErrInvalidFetchInterval = errors.New("invalid fetch interval")
+)
+
+// Listing crawl defaults: only the configured page is read and at most 20 articles are
+// queued per run.
+const (
+	DefaultCrawlMaxDepth = 1
+	DefaultCrawlMaxItems = 20
+)
+
+// News sources are fetched automatically every DefaultFetchIntervalSec unless configured
+// otherwise; intervals below MinFetchIntervalSec are rejected to stay polite to publishers.
+const (
+	DefaultFetchIntervalSec = 1800
+	MinFetchIntervalSec     = 60
 )
 
 type DataSource struct {
-	ID              int64      `json:"id"`
-	Name            string     `json:"name"`
-	SourceType      string     `json:"source_type"`
-	Platform        string     `json:"platform"`
-	AccountID       string     `json:"account_id,omitempty"`
-	SourceURL       string     `json:"source_url"`
-	ParserKind      string     `json:"parser_kind"`
-	Enabled         bool       `json:"enabled"`
-	IsBuiltin       bool       `json:"is_builtin"`
-	RightsDisplay   bool       `json:"rights_display"`
-	RightsPlayback  bool       `json:"rights_playback"`
-	RightsAISummary bool       `json:"rights_ai_summary"`
-	RightsExpiresAt *time.Time `json:"rights_expires_at,omitempty"`
-	RightsProofURL  string     `json:"rights_proof_url,omitempty"`
-	LastFetchAt     *time.Time `json:"last_fetch_at,omitempty"`
-	LastFetchStatus string     `json:"last_fetch_status,omitempty"`
-	LastFetchError  string     `json:"last_fetch_error,omitempty"`
-	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+	ID                int64      `json:"id"`
+	Name              string     `json:"name"`
+	SourceType        string     `json:"source_type"`
+	Platform          string     `json:"platform"`
+	AccountID         string     `json:"account_id,omitempty"`
+	SourceURL         string     `json:"source_url"`
+	ParserKind        string     `json:"parser_kind"`
+	Enabled           bool       `json:"enabled"`
+	IsBuiltin         bool       `json:"is_builtin"`
+	RightsDisplay     bool       `json:"rights_display"`
+	RightsPlayback    bool       `json:"rights_playback"`
+	RightsAISummary   bool       `json:"rights_ai_summary"`
+	RightsTranslation bool       `json:"rights_translation"`
+	RightsExpiresAt   *time.Time `json:"rights_expires_at,omitempty"`
+	RightsProofURL    string     `json:"rights_proof_url,omitempty"`
+	CrawlInclude      string     `json:"crawl_include_pattern,omitempty"`
+	CrawlExclude      string     `json:"crawl_exclude_pattern,omitempty"`
+	CrawlMaxDepth     int        `json:"crawl_max_depth"`
+	CrawlMaxItems     int        `json:"crawl_max_items"`
+	FetchInterval     int        `json:"fetch_interval_sec"`
+	FetchFailCount    int        `json:"fetch_fail_count"`
+	NextFetchAt       *time.Time `json:"next_fetch_at,omitempty"`
+	LastFetchAt       *time.Time `json:"last_fetch_at,omitempty"`
+	LastFetchStatus   string     `json:"last_fetch_status,omitempty"`
+	LastFetchError    string     `json:"last_fetch_error,omitempty"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
 }
 
 type CreateInput struct {
-	Name            string
-	SourceType      string
-	Platform        string
-	AccountID       string
-	SourceURL       string
-	ParserKind      string
-	Enabled         bool
-	IsBuiltin       bool
-	RightsDisplay   bool
-	RightsPlayback  bool
-	RightsAISummary bool
-	RightsExpiresAt time.Time
-	RightsProofURL  string
+	Name              string
+	SourceType        string
+	Platform          string
+	AccountID         string
+	SourceURL         string
+	ParserKind        string
+	Enabled           bool
+	IsBuiltin         bool
+	RightsDisplay     bool
+	RightsPlayback    bool
+	RightsAISummary   bool
+	RightsTranslation bool
+	RightsExpiresAt   time.Time
+	RightsProofURL    string
+	CrawlInclude      string
+	CrawlExclude      string
+	CrawlMaxDepth     int
+	CrawlMaxItems     int
+	FetchInterval     int
 }
 
 type UpdateInput struct {
-	Name            string
-	Platform        string
-	AccountID       *string
-	SourceURL       string
-	ParserKind      string
-	RightsDisplay   *bool
-	RightsPlayback  *bool
-	RightsAISummary *bool
-	RightsExpiresAt *time.Time
-	RightsProofURL  *string
+	Name              string
+	Platform          string
+	AccountID         *string
+	SourceURL         string
+	ParserKind        string
+	RightsDisplay     *bool
+	RightsPlayback    *bool
+	RightsAISummary   *bool
+	RightsTranslation *bool
+	RightsExpiresAt   *time.Time
+	RightsProofURL    *string
+	CrawlInclude      *string
+	CrawlExclude      *string
+	CrawlMaxDepth     *int
+	CrawlMaxItems     *int
+	FetchInterval     *int
 }
 
 type ListFilter struct {
@@ -93,6 +132,21 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (DataSource, er
 	if !isValidSourceType(input.SourceType) {
 		return DataSource{}, ErrInvalidSourceType
 	}
+	if err := validateCrawlConfig(&input.CrawlInclude, &input.CrawlExclude, &input.CrawlMaxDepth, &input.CrawlMaxItems); err != nil {
+		return DataSource{}, err
+	}
+	if err := validateFetchInterval(&input.FetchInterval); err != nil {
+		return DataSource{}, err
+	}
+	if input.FetchInterval == 0 {
+		input.FetchInterval = DefaultFetchIntervalSec
+	}
+	if input.CrawlMaxDepth == 0 {
+		input.CrawlMaxDepth = DefaultCrawlMaxDepth
+	}
+	if input.CrawlMaxItems == 0 {
+		input.CrawlMaxItems = DefaultCrawlMaxItems
+	}
 	return s.repo.Create(ctx, input)
 }
 
@@ -109,6 +163,12 @@ func (s *Service) GetAny(ctx context.Context, sourceID int64) (DataSource, error
 }
 
 func (s *Service) Update(ctx context.Context, sourceID int64, input UpdateInput) error {
+	if err := validateCrawlConfig(input.CrawlInclude, input.CrawlExclude, input.CrawlMaxDepth, input.CrawlMaxItems); err != nil {
+		return err
+	}
+	if err := validateFetchInterval(input.FetchInterval); err != nil {
+		return err
+	}
 	return s.repo.Update(ctx, sourceID, input)
 }
 
@@ -128,6 +188,38 @@ func (s *Service) Restore(ctx context.Context, sourceID int64) error {
 	return s.repo.Restore(ctx, sourceID)
 }
 
+func validateCrawlConfig(include *string, exclude *string, maxDepth *int, maxItems *int) error {
+	for _, pattern := range []*string{include, exclude} {
+		if pattern == nil {
+			continue
+		}
+		*pattern = strings.TrimSpace(*pattern)
+		if *pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(*pattern); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidCrawlConfig, err)
+		}
+	}
+	for _, limit := range []*int{maxDepth, maxItems} {
+		if limit != nil && *limit < 0 {
+			return fmt.Errorf("%w: limits must not be negative", ErrInvalidCrawlConfig)
+		}
+	}
+	return nil
+}
+
+// validateFetchInterval accepts 0 (keep or use the default) or at least MinFetchIntervalSec.
+func validateFetchInterval(seconds *int) error {
+	if seconds == nil || *seconds == 0 {
+		return nil
+	}
+	if *seconds < MinFetchIntervalSec {
+		return fmt.Errorf("%w: must be at least %d seconds", ErrInvalidFetchInterval, MinFetchIntervalSec)
+	}
+	return nil
+}
+
 func isValidSourceType(sourceType string) bool {
 	switch sourceType {
 	case "news", "schedule", "fighter":
@@ -155,24 +247,30 @@ func (r *InMemoryRepository) Create(_ context.Context, input CreateInput) (DataS
 	defer r.mu.Unlock()
 
 	item := DataSource{
-		ID:              r.nextID,
-		Name:            input.Name,
-		SourceType:      input.SourceType,
-		Platform:        input.Platform,
-		AccountID:       input.AccountID,
-		SourceURL:       input.SourceURL,
-		ParserKind:      input.ParserKind,
-		Enabled:         input.Enabled,
-		IsBuiltin:       input.IsBuiltin,
-		RightsDisplay:   input.RightsDisplay,
-		RightsPlayback:  input.RightsPlayback,
-		RightsAISummary: input.RightsAISummary,
+		ID:                r.nextID,
+		Name:              input.Name,
+		SourceType:        input.SourceType,
+		Platform:          input.Platform,
+		AccountID:         input.AccountID,
+		SourceURL:         input.SourceURL,
+		ParserKind:        input.ParserKind,
+		Enabled:           input.Enabled,
+		IsBuiltin:         input.IsBuiltin,
+		RightsDisplay:     input.RightsDisplay,
+		RightsPlayback:    input.RightsPlayback,
+		RightsAISummary:   input.RightsAISummary,
+		RightsTranslation: input.RightsTranslation,
 	}
 	if !input.RightsExpiresAt.IsZero() {
 		expires := input.RightsExpiresAt
 		item.RightsExpiresAt = &expires
 	}
 	item.RightsProofURL = input.RightsProofURL
+	item.CrawlInclude = input.CrawlInclude
+	item.CrawlExclude = input.CrawlExclude
+	item.CrawlMaxDepth = input.CrawlMaxDepth
+	item.CrawlMaxItems = input.CrawlMaxItems
+	item.FetchInterval = input.FetchInterval
 
 	r.items[item.ID] = item
 	r.nextID++
@@ -247,12 +345,30 @@ func (r *InMemoryRepository) Update(_ context.Context, sourceID int64, input Upd
 	if input.RightsAISummary != nil {
 		item.RightsAISummary = *input.RightsAISummary
 	}
+	if input.RightsTranslation != nil {
+		item.RightsTranslation = *input.RightsTranslation
+	}
 	if input.RightsExpiresAt != nil {
 		item.RightsExpiresAt = input.RightsExpiresAt
 	}
 	if input.RightsProofURL != nil {
 		item.RightsProofURL = *input.RightsProofURL
 	}
+	if input.CrawlInclude != nil {
+		item.CrawlInclude = *input.CrawlInclude
+	}
+	if input.CrawlExclude != nil {
+		item.CrawlExclude = *input.CrawlExclude
+	}
+	if input.CrawlMaxDepth != nil && *input.CrawlMaxDepth > 0 {
+		item.CrawlMaxDepth = *input.CrawlMaxDepth
+	}
+	if input.CrawlMaxItems != nil && *input.CrawlMaxItems > 0 {
+		item.CrawlMaxItems = *input.CrawlMaxItems
+	}
+	if input.FetchInterval != nil && *input.FetchInterval > 0 {
+		item.FetchInterval = *input.FetchInterval
+	}
 
 	r.items[sourceID] = item
 	return nil
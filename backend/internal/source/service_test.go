@@ -2,6 +2,7 @@ package source
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -123,3 +124,74 @@ func TestSourceService_SoftDeleteRestoreAndFilter(t *testing.T) {
 func boolPtr(v bool) *bool {
 	return &v
 }
+
+func TestSourceService_ValidatesCrawlConfig(t *testing.T) {
+	svc := NewService(NewInMemoryRepository())
+
+	_, err := svc.Create(context.Background(), CreateInput{
+		Name:         "MMA Junkie",
+		SourceType:   "news",
+		SourceURL:    "https://mmajunkie.example.com/news",
+		ParserKind:   "listing",
+		CrawlInclude: "/news/(",
+	})
+	if !errors.Is(err, ErrInvalidCrawlConfig) {
+		t.Fatalf("expected invalid crawl config, got %v", err)
+	}
+
+	created, err := svc.Create(context.Background(), CreateInput{
+		Name:         "MMA Junkie",
+		SourceType:   "news",
+		SourceURL:    "https://mmajunkie.example.com/news",
+		ParserKind:   "listing",
+		CrawlInclude: " /news/\\d+ ",
+	})
+	if err != nil {
+		t.Fatalf("create listing source: %v", err)
+	}
+	if created.CrawlInclude != "/news/\\d+" || created.CrawlMaxDepth != DefaultCrawlMaxDepth || created.CrawlMaxItems != DefaultCrawlMaxItems {
+		t.Fatalf("expected trimmed pattern and default limits, got %+v", created)
+	}
+
+	negative := -1
+	if err := svc.Update(context.Background(), created.ID, UpdateInput{CrawlMaxItems: &negative}); !errors.Is(err, ErrInvalidCrawlConfig) {
+		t.Fatalf("expected negative cap rejected, got %v", err)
+	}
+}
+
+func TestSourceService_ValidatesFetchInterval(t *testing.T) {
+	svc := NewService(NewInMemoryRepository())
+
+	_, err := svc.Create(context.Background(), CreateInput{
+		Name:          "MMA Fighting",
+		SourceType:    "news",
+		SourceURL:     "https://mmafighting.example.com/rss",
+		ParserKind:    "rss",
+		FetchInterval: 10,
+	})
+	if !errors.Is(err, ErrInvalidFetchInterval) {
+		t.Fatalf("expected too-short interval rejected, got %v", err)
+	}
+
+	created, err := svc.Create(context.Background(), CreateInput{
+		Name:       "MMA Fighting",
+		SourceType: "news",
+		SourceURL:  "https://mmafighting.example.com/rss",
+		ParserKind: "rss",
+	})
+	if err != nil {
+		t.Fatalf("create source: %v", err)
+	}
+	if created.FetchInterval != DefaultFetchIntervalSec {
+		t.Fatalf("expected default interval, got %d", created.FetchInterval)
+	}
+
+	hourly := 3600
+	if err := svc.Update(context.Background(), created.ID, UpdateInput{FetchInterval: &hourly}); err != nil {
+		t.Fatalf("update interval: %v", err)
+	}
+	updated, _ := svc.Get(context.Background(), created.ID)
+	if updated.FetchInterval != hourly {
+		t.Fatalf("expected hourly interval, got %d", updated.FetchInterval)
+	}
+}
@@ -17,6 +17,30 @@ func TestSchema_HasAuthSourceMediaAndTakedownTables(t *testing.T) {
 	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0007_event_bout_display_fields.up.sql"))
 	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0008_bout_result_fields.up.sql"))
 	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0009_fighter_profile_extensions.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0010_bout_live_state.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0011_bout_result_history.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0012_bout_result_lock.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0013_feed_item_fields.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0014_article_body_meta.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0015_source_crawl_settings.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0016_pending_article_simhash.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0017_source_fetch_schedule.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0018_ingest_run_blocked_status.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0019_article_entities.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0020_translation_jobs.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0021_review_workflow.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0022_review_claims.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0023_article_schedule.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0024_article_revisions.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0025_article_feed_index.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0026_article_tags.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0027_search_fulltext.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0028_featured_slots.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0029_admin_user_password_changed.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0030_bout_result_history_bout_ids.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0031_pending_edited_fields.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0032_articles_content_mediumtext.up.sql"))
+	applyMigration(t, db, filepath.Join("..", "..", "migrations", "0033_crawl_links.up.sql"))
 
 	mustHaveTable(t, db, "admin_users")
 	mustHaveTable(t, db, "data_sources")
@@ -57,6 +81,47 @@ func TestSchema_HasAuthSourceMediaAndTakedownTables(t *testing.T) {
 	mustHaveColumn(t, db, "data_sources", "last_fetch_status")
 	mustHaveColumn(t, db, "data_sources", "last_fetch_error")
 	mustHaveColumn(t, db, "data_sources", "deleted_at")
+	mustHaveColumn(t, db, "bouts", "live_state")
+	mustHaveColumn(t, db, "bouts", "current_round")
+	mustHaveTable(t, db, "bout_result_history")
+	mustHaveColumn(t, db, "bouts", "result_locked")
+	mustHaveColumn(t, db, "pending_articles", "guid")
+	mustHaveColumn(t, db, "pending_articles", "cover_url")
+	mustHaveColumn(t, db, "articles", "guid")
+	mustHaveColumn(t, db, "pending_articles", "content")
+	mustHaveColumn(t, db, "articles", "summary")
+	mustHaveColumn(t, db, "articles", "author")
+	mustHaveColumn(t, db, "data_sources", "crawl_include_pattern")
+	mustHaveColumn(t, db, "data_sources", "crawl_max_items")
+	mustHaveColumn(t, db, "pending_articles", "simhash")
+	mustHaveColumn(t, db, "pending_articles", "duplicate_of")
+	mustHaveColumn(t, db, "data_sources", "fetch_interval_sec")
+	mustHaveColumn(t, db, "data_sources", "next_fetch_at")
+	mustHaveTable(t, db, "article_entities")
+	mustHaveColumn(t, db, "article_entities", "entity_type")
+	mustHaveTable(t, db, "translation_jobs")
+	mustHaveColumn(t, db, "data_sources", "rights_translation")
+	mustHaveColumn(t, db, "articles", "original_title")
+	mustHaveTable(t, db, "review_notes")
+	mustHaveColumn(t, db, "pending_articles", "reject_reason")
+	mustHaveColumn(t, db, "pending_articles", "video_url")
+	mustHaveColumn(t, db, "pending_articles", "version")
+	mustHaveColumn(t, db, "pending_articles", "claimed_until")
+	mustHaveColumn(t, db, "articles", "publish_at")
+	mustHaveColumn(t, db, "articles", "embargo_until")
+	mustHaveTable(t, db, "article_revisions")
+	mustHaveColumn(t, db, "article_revisions", "editor_id")
+	mustHaveTable(t, db, "tags")
+	mustHaveTable(t, db, "tag_source_rules")
+	mustHaveTable(t, db, "article_tags")
+	mustHaveIndex(t, db, "fighters", "ft_fighters_names")
+	mustHaveIndex(t, db, "events", "ft_events_name")
+	mustHaveIndex(t, db, "articles", "ft_articles_text")
+	mustHaveTable(t, db, "featured_slots")
+	mustHaveColumn(t, db, "featured_slots", "ends_at")
+	mustHaveColumn(t, db, "admin_users", "password_changed_at")
+	mustHaveColumn(t, db, "pending_articles", "edited_fields")
+	mustHaveIndex(t, db, "crawl_links", "uk_crawl_links_source_url")
 	mustHaveBuiltInSource(t, db, "UFC 官方赛程")
 	mustHaveBuiltInSource(t, db, "ONE Championship 官方赛程")
 	mustHaveBuiltInSource(t, db, "PFL 官方赛程")
@@ -86,6 +151,24 @@ func mustHaveColumn(t *testing.T, db *sql.DB, table string, column string) {
 	}
 }
 
+func mustHaveIndex(t *testing.T, db *sql.DB, table string, index string) {
+	t.Helper()
+
+	var count int
+	if err := db.QueryRow(`
+		SELECT COUNT(1)
+		FROM information_schema.statistics
+		WHERE table_schema = DATABASE()
+		  AND table_name = ?
+		  AND index_name = ?
+	`, table, index).Scan(&count); err != nil {
+		t.Fatalf("query index metadata failed: %v", err)
+	}
+	if count == 0 {
+		t.Fatalf("expected index %q to exist in table %q", index, table)
+	}
+}
+
 func mustHaveBuiltInSource(t *testing.T, db *sql.DB, name string) {
 	t.Helper()
 
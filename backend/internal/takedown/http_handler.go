@@ -62,7 +62,7 @@ func RegisterAdminTakedownRoutes(r *gin.Engine, svc *Service) {
 			return
 		}
 
-		if err := svc.Resolve(c.Request.Context(), ticketID, req.Action); err != nil {
+		if err := svc.Resolve(c.Request.Context(), ticketID, req.Action, c.GetInt64("admin_user_id")); err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
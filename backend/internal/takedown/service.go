@@ -42,7 +42,8 @@ type Repository interface {
 }
 
 type ContentOffliner interface {
-	OfflineArticle(ctx context.Context, articleID int64) error
+	// OfflineArticle takes an article down; editorID is recorded in its revision history.
+	OfflineArticle(ctx context.Context, articleID int64, editorID int64) error
 }
 
 type CacheInvalidator interface {
@@ -67,7 +68,8 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (Ticket, error)
 	return s.repo.Create(ctx, input)
 }
 
-func (s *Service) Resolve(ctx context.Context, ticketID int64, action string) error {
+// Resolve closes a ticket. editorID is the admin resolving it.
+func (s *Service) Resolve(ctx context.Context, ticketID int64, action string, editorID int64) error {
 	ticket, err := s.repo.Get(ctx, ticketID)
 	if err != nil {
 		return err
@@ -76,7 +78,7 @@ func (s *Service) Resolve(ctx context.Context, ticketID int64, action string) er
 	if action == ActionOfflined {
 		switch ticket.TargetType {
 		case "article":
-			if err := s.offliner.OfflineArticle(ctx, ticket.TargetID); err != nil {
+			if err := s.offliner.OfflineArticle(ctx, ticket.TargetID, editorID); err != nil {
 				return err
 			}
 			if s.cache != nil {
@@ -27,10 +27,12 @@ func (f *fakeRepo) Resolve(_ context.Context, ticketID int64, action string) err
 
 type fakeOffliner struct {
 	offlinedIDs []int64
+	editorID    int64
 }
 
-func (f *fakeOffliner) OfflineArticle(_ context.Context, articleID int64) error {
+func (f *fakeOffliner) OfflineArticle(_ context.Context, articleID int64, editorID int64) error {
 	f.offlinedIDs = append(f.offlinedIDs, articleID)
+	f.editorID = editorID
 	return nil
 }
 
@@ -56,12 +58,15 @@ func TestResolveTakedown_OfflinesTargetAndInvalidatesCache(t *testing.T) {
 	cache := &fakeCache{}
 	svc := NewService(repo, offliner, cache)
 
-	if err := svc.Resolve(context.Background(), 10, ActionOfflined); err != nil {
+	if err := svc.Resolve(context.Background(), 10, ActionOfflined, 7); err != nil {
 		t.Fatalf("resolve takedown: %v", err)
 	}
 	if len(offliner.offlinedIDs) != 1 || offliner.offlinedIDs[0] != 101 {
 		t.Fatalf("expected article 101 to be offlined")
 	}
+	if offliner.editorID != 7 {
+		t.Fatalf("expected editor passed to offliner, got %d", offliner.editorID)
+	}
 	if !cache.invalidated {
 		t.Fatalf("expected article cache invalidated")
 	}
@@ -0,0 +1,88 @@
+package taxonomy
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterTagRoutes serves the tag list the miniapp builds its news tabs from.
+func RegisterTagRoutes(r *gin.Engine, svc *Service) {
+	r.GET("/api/tags", func(c *gin.Context) {
+		items, err := svc.List(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		kind := c.Query("kind")
+		out := make([]gin.H, 0, len(items))
+		for _, item := range items {
+			if kind != "" && item.Kind != kind {
+				continue
+			}
+			out = append(out, gin.H{"slug": item.Slug, "name": item.Name, "kind": item.Kind})
+		}
+		c.JSON(http.StatusOK, gin.H{"items": out})
+	})
+}
+
+func RegisterAdminTagRoutes(r *gin.Engine, svc *Service) {
+	r.GET("/admin/tags", func(c *gin.Context) {
+		items, err := svc.List(c.Request.Context())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": items})
+	})
+
+	r.POST("/admin/tags", func(c *gin.Context) {
+		var input CreateInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		tag, err := svc.Create(c.Request.Context(), input)
+		if err != nil {
+			writeTagError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, tag)
+	})
+
+	r.PUT("/admin/tags/:id", func(c *gin.Context) {
+		tagID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil || tagID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tag id"})
+			return
+		}
+		var input UpdateInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		tag, err := svc.Update(c.Request.Context(), tagID, input)
+		if err != nil {
+			writeTagError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, tag)
+	})
+}
+
+func writeTagError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidTag):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrTagNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrDuplicateTag):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
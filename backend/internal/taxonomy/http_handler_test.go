@@ -0,0 +1,57 @@
+package taxonomy
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTagHandlers_AdminCRUDAndPublicList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := NewService(NewInMemoryRepository())
+	r := gin.New()
+	RegisterTagRoutes(r, svc)
+	RegisterAdminTagRoutes(r, svc)
+
+	send := func(method string, url string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, url, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(http.MethodPost, "/admin/tags", `{"slug":"ufc","name":"UFC","kind":"org","keywords":["UFC"]}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/admin/tags", `{"slug":"news","name":"资讯","kind":"category"}`); w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/admin/tags", `{"slug":"ufc","name":"again","kind":"org"}`); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a duplicate slug, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/admin/tags", `{"slug":"Bad Slug","name":"x"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an invalid slug, got %d", w.Code)
+	}
+	if w := send(http.MethodPut, "/admin/tags/1", `{"source_ids":[2]}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPut, "/admin/tags/9", `{"name":"x"}`); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+
+	w := send(http.MethodGet, "/api/tags?kind=category", "")
+	var payload struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(payload.Items) != 1 || payload.Items[0]["slug"] != "news" || payload.Items[0]["keywords"] != nil {
+		t.Fatalf("expected only public fields of the news category, got %+v", payload.Items)
+	}
+}
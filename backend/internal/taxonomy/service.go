@@ -0,0 +1,241 @@
+package taxonomy
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// KindCategory tags say what an article is: news, results, interviews, videos.
+	KindCategory = "category"
+	// KindOrg tags say which promotion an article is about.
+	KindOrg = "org"
+	KindTag = "tag"
+)
+
+var (
+	ErrInvalidTag   = errors.New("tag needs a lowercase slug, a name and a known kind")
+	ErrTagNotFound  = errors.New("tag not found")
+	ErrDuplicateTag = errors.New("tag slug already exists")
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,63}$`)
+
+// Tag labels articles. Keywords and source rules let ingest suggest the tag for new articles:
+// an article from one of SourceIDs, or mentioning one of Keywords, gets it pre-selected.
+type Tag struct {
+	ID        int64    `json:"id"`
+	Slug      string   `json:"slug"`
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"`
+	Keywords  []string `json:"keywords"`
+	SourceIDs []int64  `json:"source_ids"`
+}
+
+type CreateInput struct {
+	Slug      string   `json:"slug"`
+	Name      string   `json:"name"`
+	Kind      string   `json:"kind"`
+	Keywords  []string `json:"keywords"`
+	SourceIDs []int64  `json:"source_ids"`
+}
+
+// UpdateInput changes a tag; nil fields are left as they are. The slug never changes since
+// clients and cached feeds refer to it.
+type UpdateInput struct {
+	Name      *string   `json:"name"`
+	Kind      *string   `json:"kind"`
+	Keywords  *[]string `json:"keywords"`
+	SourceIDs *[]int64  `json:"source_ids"`
+}
+
+type Repository interface {
+	// List returns all tags ordered by kind and slug.
+	List(ctx context.Context) ([]Tag, error)
+	// Create stores a tag, or returns ErrDuplicateTag.
+	Create(ctx context.Context, tag Tag) (Tag, error)
+	// Update replaces a tag's name, kind, keywords and source rules, or returns ErrTagNotFound.
+	Update(ctx context.Context, tag Tag) (Tag, error)
+	Get(ctx context.Context, tagID int64) (Tag, error)
+}
+
+func ValidKind(kind string) bool {
+	return kind == KindCategory || kind == KindOrg || kind == KindTag
+}
+
+// ValidSlug reports whether slug can name a tag.
+func ValidSlug(slug string) bool {
+	return slugPattern.MatchString(slug)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) List(ctx context.Context) ([]Tag, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *Service) Create(ctx context.Context, input CreateInput) (Tag, error) {
+	tag := Tag{
+		Slug:      strings.ToLower(strings.TrimSpace(input.Slug)),
+		Name:      strings.TrimSpace(input.Name),
+		Kind:      strings.TrimSpace(input.Kind),
+		Keywords:  cleanKeywords(input.Keywords),
+		SourceIDs: cleanSourceIDs(input.SourceIDs),
+	}
+	if tag.Kind == "" {
+		tag.Kind = KindTag
+	}
+	if !ValidSlug(tag.Slug) || tag.Name == "" || !ValidKind(tag.Kind) {
+		return Tag{}, ErrInvalidTag
+	}
+	return s.repo.Create(ctx, tag)
+}
+
+func (s *Service) Update(ctx context.Context, tagID int64, input UpdateInput) (Tag, error) {
+	tag, err := s.repo.Get(ctx, tagID)
+	if err != nil {
+		return Tag{}, err
+	}
+	if input.Name != nil {
+		tag.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Kind != nil {
+		tag.Kind = strings.TrimSpace(*input.Kind)
+	}
+	if input.Keywords != nil {
+		tag.Keywords = cleanKeywords(*input.Keywords)
+	}
+	if input.SourceIDs != nil {
+		tag.SourceIDs = cleanSourceIDs(*input.SourceIDs)
+	}
+	if tag.Name == "" || !ValidKind(tag.Kind) {
+		return Tag{}, ErrInvalidTag
+	}
+	return s.repo.Update(ctx, tag)
+}
+
+// NormalizeSlugs trims, lowercases and de-duplicates slugs, keeping their order. Invalid
+// slugs yield ErrInvalidTag.
+func NormalizeSlugs(slugs []string) ([]string, error) {
+	out := make([]string, 0, len(slugs))
+	seen := make(map[string]struct{}, len(slugs))
+	for _, slug := range slugs {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if !ValidSlug(slug) {
+			return nil, ErrInvalidTag
+		}
+		if _, ok := seen[slug]; ok {
+			continue
+		}
+		seen[slug] = struct{}{}
+		out = append(out, slug)
+	}
+	return out, nil
+}
+
+func cleanKeywords(keywords []string) []string {
+	out := make([]string, 0, len(keywords))
+	seen := make(map[string]struct{}, len(keywords))
+	for _, keyword := range keywords {
+		keyword = strings.TrimSpace(keyword)
+		key := strings.ToLower(keyword)
+		if keyword == "" || strings.Contains(keyword, ",") {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, keyword)
+	}
+	return out
+}
+
+func cleanSourceIDs(ids []int64) []int64 {
+	out := make([]int64, 0, len(ids))
+	seen := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id <= 0 {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+type InMemoryRepository struct {
+	mu     sync.Mutex
+	nextID int64
+	items  []Tag
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{nextID: 1, items: []Tag{}}
+}
+
+func (r *InMemoryRepository) List(context.Context) ([]Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := append([]Tag(nil), r.items...)
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Kind != items[j].Kind {
+			return items[i].Kind < items[j].Kind
+		}
+		return items[i].Slug < items[j].Slug
+	})
+	return items, nil
+}
+
+func (r *InMemoryRepository) Get(_ context.Context, tagID int64) (Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, item := range r.items {
+		if item.ID == tagID {
+			return item, nil
+		}
+	}
+	return Tag{}, ErrTagNotFound
+}
+
+func (r *InMemoryRepository) Create(_ context.Context, tag Tag) (Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, item := range r.items {
+		if item.Slug == tag.Slug {
+			return Tag{}, ErrDuplicateTag
+		}
+	}
+	tag.ID = r.nextID
+	r.nextID++
+	r.items = append(r.items, tag)
+	return tag, nil
+}
+
+func (r *InMemoryRepository) Update(_ context.Context, tag Tag) (Tag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for idx, item := range r.items {
+		if item.ID == tag.ID {
+			tag.Slug = item.Slug
+			r.items[idx] = tag
+			return tag, nil
+		}
+	}
+	return Tag{}, ErrTagNotFound
+}
@@ -0,0 +1,111 @@
+package taxonomy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestService_CreateValidatesAndNormalizes(t *testing.T) {
+	svc := NewService(NewInMemoryRepository())
+	ctx := context.Background()
+
+	tag, err := svc.Create(ctx, CreateInput{
+		Slug:      " Results ",
+		Name:      "赛果",
+		Kind:      KindCategory,
+		Keywords:  []string{"defeats", " Defeats", "", "赛果"},
+		SourceIDs: []int64{3, 1, 3, 0},
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if tag.Slug != "results" || len(tag.Keywords) != 2 || len(tag.SourceIDs) != 2 || tag.SourceIDs[0] != 1 {
+		t.Fatalf("expected normalized tag, got %+v", tag)
+	}
+	if _, err := svc.Create(ctx, CreateInput{Slug: "results", Name: "dup"}); !errors.Is(err, ErrDuplicateTag) {
+		t.Fatalf("expected duplicate slug rejected, got %v", err)
+	}
+	for _, input := range []CreateInput{
+		{Slug: "bad slug", Name: "x"},
+		{Slug: "ok", Name: " "},
+		{Slug: "ok", Name: "x", Kind: "weird"},
+	} {
+		if _, err := svc.Create(ctx, input); !errors.Is(err, ErrInvalidTag) {
+			t.Fatalf("expected %+v rejected, got %v", input, err)
+		}
+	}
+
+	name := "比赛结果"
+	keywords := []string{"def."}
+	updated, err := svc.Update(ctx, tag.ID, UpdateInput{Name: &name, Keywords: &keywords})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.Name != name || updated.Slug != "results" || len(updated.Keywords) != 1 || len(updated.SourceIDs) != 2 {
+		t.Fatalf("expected partial update, got %+v", updated)
+	}
+	if _, err := svc.Update(ctx, 99, UpdateInput{Name: &name}); !errors.Is(err, ErrTagNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestSuggest_UsesSourceRulesThenKeywords(t *testing.T) {
+	tags := []Tag{
+		{Slug: "interviews", Keywords: []string{"interview", "专访"}},
+		{Slug: "ufc", Keywords: []string{"UFC"}},
+		{Slug: "videos", SourceIDs: []int64{4}},
+		{Slug: "one", Keywords: []string{"ONE Championship"}},
+	}
+
+	got := Suggest(tags, 4, "UFC 300 专访：Pereira", "An exclusive sit-down.")
+	want := []string{"videos", "interviews", "ufc"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+	if got := Suggest(tags, 1, "Interviewing for a job", "ufcstats.com"); len(got) != 0 {
+		t.Fatalf("expected keywords to match whole words only, got %v", got)
+	}
+}
+
+type countingDirectory struct {
+	calls int
+	tags  []Tag
+	err   error
+}
+
+func (d *countingDirectory) List(context.Context) ([]Tag, error) {
+	d.calls++
+	return d.tags, d.err
+}
+
+func TestSuggester_ReloadsAfterRefreshAndSurvivesErrors(t *testing.T) {
+	dir := &countingDirectory{tags: []Tag{{Slug: "ufc", Keywords: []string{"UFC"}}}}
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := NewSuggester(dir)
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if got, err := s.Suggest(ctx, 0, "UFC 300", ""); err != nil || len(got) != 1 {
+		t.Fatalf("expected ufc suggested, got %v %v", got, err)
+	}
+	_, _ = s.Suggest(ctx, 0, "UFC 300", "")
+	if dir.calls != 1 {
+		t.Fatalf("expected cached tag list, got %d loads", dir.calls)
+	}
+
+	now = now.Add(DefaultRefresh)
+	dir.err = errors.New("db down")
+	if got, err := s.Suggest(ctx, 0, "UFC 300", ""); err != nil || len(got) != 1 {
+		t.Fatalf("expected stale tags kept on error, got %v %v", got, err)
+	}
+	if dir.calls != 2 {
+		t.Fatalf("expected reload after refresh, got %d loads", dir.calls)
+	}
+}
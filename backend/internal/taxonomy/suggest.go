@@ -0,0 +1,65 @@
+package taxonomy
+
+import (
+	"context"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/linking"
+)
+
+// DefaultRefresh is how long a suggester keeps its tag list before reloading it.
+const DefaultRefresh = 10 * time.Minute
+
+// Directory lists the tags suggestions are drawn from.
+type Directory interface {
+	List(ctx context.Context) ([]Tag, error)
+}
+
+// Suggest returns the slugs of tags an article should start with: tags whose source rules
+// include the article's source, then tags whose keywords the title or body mentions.
+func Suggest(tags []Tag, sourceID int64, title string, body string) []string {
+	text := title + "\n" + body
+	bySource := make([]string, 0)
+	byKeyword := make([]string, 0)
+	for _, tag := range tags {
+		if sourceID > 0 && containsID(tag.SourceIDs, sourceID) {
+			bySource = append(bySource, tag.Slug)
+			continue
+		}
+		for _, keyword := range tag.Keywords {
+			if linking.Mentions(text, keyword) {
+				byKeyword = append(byKeyword, tag.Slug)
+				break
+			}
+		}
+	}
+	return append(bySource, byKeyword...)
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Suggester suggests tags for new articles, reloading the tag list every refresh interval so
+// rules edited after startup are picked up.
+type Suggester struct {
+	tags *linking.RefreshedList[Tag]
+	now  func() time.Time
+}
+
+func NewSuggester(dir Directory) *Suggester {
+	return &Suggester{tags: linking.NewRefreshedList(dir.List, DefaultRefresh), now: time.Now}
+}
+
+func (s *Suggester) Suggest(ctx context.Context, sourceID int64, title string, body string) ([]string, error) {
+	tags, err := s.tags.Get(ctx, s.now())
+	if err != nil {
+		return nil, err
+	}
+	return Suggest(tags, sourceID, title, body), nil
+}
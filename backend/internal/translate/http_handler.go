@@ -0,0 +1,57 @@
+package translate
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+func RegisterAdminTranslationRoutes(r *gin.Engine, svc *Service) {
+	r.POST("/admin/review/:id/translate", func(c *gin.Context) {
+		pendingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pending id"})
+			return
+		}
+
+		job, err := svc.EnqueuePending(c.Request.Context(), pendingID)
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrNotAllowed):
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			case errors.Is(err, ErrArticleNotFound):
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+		c.JSON(http.StatusOK, job)
+	})
+
+	r.GET("/admin/translation-jobs", func(c *gin.Context) {
+		limit := defaultListLimit
+		if raw := c.Query("limit"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+				return
+			}
+			limit = min(parsed, maxListLimit)
+		}
+
+		items, err := svc.ListJobs(c.Request.Context(), limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"items": items})
+	})
+}
@@ -0,0 +1,122 @@
+package translate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultOpenAIBase  = "https://api.openai.com/v1"
+	defaultOpenAIModel = "gpt-4o-mini"
+	maxErrorBodyBytes  = 512
+)
+
+const translatePrompt = `You translate mixed martial arts news for Chinese readers.
+Translate the JSON article the user sends into %s. Keep fighter and event names recognisable:
+use the common Chinese name when one exists and keep the English name in parentheses on first mention.
+Keep paragraph breaks. Reply with a JSON object {"title": "...", "body": "..."} and nothing else.`
+
+// OpenAITranslator calls an OpenAI-compatible chat completions endpoint.
+type OpenAITranslator struct {
+	apiBase string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+func NewOpenAITranslator(config Config, client *http.Client) *OpenAITranslator {
+	if client == nil {
+		client = &http.Client{Timeout: 60 * time.Second}
+	}
+	apiBase := strings.TrimRight(strings.TrimSpace(config.APIBase), "/")
+	if apiBase == "" {
+		apiBase = defaultOpenAIBase
+	}
+	model := strings.TrimSpace(config.Model)
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+	return &OpenAITranslator{apiBase: apiBase, apiKey: config.APIKey, model: model, client: client}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string            `json:"model"`
+	Messages       []chatMessage     `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	ResponseFormat map[string]string `json:"response_format"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (p *OpenAITranslator) Translate(ctx context.Context, text Text, targetLang string) (Text, error) {
+	if strings.TrimSpace(p.apiKey) == "" {
+		return Text{}, ErrAPIKeyRequired
+	}
+	article, err := json.Marshal(text)
+	if err != nil {
+		return Text{}, err
+	}
+	payload, err := json.Marshal(chatRequest{
+		Model: p.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: fmt.Sprintf(translatePrompt, targetLang)},
+			{Role: "user", Content: string(article)},
+		},
+		Temperature:    0.2,
+		ResponseFormat: map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return Text{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiBase+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return Text{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Text{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+		return Text{}, fmt.Errorf("translation request failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var decoded chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return Text{}, err
+	}
+	if len(decoded.Choices) == 0 {
+		return Text{}, errors.New("translation response has no choices")
+	}
+	var out Text
+	if err := json.Unmarshal([]byte(decoded.Choices[0].Message.Content), &out); err != nil {
+		return Text{}, fmt.Errorf("decode translation: %w", err)
+	}
+	out.Title = strings.TrimSpace(out.Title)
+	out.Body = strings.TrimSpace(out.Body)
+	if out.Title == "" {
+		return Text{}, errors.New("translation response has no title")
+	}
+	return out, nil
+}
@@ -0,0 +1,277 @@
+package translate
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/source"
+)
+
+const (
+	StatusPending        = "pending"
+	StatusRunning        = "running"
+	StatusDone           = "done"
+	StatusFailed         = "failed"
+	StatusManualRequired = "manual_required"
+)
+
+var (
+	ErrJobNotFound     = errors.New("translation job not found")
+	ErrArticleNotFound = errors.New("pending article not found")
+	// ErrNotAllowed means the article's source has not granted translation rights.
+	ErrNotAllowed = errors.New("source has no translation rights")
+)
+
+// Job translates one pending article. The original text is kept on the job next to the
+// draft so editors can compare them.
+type Job struct {
+	ID         int64     `json:"id"`
+	PendingID  int64     `json:"pending_id"`
+	SourceID   int64     `json:"source_id"`
+	Status     string    `json:"status"`
+	Provider   string    `json:"provider,omitempty"`
+	TargetLang string    `json:"target_lang"`
+	Original   Text      `json:"original"`
+	Draft      Text      `json:"draft"`
+	ErrorMsg   string    `json:"error_msg,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Article is the pending article a job is created for.
+type Article struct {
+	PendingID int64
+	SourceID  int64
+	Text      Text
+}
+
+type CreateInput struct {
+	PendingID  int64
+	SourceID   int64
+	Status     string
+	Provider   string
+	TargetLang string
+	Original   Text
+	ErrorMsg   string
+}
+
+type Repository interface {
+	Create(ctx context.Context, input CreateInput) (Job, error)
+	List(ctx context.Context, limit int) ([]Job, error)
+	// ClaimNext marks the oldest pending job running and returns it. Jobs left running since
+	// before staleBefore count as pending, so a job whose worker died is picked up again.
+	ClaimNext(ctx context.Context, staleBefore time.Time) (Job, bool, error)
+	// Active returns the article's pending or running job, if any.
+	Active(ctx context.Context, pendingID int64) (Job, bool, error)
+	Complete(ctx context.Context, jobID int64, draft Text) error
+	Fail(ctx context.Context, jobID int64, errorMsg string) error
+	GetArticle(ctx context.Context, pendingID int64) (Article, error)
+}
+
+type SourceReader interface {
+	Get(ctx context.Context, sourceID int64) (source.DataSource, error)
+}
+
+type Service struct {
+	repo    Repository
+	sources SourceReader
+	config  Config
+	now     func() time.Time
+}
+
+func NewService(repo Repository, sources SourceReader, config Config) *Service {
+	return &Service{repo: repo, sources: sources, config: config, now: time.Now}
+}
+
+// Enqueue creates a translation job when the article's source allows translation.
+func (s *Service) Enqueue(ctx context.Context, article Article) (Job, error) {
+	if err := s.checkRights(ctx, article.SourceID); err != nil {
+		return Job{}, err
+	}
+	status := StatusPending
+	errMsg := ""
+	if !s.config.Enabled() {
+		status = StatusManualRequired
+		errMsg = "translation provider not configured, fallback to manual"
+	}
+	return s.repo.Create(ctx, CreateInput{
+		PendingID:  article.PendingID,
+		SourceID:   article.SourceID,
+		Status:     status,
+		Provider:   s.config.Provider,
+		TargetLang: TargetLang,
+		Original:   article.Text,
+		ErrorMsg:   errMsg,
+	})
+}
+
+// EnqueuePending creates a job for a pending article by ID, for editors re-running a translation.
+// While a job for the article is still pending or running, that job is returned instead.
+func (s *Service) EnqueuePending(ctx context.Context, pendingID int64) (Job, error) {
+	if job, ok, err := s.repo.Active(ctx, pendingID); err != nil || ok {
+		return job, err
+	}
+	article, err := s.repo.GetArticle(ctx, pendingID)
+	if err != nil {
+		return Job{}, err
+	}
+	return s.Enqueue(ctx, article)
+}
+
+func (s *Service) ListJobs(ctx context.Context, limit int) ([]Job, error) {
+	return s.repo.List(ctx, limit)
+}
+
+func (s *Service) checkRights(ctx context.Context, sourceID int64) error {
+	if s.sources == nil || sourceID <= 0 {
+		return ErrNotAllowed
+	}
+	item, err := s.sources.Get(ctx, sourceID)
+	if err != nil {
+		return err
+	}
+	if !item.RightsTranslation {
+		return ErrNotAllowed
+	}
+	if item.RightsExpiresAt != nil && s.now().After(*item.RightsExpiresAt) {
+		return ErrNotAllowed
+	}
+	return nil
+}
+
+// InMemoryRepository keeps jobs in memory for local runs and tests.
+type InMemoryRepository struct {
+	mu       sync.Mutex
+	nextID   int64
+	items    map[int64]Job
+	articles map[int64]Article
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		nextID:   1,
+		items:    map[int64]Job{},
+		articles: map[int64]Article{},
+	}
+}
+
+// PutArticle registers a pending article for GetArticle.
+func (r *InMemoryRepository) PutArticle(article Article) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.articles[article.PendingID] = article
+}
+
+func (r *InMemoryRepository) Create(_ context.Context, input CreateInput) (Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	item := Job{
+		ID:         r.nextID,
+		PendingID:  input.PendingID,
+		SourceID:   input.SourceID,
+		Status:     input.Status,
+		Provider:   input.Provider,
+		TargetLang: input.TargetLang,
+		Original:   input.Original,
+		ErrorMsg:   input.ErrorMsg,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	r.items[item.ID] = item
+	r.nextID++
+	return item, nil
+}
+
+func (r *InMemoryRepository) List(_ context.Context, limit int) ([]Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	items := make([]Job, 0, len(r.items))
+	for _, item := range r.items {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID > items[j].ID })
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (r *InMemoryRepository) ClaimNext(_ context.Context, staleBefore time.Time) (Job, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var next Job
+	for _, item := range r.items {
+		claimable := item.Status == StatusPending || (item.Status == StatusRunning && item.UpdatedAt.Before(staleBefore))
+		if claimable && (next.ID == 0 || item.ID < next.ID) {
+			next = item
+		}
+	}
+	if next.ID == 0 {
+		return Job{}, false, nil
+	}
+	next.Status = StatusRunning
+	next.UpdatedAt = time.Now()
+	r.items[next.ID] = next
+	return next, true, nil
+}
+
+func (r *InMemoryRepository) Active(_ context.Context, pendingID int64) (Job, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var active Job
+	for _, item := range r.items {
+		inFlight := item.Status == StatusPending || item.Status == StatusRunning
+		if inFlight && item.PendingID == pendingID && item.ID > active.ID {
+			active = item
+		}
+	}
+	return active, active.ID != 0, nil
+}
+
+func (r *InMemoryRepository) Complete(_ context.Context, jobID int64, draft Text) error {
+	return r.update(jobID, func(item *Job) {
+		item.Status = StatusDone
+		item.Draft = draft
+		item.ErrorMsg = ""
+	})
+}
+
+func (r *InMemoryRepository) Fail(_ context.Context, jobID int64, errorMsg string) error {
+	return r.update(jobID, func(item *Job) {
+		item.Status = StatusFailed
+		item.ErrorMsg = errorMsg
+	})
+}
+
+func (r *InMemoryRepository) GetArticle(_ context.Context, pendingID int64) (Article, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	article, ok := r.articles[pendingID]
+	if !ok {
+		return Article{}, ErrArticleNotFound
+	}
+	return article, nil
+}
+
+func (r *InMemoryRepository) update(jobID int64, apply func(*Job)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[jobID]
+	if !ok {
+		return ErrJobNotFound
+	}
+	apply(&item)
+	item.UpdatedAt = time.Now()
+	r.items[jobID] = item
+	return nil
+}
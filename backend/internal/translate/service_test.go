@@ -0,0 +1,181 @@
+package translate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/source"
+)
+
+type fakeSources map[int64]source.DataSource
+
+func (f fakeSources) Get(_ context.Context, sourceID int64) (source.DataSource, error) {
+	item, ok := f[sourceID]
+	if !ok {
+		return source.DataSource{}, errors.New("source not found")
+	}
+	return item, nil
+}
+
+func testSources() fakeSources {
+	expired := time.Now().Add(-time.Hour)
+	return fakeSources{
+		1: {ID: 1, RightsTranslation: true},
+		2: {ID: 2, RightsTranslation: false, RightsAISummary: true},
+		3: {ID: 3, RightsTranslation: true, RightsExpiresAt: &expired},
+	}
+}
+
+func TestEnqueue_RespectsSourceRights(t *testing.T) {
+	svc := NewService(NewInMemoryRepository(), testSources(), Config{Provider: ProviderStub})
+	article := Article{PendingID: 10, Text: Text{Title: "Pereira retains"}}
+
+	for _, sourceID := range []int64{0, 2, 3} {
+		article.SourceID = sourceID
+		if _, err := svc.Enqueue(context.Background(), article); !errors.Is(err, ErrNotAllowed) {
+			t.Fatalf("source %d: expected ErrNotAllowed, got %v", sourceID, err)
+		}
+	}
+
+	article.SourceID = 1
+	job, err := svc.Enqueue(context.Background(), article)
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if job.Status != StatusPending || job.Original.Title != "Pereira retains" || job.TargetLang != TargetLang {
+		t.Fatalf("unexpected job %+v", job)
+	}
+}
+
+func TestEnqueue_WithoutProviderFallsBackToManual(t *testing.T) {
+	svc := NewService(NewInMemoryRepository(), testSources(), Config{Provider: ProviderOpenAI})
+	job, err := svc.Enqueue(context.Background(), Article{PendingID: 10, SourceID: 1, Text: Text{Title: "t"}})
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if job.Status != StatusManualRequired {
+		t.Fatalf("expected %s, got %s", StatusManualRequired, job.Status)
+	}
+}
+
+type failingTranslator struct{}
+
+func (failingTranslator) Translate(context.Context, Text, string) (Text, error) {
+	return Text{}, errors.New("provider down")
+}
+
+func TestWorker_TranslatesPendingJobsAndKeepsOriginal(t *testing.T) {
+	repo := NewInMemoryRepository()
+	svc := NewService(repo, testSources(), Config{Provider: ProviderStub})
+	for _, pendingID := range []int64{10, 11} {
+		if _, err := svc.Enqueue(context.Background(), Article{PendingID: pendingID, SourceID: 1, Text: Text{Title: "Title", Body: "Body"}}); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+
+	processed, err := NewWorker(repo, StubTranslator{}).RunPending(context.Background())
+	if err != nil || processed != 2 {
+		t.Fatalf("expected 2 jobs processed, got %d, %v", processed, err)
+	}
+	jobs, _ := repo.List(context.Background(), 0)
+	for _, job := range jobs {
+		if job.Status != StatusDone || job.Draft.Title != "[zh-CN] Title" || job.Original.Title != "Title" {
+			t.Fatalf("unexpected job %+v", job)
+		}
+	}
+
+	if _, err := svc.Enqueue(context.Background(), Article{PendingID: 12, SourceID: 1, Text: Text{Title: "Title"}}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	found, err := NewWorker(repo, failingTranslator{}).RunOnce(context.Background())
+	if !found || err != nil {
+		t.Fatalf("expected failed translation to be recorded, got %v, %v", found, err)
+	}
+	jobs, _ = repo.List(context.Background(), 1)
+	if jobs[0].Status != StatusFailed || jobs[0].ErrorMsg != "provider down" {
+		t.Fatalf("unexpected failed job %+v", jobs[0])
+	}
+}
+
+func TestAdminTranslateRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repo := NewInMemoryRepository()
+	repo.PutArticle(Article{PendingID: 10, SourceID: 1, Text: Text{Title: "Title"}})
+	repo.PutArticle(Article{PendingID: 11, SourceID: 2, Text: Text{Title: "Title"}})
+	r := gin.New()
+	RegisterAdminTranslationRoutes(r, NewService(repo, testSources(), Config{Provider: ProviderStub}))
+
+	cases := map[string]int{
+		"/admin/review/10/translate": http.StatusOK,
+		"/admin/review/11/translate": http.StatusForbidden,
+		"/admin/review/99/translate": http.StatusNotFound,
+		"/admin/review/x/translate":  http.StatusBadRequest,
+	}
+	for path, want := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+		if w.Code != want {
+			t.Fatalf("%s: expected %d, got %d", path, want, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/translation-jobs?limit=10", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestWorker_ReclaimsJobsLeftRunning(t *testing.T) {
+	repo := NewInMemoryRepository()
+	svc := NewService(repo, testSources(), Config{Provider: ProviderStub})
+	job, err := svc.Enqueue(context.Background(), Article{PendingID: 10, SourceID: 1, Text: Text{Title: "Title"}})
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if _, ok, err := repo.ClaimNext(context.Background(), time.Now().Add(-staleJobAfter)); !ok || err != nil {
+		t.Fatalf("claim: %v %v", ok, err)
+	}
+
+	worker := NewWorker(repo, StubTranslator{})
+	if found, err := worker.RunOnce(context.Background()); found || err != nil {
+		t.Fatalf("expected a fresh running job left alone, got %v %v", found, err)
+	}
+	worker.now = func() time.Time { return time.Now().Add(staleJobAfter + time.Minute) }
+	if found, err := worker.RunOnce(context.Background()); !found || err != nil {
+		t.Fatalf("expected the stale running job claimed again, got %v %v", found, err)
+	}
+	jobs, _ := repo.List(context.Background(), 0)
+	if len(jobs) != 1 || jobs[0].ID != job.ID || jobs[0].Status != StatusDone {
+		t.Fatalf("expected the reclaimed job translated, got %+v", jobs)
+	}
+}
+
+func TestEnqueuePending_ReturnsJobStillInFlight(t *testing.T) {
+	repo := NewInMemoryRepository()
+	repo.PutArticle(Article{PendingID: 10, SourceID: 1, Text: Text{Title: "Title"}})
+	svc := NewService(repo, testSources(), Config{Provider: ProviderStub})
+
+	first, err := svc.EnqueuePending(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	again, err := svc.EnqueuePending(context.Background(), 10)
+	if err != nil || again.ID != first.ID {
+		t.Fatalf("expected the pending job returned, got %+v %v", again, err)
+	}
+
+	if _, err := NewWorker(repo, failingTranslator{}).RunPending(context.Background()); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	retry, err := svc.EnqueuePending(context.Background(), 10)
+	if err != nil || retry.ID == first.ID || retry.Status != StatusPending {
+		t.Fatalf("expected a new job after the failed one, got %+v %v", retry, err)
+	}
+}
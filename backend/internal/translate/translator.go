@@ -0,0 +1,92 @@
+package translate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+const (
+	ProviderOpenAI = "openai"
+	// ProviderStub translates deterministically without network access, for tests and local runs.
+	ProviderStub = "stub"
+
+	// TargetLang is the language drafts are produced in.
+	TargetLang = "zh-CN"
+)
+
+var ErrAPIKeyRequired = errors.New("translation api key is required")
+
+// foreignLetterRatio is the share of Latin letters above which an article is treated as foreign.
+// Chinese reports often quote English names, so a few Latin words do not count.
+const foreignLetterRatio = 0.6
+
+// Text is an article title and body.
+type Text struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// Translator turns an article into the target language.
+type Translator interface {
+	Translate(ctx context.Context, text Text, targetLang string) (Text, error)
+}
+
+// Config selects and configures a translation provider.
+type Config struct {
+	Provider string
+	APIBase  string
+	APIKey   string
+	Model    string
+}
+
+// Enabled reports whether jobs can run automatically. Without a provider they are created
+// as manual_required so editors translate by hand.
+func (c Config) Enabled() bool {
+	if c.Provider == ProviderStub {
+		return true
+	}
+	return strings.TrimSpace(c.APIKey) != ""
+}
+
+// NewTranslator builds the configured provider, or nil when translation is not enabled.
+func NewTranslator(config Config, client *http.Client) Translator {
+	if !config.Enabled() {
+		return nil
+	}
+	if config.Provider == ProviderStub {
+		return StubTranslator{}
+	}
+	return NewOpenAITranslator(config, client)
+}
+
+// StubTranslator marks text as translated without changing it.
+type StubTranslator struct{}
+
+func (StubTranslator) Translate(_ context.Context, text Text, targetLang string) (Text, error) {
+	prefix := "[" + targetLang + "] "
+	out := Text{Title: prefix + strings.TrimSpace(text.Title)}
+	if body := strings.TrimSpace(text.Body); body != "" {
+		out.Body = prefix + body
+	}
+	return out, nil
+}
+
+// NeedsTranslation reports whether an article is mostly written in a Latin script.
+func NeedsTranslation(title string, body string) bool {
+	latin, cjk := 0, 0
+	for _, r := range title + " " + body {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			cjk++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	if latin == 0 {
+		return false
+	}
+	return float64(latin)/float64(latin+cjk) >= foreignLetterRatio
+}
@@ -0,0 +1,80 @@
+package translate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNeedsTranslation_DetectsLatinArticles(t *testing.T) {
+	if !NeedsTranslation("Pereira retains title at UFC 307", "Alex Pereira stopped Khalil Rountree in the fourth round.") {
+		t.Fatalf("expected English article to need translation")
+	}
+	if NeedsTranslation("佩雷拉在 UFC 307 卫冕", "Alex Pereira 第四回合击败了 Rountree，成功卫冕轻重量级金腰带，赛后他表示希望尽快再战。") {
+		t.Fatalf("expected Chinese article quoting English names to be left alone")
+	}
+	if NeedsTranslation("", "123") {
+		t.Fatalf("expected text without letters to be left alone")
+	}
+}
+
+func TestNewTranslator_SelectsProvider(t *testing.T) {
+	if NewTranslator(Config{Provider: ProviderOpenAI}, nil) != nil {
+		t.Fatalf("expected no translator without api key")
+	}
+	stub := NewTranslator(Config{Provider: ProviderStub}, nil)
+	out, err := stub.Translate(context.Background(), Text{Title: "Title", Body: "Body"}, TargetLang)
+	if err != nil || out.Title != "[zh-CN] Title" || out.Body != "[zh-CN] Body" {
+		t.Fatalf("unexpected stub output %+v, %v", out, err)
+	}
+	if _, ok := NewTranslator(Config{Provider: ProviderOpenAI, APIKey: "k"}, nil).(*OpenAITranslator); !ok {
+		t.Fatalf("expected openai translator with api key")
+	}
+}
+
+func TestOpenAITranslator_SendsChatCompletion(t *testing.T) {
+	var got chatRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer key-123" {
+			t.Errorf("unexpected request %s %s", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{
+				"message": map[string]string{"role": "assistant", "content": `{"title":"佩雷拉卫冕","body":"正文"}`},
+			}},
+		})
+	}))
+	defer srv.Close()
+
+	p := NewOpenAITranslator(Config{APIBase: srv.URL + "/v1/", APIKey: "key-123", Model: "test-model"}, srv.Client())
+	out, err := p.Translate(context.Background(), Text{Title: "Pereira retains", Body: "Body"}, TargetLang)
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	if out.Title != "佩雷拉卫冕" || out.Body != "正文" {
+		t.Fatalf("unexpected translation %+v", out)
+	}
+	if got.Model != "test-model" || len(got.Messages) != 2 || !strings.Contains(got.Messages[1].Content, "Pereira retains") {
+		t.Fatalf("unexpected request payload %+v", got)
+	}
+}
+
+func TestOpenAITranslator_ReportsHTTPErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"error":"rate limited"}`, http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	p := NewOpenAITranslator(Config{APIBase: srv.URL, APIKey: "k"}, srv.Client())
+	_, err := p.Translate(context.Background(), Text{Title: "t"}, TargetLang)
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("expected status in error, got %v", err)
+	}
+	if _, err := NewOpenAITranslator(Config{}, nil).Translate(context.Background(), Text{Title: "t"}, TargetLang); err != ErrAPIKeyRequired {
+		t.Fatalf("expected api key error, got %v", err)
+	}
+}
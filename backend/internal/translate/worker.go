@@ -0,0 +1,83 @@
+package translate
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// DefaultWorkerTick is how often the worker looks for pending translation jobs.
+	DefaultWorkerTick = 30 * time.Second
+
+	// staleJobAfter bounds how long a job may stay running. A job still running after this
+	// lost its worker (crash or deploy) and is claimed again.
+	staleJobAfter = 30 * time.Minute
+)
+
+type Worker struct {
+	repo       Repository
+	translator Translator
+	now        func() time.Time
+}
+
+func NewWorker(repo Repository, translator Translator) *Worker {
+	return &Worker{repo: repo, translator: translator, now: time.Now}
+}
+
+// RunOnce translates the oldest pending or stale running job. It reports whether a job was found; a failed
+// translation is recorded on the job and does not return an error.
+func (w *Worker) RunOnce(ctx context.Context) (bool, error) {
+	job, ok, err := w.repo.ClaimNext(ctx, w.now().Add(-staleJobAfter))
+	if err != nil || !ok {
+		return false, err
+	}
+	draft, err := w.translator.Translate(ctx, job.Original, job.TargetLang)
+	if err != nil {
+		return true, w.repo.Fail(context.WithoutCancel(ctx), job.ID, err.Error())
+	}
+	return true, w.repo.Complete(context.WithoutCancel(ctx), job.ID, draft)
+}
+
+// RunPending drains pending jobs until none are left or ctx is done.
+func (w *Worker) RunPending(ctx context.Context) (int, error) {
+	processed := 0
+	for ctx.Err() == nil {
+		found, err := w.RunOnce(ctx)
+		if err != nil {
+			return processed, err
+		}
+		if !found {
+			break
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// StartWorker drains pending jobs every tick until ctx is done.
+func StartWorker(ctx context.Context, worker *Worker, tick time.Duration) {
+	if worker == nil || worker.translator == nil {
+		return
+	}
+	if tick <= 0 {
+		tick = DefaultWorkerTick
+	}
+	if _, err := worker.RunPending(ctx); err != nil {
+		log.Printf("translation jobs failed: %v", err)
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := worker.RunPending(ctx); err != nil {
+				log.Printf("translation jobs failed: %v", err)
+			}
+		}
+	}
+}
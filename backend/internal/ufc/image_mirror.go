@@ -14,6 +14,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/fetch"
 )
 
 type ImageMirror interface {
@@ -92,7 +94,7 @@ func (m *LocalImageMirror) MirrorImage(ctx context.Context, rawURL string) (stri
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; w-mma-image-mirror/1.0)")
+	req.Header.Set("User-Agent", fetch.DefaultUserAgent)
 	req.Header.Set("Accept", "image/*,*/*;q=0.8")
 
 	resp, err := m.client.Do(req)
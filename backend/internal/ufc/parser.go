@@ -12,6 +12,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/fetch"
 )
 
 var (
@@ -58,6 +61,10 @@ var (
 	athletePFPRankPattern       = regexp.MustCompile(`(?i)#\s*(\d+)\s*PFP`)
 	athleteFightHistoryPattern  = regexp.MustCompile(`(?is)([A-Z][a-z]{2}\.\s+\d{1,2},\s+\d{4})\s+Round\s+(\d+)\s+Time\s+(\d{1,2}:\d{2})\s+Method\s+(.+?)(?:Watch Replay|Fight Card|Load More|$)`)
 	fightResultTokenPattern     = regexp.MustCompile(`(?i)\b(no contest|win|loss|draw)\b`)
+	fightStatusAttrPattern      = regexp.MustCompile(`(?is)data-(?:fight-)?status=["']([a-z_\- ]+)["']`)
+	fightCurrentRoundPattern    = regexp.MustCompile(`(?is)data-current-round=["'](\d+)["']`)
+	fightLiveBannerPattern      = regexp.MustCompile(`(?is)c-listing-fight__banner--live[^>]*>(.*?)</`)
+	liveBannerRoundPattern      = regexp.MustCompile(`(?i)\bround\s+(\d+)\b|\bR(\d+)\b`)
 )
 
 type Scraper interface {
@@ -118,7 +125,7 @@ func (c *HTTPClient) fetchHTML(ctx context.Context, targetURL string) (string, e
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; w-mma-bot/1.0)")
+	req.Header.Set("User-Agent", fetch.DefaultUserAgent)
 	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -269,19 +276,21 @@ func parseEventCardHTML(html string, eventURL string, baseURL string) EventCard
 			boutResult = boutResults[resultIdx]
 		}
 		bouts = append(bouts, EventBout{
-			RedName:     athleteNameFromURL(red.URL),
-			RedURL:      red.URL,
-			RedRank:     redRank,
-			BlueName:    athleteNameFromURL(blue.URL),
-			BlueURL:     blue.URL,
-			BlueRank:    blueRank,
-			WeightClass: weightClass,
-			CardSegment: cardSegmentByOffset((red.Start+blue.End)/2, sectionMarkers),
-			WinnerSide:  boutResult.WinnerSide,
-			Result:      boutResult.Result,
-			Method:      boutResult.Method,
-			Round:       boutResult.Round,
-			TimeSec:     boutResult.TimeSec,
+			RedName:      athleteNameFromURL(red.URL),
+			RedURL:       red.URL,
+			RedRank:      redRank,
+			BlueName:     athleteNameFromURL(blue.URL),
+			BlueURL:      blue.URL,
+			BlueRank:     blueRank,
+			WeightClass:  weightClass,
+			CardSegment:  cardSegmentByOffset((red.Start+blue.End)/2, sectionMarkers),
+			WinnerSide:   boutResult.WinnerSide,
+			Result:       boutResult.Result,
+			Method:       boutResult.Method,
+			Round:        boutResult.Round,
+			TimeSec:      boutResult.TimeSec,
+			LiveState:    boutResult.LiveState,
+			CurrentRound: boutResult.CurrentRound,
 		})
 	}
 
@@ -370,11 +379,13 @@ func cardSegmentByOffset(offset int, markers []sectionMarker) string {
 }
 
 type boutResultMeta struct {
-	WinnerSide string
-	Result     string
-	Method     string
-	Round      int
-	TimeSec    int
+	WinnerSide   string
+	Result       string
+	Method       string
+	Round        int
+	TimeSec      int
+	LiveState    string
+	CurrentRound int
 }
 
 func parseBoutResultMeta(rawHTML string) []boutResultMeta {
@@ -393,17 +404,63 @@ func parseBoutResultMeta(rawHTML string) []boutResultMeta {
 		method := extractFightResultText(chunk, methodTextPattern)
 		round := atoi(extractFightResultText(chunk, roundTextPattern))
 		timeText := extractFightResultText(chunk, timeTextPattern)
-		items = append(items, boutResultMeta{
+		item := boutResultMeta{
 			WinnerSide: inferWinnerSide(chunk),
 			Result:     composeBoutResult(method, round, timeText),
 			Method:     method,
 			Round:      round,
 			TimeSec:    parseFightTimeToSeconds(timeText),
-		})
+		}
+		item.LiveState, item.CurrentRound = inferBoutLiveState(chunk, item)
+		items = append(items, item)
 	}
 	return items
 }
 
+// inferBoutLiveState reads the per-fight live markers ufc.com renders during an event.
+// It returns an empty state when the page does not expose one.
+func inferBoutLiveState(chunk string, meta boutResultMeta) (string, int) {
+	if meta.WinnerSide != "" || meta.Method != "" || meta.Round > 0 || meta.TimeSec > 0 || drawOutcomePattern.MatchString(chunk) {
+		return event.BoutStateFinished, 0
+	}
+
+	currentRound := atoi(extractByPattern(chunk, fightCurrentRoundPattern))
+	banner := ""
+	if m := fightLiveBannerPattern.FindStringSubmatch(chunk); len(m) > 1 {
+		banner = strings.ToLower(cleanText(m[1]))
+		if currentRound == 0 {
+			if rm := liveBannerRoundPattern.FindStringSubmatch(banner); len(rm) > 2 {
+				currentRound = atoi(chooseFirstNonEmpty(rm[1], rm[2]))
+			}
+		}
+	}
+
+	status := strings.ToLower(strings.TrimSpace(extractByPattern(chunk, fightStatusAttrPattern)))
+	switch {
+	case strings.Contains(status, "walkout") || strings.Contains(banner, "walkout"):
+		return event.BoutStateWalkouts, 0
+	case status == "live" || status == "in_progress" || status == "in-progress" || banner != "":
+		return event.BoutStateInProgress, currentRound
+	case status == "final" || status == "completed" || status == "finished":
+		return event.BoutStateFinished, 0
+	case status == "upcoming" || status == "scheduled" || status == "pending":
+		return event.BoutStateUpcoming, 0
+	}
+	if currentRound > 0 {
+		return event.BoutStateInProgress, currentRound
+	}
+	return "", 0
+}
+
+func chooseFirstNonEmpty(items ...string) string {
+	for _, item := range items {
+		if strings.TrimSpace(item) != "" {
+			return item
+		}
+	}
+	return ""
+}
+
 func extractFightResultText(chunk string, pattern *regexp.Regexp) string {
 	m := pattern.FindStringSubmatch(chunk)
 	if len(m) < 2 {
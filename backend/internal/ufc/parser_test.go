@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
 )
 
 func TestParseEventLinks(t *testing.T) {
@@ -497,3 +499,48 @@ func TestParseAthleteProfile_ExtractsFightHistoryAsUpdates(t *testing.T) {
 		t.Fatalf("expected parsed published_at for fight history")
 	}
 }
+
+func TestParseEventCard_ExtractsLiveBoutState(t *testing.T) {
+	html := `
+<html><body>
+  <h3>Main Card</h3>
+  <div class="c-listing-fight" data-fight-status="live">
+    <div class="c-listing-fight__banner--live">Live Now - Round 3</div>
+    <a href="/athlete/fighter-a">Fighter A</a>
+    <a href="/athlete/fighter-b">Fighter B</a>
+  </div>
+  <div class="c-listing-fight" data-fight-status="walkouts">
+    <a href="/athlete/fighter-c">Fighter C</a>
+    <a href="/athlete/fighter-d">Fighter D</a>
+  </div>
+  <div class="c-listing-fight">
+    <a href="/athlete/fighter-e">Fighter E</a>
+    <a href="/athlete/fighter-f">Fighter F</a>
+  </div>
+  <div class="c-listing-fight">
+    <div class="c-listing-fight__corner-body--red">
+      <div class="c-listing-fight__outcome-wrapper"><div class="c-listing-fight__outcome--win">Win</div></div>
+    </div>
+    <div class="c-listing-fight__result-text method">KO/TKO</div>
+    <a href="/athlete/fighter-g">Fighter G</a>
+    <a href="/athlete/fighter-h">Fighter H</a>
+  </div>
+</body></html>`
+
+	card := parseEventCardHTML(html, "https://www.ufc.com/event/ufc-326", "https://www.ufc.com")
+	if len(card.Bouts) != 4 {
+		t.Fatalf("expected 4 bouts, got %d", len(card.Bouts))
+	}
+	if card.Bouts[0].LiveState != event.BoutStateInProgress || card.Bouts[0].CurrentRound != 3 {
+		t.Fatalf("expected in_progress round 3, got %q round %d", card.Bouts[0].LiveState, card.Bouts[0].CurrentRound)
+	}
+	if card.Bouts[1].LiveState != event.BoutStateWalkouts {
+		t.Fatalf("expected walkouts, got %q", card.Bouts[1].LiveState)
+	}
+	if card.Bouts[2].LiveState != "" {
+		t.Fatalf("expected no live state without markers, got %q", card.Bouts[2].LiveState)
+	}
+	if card.Bouts[3].LiveState != event.BoutStateFinished {
+		t.Fatalf("expected finished, got %q", card.Bouts[3].LiveState)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bajiaozhi/w-mma/backend/internal/fetch"
 	"github.com/bajiaozhi/w-mma/backend/internal/source"
 )
 
@@ -35,6 +36,7 @@ type Service struct {
 	store       Store
 	scraper     Scraper
 	imageMirror ImageMirror
+	fetchClient *http.Client
 	now         func() time.Time
 }
 
@@ -48,12 +50,23 @@ func WithImageMirror(mirror ImageMirror) ServiceOption {
 	}
 }
 
+// WithFetchClient sets the client used for the athlete text mirror, so it shares the
+// crawl policy with the scraper.
+func WithFetchClient(client *http.Client) ServiceOption {
+	return func(s *Service) {
+		if client != nil {
+			s.fetchClient = client
+		}
+	}
+}
+
 func NewService(sourceRepo SourceRepository, store Store, scraper Scraper, opts ...ServiceOption) *Service {
 	svc := &Service{
 		sourceRepo:  sourceRepo,
 		store:       store,
 		scraper:     scraper,
 		imageMirror: passthroughImageMirror{},
+		fetchClient: &http.Client{Timeout: 20 * time.Second},
 		now:         time.Now,
 	}
 	for _, opt := range opts {
@@ -271,7 +284,7 @@ func (s *Service) enrichSingleAthleteProfile(ctx context.Context, athleteURL str
 	}
 
 	mirrorRaw := ""
-	if raw, err := fetchAthleteMirrorText(ctx, athleteURL); err == nil {
+	if raw, err := s.fetchAthleteMirrorText(ctx, athleteURL); err == nil {
 		mirrorRaw = raw
 	}
 
@@ -301,7 +314,7 @@ func (s *Service) enrichSingleAthleteProfile(ctx context.Context, athleteURL str
 			}
 		}
 	}
-	profile.Updates = mergeAthleteUpdates(profile.Updates, collectAthleteMirrorUpdates(ctx, athleteURL, 8))
+	profile.Updates = mergeAthleteUpdates(profile.Updates, collectAthleteMirrorUpdatesWithFetcher(ctx, athleteURL, 8, s.fetchAthleteMirrorText))
 	profile.Updates = fillFightResultsFromNarrative(profile.Updates, mirrorRaw)
 	return profile
 }
@@ -348,7 +361,7 @@ func missingSingleAthleteHeroFields(profile AthleteProfile) bool {
 	return false
 }
 
-func fetchAthleteMirrorText(ctx context.Context, athleteURL string) (string, error) {
+func (s *Service) fetchAthleteMirrorText(ctx context.Context, athleteURL string) (string, error) {
 	athleteURL = strings.TrimSpace(athleteURL)
 	if athleteURL == "" {
 		return "", errors.New("empty athlete url")
@@ -367,9 +380,8 @@ func fetchAthleteMirrorText(ctx context.Context, athleteURL string) (string, err
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; w-mma-bot/1.0)")
-	client := &http.Client{Timeout: 20 * time.Second}
-	resp, err := client.Do(req)
+	req.Header.Set("User-Agent", fetch.DefaultUserAgent)
+	resp, err := s.fetchClient.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -387,10 +399,6 @@ func fetchAthleteMirrorText(ctx context.Context, athleteURL string) (string, err
 var mirrorLoadMorePattern = regexp.MustCompile(`(?is)\[\s*Load More\s*\]\(([^)\s]+)`)
 var mirrorFightHistoryPattern = regexp.MustCompile(`(?is)([A-Z][a-z]{2}\.\s+\d{1,2},\s+\d{4})\s+Round\s+(\d+)\s+Time\s+(\d{1,2}:\d{2})\s+Method\s+(.+?)(?:\[\s*Watch Replay|\[\s*Fight Card|$)`)
 
-func collectAthleteMirrorUpdates(ctx context.Context, athleteURL string, maxPages int) []AthleteUpdate {
-	return collectAthleteMirrorUpdatesWithFetcher(ctx, athleteURL, maxPages, fetchAthleteMirrorText)
-}
-
 func collectAthleteMirrorUpdatesWithFetcher(
 	ctx context.Context,
 	athleteURL string,
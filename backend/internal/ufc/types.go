@@ -23,6 +23,9 @@ type EventBout struct {
 	Method      string
 	Round       int
 	TimeSec     int
+	// LiveState is empty when the event page exposes no per-fight live marker.
+	LiveState    string
+	CurrentRound int
 }
 
 type EventCard struct {
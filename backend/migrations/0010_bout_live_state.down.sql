@@ -0,0 +1,3 @@
+ALTER TABLE bouts
+  DROP COLUMN live_state,
+  DROP COLUMN current_round;
@@ -0,0 +1,3 @@
+ALTER TABLE bouts
+  ADD COLUMN live_state VARCHAR(16) NULL,
+  ADD COLUMN current_round INT NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bout_result_history;
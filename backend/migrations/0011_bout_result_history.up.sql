@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS bout_result_history (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  event_id BIGINT NOT NULL,
+  bout_id BIGINT NOT NULL,
+  sequence_no INT NOT NULL,
+  winner_fighter_id BIGINT NULL,
+  method VARCHAR(128) NULL,
+  `round` INT NULL,
+  time_sec INT NULL,
+  result VARCHAR(255) NULL,
+  previous_winner_fighter_id BIGINT NULL,
+  previous_result VARCHAR(255) NULL,
+  source ENUM('scraper','manual','correction') NOT NULL,
+  note VARCHAR(255) NULL,
+  created_at DATETIME NOT NULL,
+  KEY idx_bout_result_history_event_sequence (event_id, sequence_no, id),
+  KEY idx_bout_result_history_bout (bout_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,4 @@
+ALTER TABLE bouts
+  DROP COLUMN result_locked,
+  DROP COLUMN result_locked_by,
+  DROP COLUMN result_locked_at;
@@ -0,0 +1,4 @@
+ALTER TABLE bouts
+  ADD COLUMN result_locked TINYINT(1) NOT NULL DEFAULT 0,
+  ADD COLUMN result_locked_by BIGINT NULL,
+  ADD COLUMN result_locked_at DATETIME NULL;
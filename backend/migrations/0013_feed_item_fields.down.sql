@@ -0,0 +1,8 @@
+DROP INDEX idx_articles_guid ON articles;
+ALTER TABLE articles DROP COLUMN guid;
+
+DROP INDEX idx_pending_articles_guid ON pending_articles;
+ALTER TABLE pending_articles
+  DROP COLUMN guid,
+  DROP COLUMN cover_url,
+  DROP COLUMN published_at;
@@ -0,0 +1,11 @@
+ALTER TABLE pending_articles
+  ADD COLUMN guid VARCHAR(512) NULL,
+  ADD COLUMN cover_url VARCHAR(512) NULL,
+  ADD COLUMN published_at DATETIME NULL;
+
+CREATE INDEX idx_pending_articles_guid ON pending_articles (guid(191));
+
+ALTER TABLE articles
+  ADD COLUMN guid VARCHAR(512) NULL;
+
+CREATE INDEX idx_articles_guid ON articles (guid(191));
@@ -0,0 +1,7 @@
+ALTER TABLE articles
+  DROP COLUMN summary,
+  DROP COLUMN author;
+
+ALTER TABLE pending_articles
+  DROP COLUMN content,
+  DROP COLUMN author;
@@ -0,0 +1,7 @@
+ALTER TABLE pending_articles
+  ADD COLUMN content MEDIUMTEXT NULL,
+  ADD COLUMN author VARCHAR(128) NULL;
+
+ALTER TABLE articles
+  ADD COLUMN summary TEXT NULL,
+  ADD COLUMN author VARCHAR(128) NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE data_sources
+  DROP COLUMN crawl_include_pattern,
+  DROP COLUMN crawl_exclude_pattern,
+  DROP COLUMN crawl_max_depth,
+  DROP COLUMN crawl_max_items;
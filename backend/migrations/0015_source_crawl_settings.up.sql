@@ -0,0 +1,5 @@
+ALTER TABLE data_sources
+  ADD COLUMN crawl_include_pattern VARCHAR(512) NULL,
+  ADD COLUMN crawl_exclude_pattern VARCHAR(512) NULL,
+  ADD COLUMN crawl_max_depth INT NOT NULL DEFAULT 1,
+  ADD COLUMN crawl_max_items INT NOT NULL DEFAULT 20;
@@ -0,0 +1,4 @@
+DROP INDEX idx_pending_articles_duplicate_of ON pending_articles;
+ALTER TABLE pending_articles
+  DROP COLUMN simhash,
+  DROP COLUMN duplicate_of;
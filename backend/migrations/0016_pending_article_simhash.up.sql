@@ -0,0 +1,5 @@
+ALTER TABLE pending_articles
+  ADD COLUMN simhash BIGINT UNSIGNED NULL,
+  ADD COLUMN duplicate_of BIGINT NULL;
+
+CREATE INDEX idx_pending_articles_duplicate_of ON pending_articles (duplicate_of);
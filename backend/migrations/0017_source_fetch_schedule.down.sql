@@ -0,0 +1,6 @@
+DROP INDEX idx_ingest_runs_source_status ON ingest_runs;
+DROP INDEX idx_data_sources_next_fetch ON data_sources;
+ALTER TABLE data_sources
+  DROP COLUMN fetch_interval_sec,
+  DROP COLUMN fetch_fail_count,
+  DROP COLUMN next_fetch_at;
@@ -0,0 +1,7 @@
+ALTER TABLE data_sources
+  ADD COLUMN fetch_interval_sec INT NOT NULL DEFAULT 1800,
+  ADD COLUMN fetch_fail_count INT NOT NULL DEFAULT 0,
+  ADD COLUMN next_fetch_at DATETIME NULL;
+
+CREATE INDEX idx_data_sources_next_fetch ON data_sources (next_fetch_at);
+CREATE INDEX idx_ingest_runs_source_status ON ingest_runs (source_id, status);
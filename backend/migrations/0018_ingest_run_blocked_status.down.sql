@@ -0,0 +1,3 @@
+UPDATE ingest_runs SET status = 'failed' WHERE status = 'blocked';
+ALTER TABLE ingest_runs
+  MODIFY COLUMN status ENUM('queued','running','succeeded','failed') NOT NULL DEFAULT 'queued';
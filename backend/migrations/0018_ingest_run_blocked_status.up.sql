@@ -0,0 +1,2 @@
+ALTER TABLE ingest_runs
+  MODIFY COLUMN status ENUM('queued','running','succeeded','failed','blocked') NOT NULL DEFAULT 'queued';
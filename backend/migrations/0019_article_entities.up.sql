@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS article_entities (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  pending_article_id BIGINT NULL,
+  article_id BIGINT NULL,
+  entity_type ENUM('fighter','event') NOT NULL,
+  entity_id BIGINT NOT NULL,
+  entity_name VARCHAR(255) NOT NULL DEFAULT '',
+  matched_text VARCHAR(255) NULL,
+  status ENUM('suggested','confirmed','rejected') NOT NULL DEFAULT 'suggested',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE KEY uk_article_entities_pending (pending_article_id, entity_type, entity_id),
+  KEY idx_article_entities_article (article_id),
+  KEY idx_article_entities_entity (entity_type, entity_id, status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
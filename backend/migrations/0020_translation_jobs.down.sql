@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS translation_jobs;
+ALTER TABLE articles
+  DROP COLUMN original_title,
+  DROP COLUMN original_content;
+ALTER TABLE data_sources
+  DROP COLUMN rights_translation;
@@ -0,0 +1,24 @@
+ALTER TABLE data_sources
+  ADD COLUMN rights_translation TINYINT(1) NOT NULL DEFAULT 0 AFTER rights_ai_summary;
+
+ALTER TABLE articles
+  ADD COLUMN original_title VARCHAR(255) NULL,
+  ADD COLUMN original_content MEDIUMTEXT NULL;
+
+CREATE TABLE IF NOT EXISTS translation_jobs (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  pending_article_id BIGINT NOT NULL,
+  source_id BIGINT NOT NULL,
+  status ENUM('pending','running','done','failed','manual_required') NOT NULL DEFAULT 'pending',
+  provider VARCHAR(64) NULL,
+  target_lang VARCHAR(16) NOT NULL,
+  original_title VARCHAR(255) NOT NULL,
+  original_body MEDIUMTEXT NULL,
+  title VARCHAR(255) NULL,
+  body MEDIUMTEXT NULL,
+  error_msg TEXT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  KEY idx_translation_jobs_status (status, id),
+  KEY idx_translation_jobs_pending (pending_article_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
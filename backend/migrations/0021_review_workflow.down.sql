@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS review_notes;
+DROP INDEX idx_pending_articles_source_status ON pending_articles;
+ALTER TABLE pending_articles
+  DROP COLUMN video_url,
+  DROP COLUMN reject_reason;
@@ -0,0 +1,14 @@
+ALTER TABLE pending_articles
+  ADD COLUMN video_url VARCHAR(512) NULL AFTER cover_url,
+  ADD COLUMN reject_reason VARCHAR(512) NULL AFTER status;
+
+CREATE INDEX idx_pending_articles_source_status ON pending_articles (source_id, status);
+
+CREATE TABLE IF NOT EXISTS review_notes (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  pending_article_id BIGINT NOT NULL,
+  reviewer_id BIGINT NULL,
+  body TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  KEY idx_review_notes_pending (pending_article_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
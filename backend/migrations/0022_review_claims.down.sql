@@ -0,0 +1,4 @@
+ALTER TABLE pending_articles
+  DROP COLUMN version,
+  DROP COLUMN claimed_by,
+  DROP COLUMN claimed_until;
@@ -0,0 +1,4 @@
+ALTER TABLE pending_articles
+  ADD COLUMN version INT NOT NULL DEFAULT 1,
+  ADD COLUMN claimed_by BIGINT NULL,
+  ADD COLUMN claimed_until DATETIME NULL;
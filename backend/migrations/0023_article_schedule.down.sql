@@ -0,0 +1,6 @@
+UPDATE articles SET status = 'published' WHERE status = 'scheduled';
+ALTER TABLE articles
+  DROP KEY idx_articles_status_publish_at,
+  DROP COLUMN publish_at,
+  DROP COLUMN embargo_until,
+  MODIFY COLUMN status ENUM('pending','published','offline') NOT NULL DEFAULT 'pending';
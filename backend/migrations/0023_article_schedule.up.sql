@@ -0,0 +1,5 @@
+ALTER TABLE articles
+  MODIFY COLUMN status ENUM('pending','scheduled','published','offline') NOT NULL DEFAULT 'pending',
+  ADD COLUMN publish_at DATETIME NULL,
+  ADD COLUMN embargo_until DATETIME NULL,
+  ADD KEY idx_articles_status_publish_at (status, publish_at);
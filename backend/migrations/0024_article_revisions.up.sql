@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS article_revisions (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  article_id BIGINT NOT NULL,
+  editor_id BIGINT NULL,
+  action VARCHAR(16) NOT NULL,
+  title VARCHAR(255) NOT NULL,
+  summary TEXT NULL,
+  content MEDIUMTEXT NOT NULL,
+  cover_url VARCHAR(512) NULL,
+  video_url VARCHAR(512) NULL,
+  status VARCHAR(16) NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  KEY idx_article_revisions_article (article_id, id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
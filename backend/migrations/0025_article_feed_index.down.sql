@@ -0,0 +1,2 @@
+DROP INDEX idx_articles_source_published ON articles;
+DROP INDEX idx_articles_status_published ON articles;
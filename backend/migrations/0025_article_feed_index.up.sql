@@ -0,0 +1,2 @@
+CREATE INDEX idx_articles_status_published ON articles (status, published_at, id);
+CREATE INDEX idx_articles_source_published ON articles (source_id, published_at, id);
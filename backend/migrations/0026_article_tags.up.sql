@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS tags (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  slug VARCHAR(64) NOT NULL,
+  name VARCHAR(64) NOT NULL,
+  kind ENUM('category','org','tag') NOT NULL DEFAULT 'tag',
+  keywords VARCHAR(1024) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE KEY uk_tags_slug (slug)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS tag_source_rules (
+  tag_id BIGINT NOT NULL,
+  source_id BIGINT NOT NULL,
+  PRIMARY KEY (tag_id, source_id),
+  KEY idx_tag_source_rules_source (source_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS article_tags (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  tag_id BIGINT NOT NULL,
+  pending_article_id BIGINT NULL,
+  article_id BIGINT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE KEY uk_article_tags_pending (pending_article_id, tag_id),
+  KEY idx_article_tags_tag (tag_id, article_id),
+  KEY idx_article_tags_article (article_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT IGNORE INTO tags (slug, name, kind, keywords) VALUES
+  ('news', '资讯', 'category', ''),
+  ('results', '赛果', 'category', 'results,赛果,击败,胜出,卫冕'),
+  ('interviews', '专访', 'category', 'interview,专访,采访'),
+  ('videos', '视频', 'category', 'highlights,视频,集锦'),
+  ('ufc', 'UFC', 'org', 'UFC,Dana White'),
+  ('one', 'ONE', 'org', 'ONE Championship,ONE FC'),
+  ('pfl', 'PFL', 'org', 'PFL');
+
+INSERT IGNORE INTO tag_source_rules (tag_id, source_id)
+SELECT t.id, s.id
+FROM tags t
+JOIN data_sources s ON s.platform = t.slug
+WHERE t.kind = 'org' AND s.source_type = 'news';
@@ -0,0 +1,3 @@
+DROP INDEX ft_articles_text ON articles;
+DROP INDEX ft_events_name ON events;
+DROP INDEX ft_fighters_names ON fighters;
@@ -0,0 +1,3 @@
+ALTER TABLE fighters ADD FULLTEXT INDEX ft_fighters_names (name, name_zh, nickname) WITH PARSER ngram;
+ALTER TABLE events ADD FULLTEXT INDEX ft_events_name (name, venue) WITH PARSER ngram;
+ALTER TABLE articles ADD FULLTEXT INDEX ft_articles_text (title, content) WITH PARSER ngram;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS featured_slots (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  kind ENUM('carousel','pinned','event') NOT NULL,
+  target_type ENUM('article','event') NOT NULL,
+  target_id BIGINT NOT NULL,
+  title VARCHAR(255) NULL,
+  image_url VARCHAR(512) NULL,
+  position INT NOT NULL DEFAULT 0,
+  starts_at DATETIME NULL,
+  ends_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  KEY idx_featured_slots_kind_position (kind, position)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,2 @@
+ALTER TABLE admin_users
+  DROP COLUMN password_changed_at;
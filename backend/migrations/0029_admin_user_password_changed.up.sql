@@ -0,0 +1,2 @@
+ALTER TABLE admin_users
+  ADD COLUMN password_changed_at DATETIME NULL AFTER password_hash;
@@ -0,0 +1 @@
+-- Re-pointing history rows at recreated bouts is a data repair; there is nothing to undo.
@@ -0,0 +1,5 @@
+UPDATE bout_result_history h
+  JOIN bouts b ON b.event_id = h.event_id AND b.sequence_no = h.sequence_no
+  LEFT JOIN bouts current ON current.id = h.bout_id
+SET h.bout_id = b.id
+WHERE current.id IS NULL;
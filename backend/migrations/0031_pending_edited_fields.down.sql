@@ -0,0 +1,2 @@
+ALTER TABLE pending_articles
+  DROP COLUMN edited_fields;
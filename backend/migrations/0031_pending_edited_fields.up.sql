@@ -0,0 +1,2 @@
+ALTER TABLE pending_articles
+  ADD COLUMN edited_fields VARCHAR(64) NULL;
@@ -0,0 +1 @@
+ALTER TABLE articles MODIFY COLUMN content TEXT NOT NULL;
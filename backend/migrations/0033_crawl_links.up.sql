@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS crawl_links (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  source_id BIGINT NOT NULL,
+  url VARCHAR(512) NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE KEY uk_crawl_links_source_url (source_id, url)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
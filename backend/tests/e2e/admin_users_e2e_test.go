@@ -0,0 +1,128 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/auth"
+	"github.com/bajiaozhi/w-mma/backend/internal/bootstrap"
+	mysqlrepo "github.com/bajiaozhi/w-mma/backend/internal/repository/mysql"
+)
+
+func TestE2E_AdminUsersStoredInMySQL(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	repo := mysqlrepo.NewAdminUserRepository(db)
+	hash, err := bcrypt.GenerateFromPassword([]byte("admin123456"), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatalf("generate hash failed: %v", err)
+	}
+	if created, err := auth.BootstrapUser(ctx, repo, "admin", string(hash)); err != nil || !created {
+		t.Fatalf("expected the env admin bootstrapped, got %v %v", created, err)
+	}
+	if created, err := auth.BootstrapUser(ctx, repo, "other", string(hash)); err != nil || created {
+		t.Fatalf("expected bootstrap skipped on a populated table, got %v %v", created, err)
+	}
+
+	svc := auth.NewService(repo, "test-secret")
+	if _, err := svc.Login(ctx, "admin", "admin123456"); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	admin, err := repo.FindByUsername(ctx, "admin")
+	if err != nil || admin.LastLoginAt == nil {
+		t.Fatalf("expected last login recorded, got %+v %v", admin, err)
+	}
+
+	editor, err := svc.CreateUser(ctx, "editor", "editor-pass")
+	if err != nil {
+		t.Fatalf("create editor failed: %v", err)
+	}
+	if _, err := svc.CreateUser(ctx, "editor", "editor-pass"); !errors.Is(err, auth.ErrDuplicateUser) {
+		t.Fatalf("expected duplicate username, got %v", err)
+	}
+	if _, err := svc.SetUserStatus(ctx, admin.ID, editor.ID, auth.StatusDisabled); err != nil {
+		t.Fatalf("disable editor failed: %v", err)
+	}
+	if _, err := svc.SetUserStatus(ctx, admin.ID, editor.ID, auth.StatusDisabled); err != nil {
+		t.Fatalf("expected disabling twice to be a no-op, got %v", err)
+	}
+	if _, err := svc.Login(ctx, "editor", "editor-pass"); !errors.Is(err, auth.ErrUserDisabled) {
+		t.Fatalf("expected disabled login refused, got %v", err)
+	}
+
+	issuedAt := time.Now().Add(-time.Minute)
+	if err := svc.ResetPassword(ctx, admin.ID, "rotated-pass"); err != nil {
+		t.Fatalf("reset password failed: %v", err)
+	}
+	if err := svc.CheckSession(ctx, admin.ID, issuedAt); !errors.Is(err, auth.ErrSessionRevoked) {
+		t.Fatalf("expected the earlier session revoked, got %v", err)
+	}
+	if err := svc.ResetPassword(ctx, 9999, "rotated-pass"); !errors.Is(err, auth.ErrUserNotFound) {
+		t.Fatalf("expected missing user, got %v", err)
+	}
+
+	users, err := svc.ListUsers(ctx)
+	if err != nil || len(users) != 2 || users[1].Status != auth.StatusDisabled {
+		t.Fatalf("expected both users listed, got %+v %v", users, err)
+	}
+}
+
+func TestE2E_ConcurrentBootstrapCreatesOneAdmin(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	repo := mysqlrepo.NewAdminUserRepository(db)
+	const instances = 8
+	var (
+		wg      sync.WaitGroup
+		created atomic.Int32
+		errs    = make(chan error, instances)
+	)
+	for i := 0; i < instances; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := auth.BootstrapUser(ctx, repo, "admin", "hash")
+			if err != nil {
+				errs <- err
+				return
+			}
+			if ok {
+				created.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("expected racing bootstraps to succeed or skip, got %v", err)
+	}
+	if created.Load() != 1 {
+		t.Fatalf("expected exactly one bootstrap to create the admin, got %d", created.Load())
+	}
+	if count, err := repo.Count(ctx); err != nil || count != 1 {
+		t.Fatalf("expected one admin user, got %d %v", count, err)
+	}
+}
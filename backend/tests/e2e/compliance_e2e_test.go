@@ -10,6 +10,7 @@ import (
 	"github.com/bajiaozhi/w-mma/backend/internal/bootstrap"
 	mysqlrepo "github.com/bajiaozhi/w-mma/backend/internal/repository/mysql"
 	"github.com/bajiaozhi/w-mma/backend/internal/review"
+	"github.com/bajiaozhi/w-mma/backend/internal/revision"
 	"github.com/bajiaozhi/w-mma/backend/internal/takedown"
 )
 
@@ -37,7 +38,7 @@ func TestE2E_TakedownOfflinesArticleAndPublicAPIHidesIt(t *testing.T) {
 	if err != nil {
 		t.Fatalf("create pending failed: %v", err)
 	}
-	if err := reviewSvc.Approve(context.Background(), pending.ID, 9001); err != nil {
+	if err := reviewSvc.Approve(context.Background(), pending.ID, 9001, 0); err != nil {
 		t.Fatalf("approve failed: %v", err)
 	}
 
@@ -60,7 +61,7 @@ func TestE2E_TakedownOfflinesArticleAndPublicAPIHidesIt(t *testing.T) {
 		t.Fatalf("create takedown failed: %v", err)
 	}
 
-	if err := takedownSvc.Resolve(context.Background(), ticket.ID, takedown.ActionOfflined); err != nil {
+	if err := takedownSvc.Resolve(context.Background(), ticket.ID, takedown.ActionOfflined, 1); err != nil {
 		t.Fatalf("resolve takedown failed: %v", err)
 	}
 
@@ -71,4 +72,13 @@ func TestE2E_TakedownOfflinesArticleAndPublicAPIHidesIt(t *testing.T) {
 	if len(after) != 0 {
 		t.Fatalf("expected 0 published article after takedown, got %d", len(after))
 	}
+
+	revisions, err := mysqlrepo.NewArticleRevisionRepository(db).List(context.Background(), before[0].ID)
+	if err != nil {
+		t.Fatalf("list revisions failed: %v", err)
+	}
+	if len(revisions) != 2 || revisions[0].Action != revision.ActionOfflined || revisions[0].EditorID != 1 ||
+		revisions[1].Action != revision.ActionPublished || revisions[1].EditorID != 9001 {
+		t.Fatalf("expected publish and takedown revisions, got %+v", revisions)
+	}
 }
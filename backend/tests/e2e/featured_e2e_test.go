@@ -0,0 +1,98 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/bootstrap"
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/featured"
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+	mysqlrepo "github.com/bajiaozhi/w-mma/backend/internal/repository/mysql"
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
+)
+
+func TestE2E_FeaturedSlotsShapeTheHomeScreen(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	upcoming := model.Event{
+		Org:      "UFC",
+		Name:     "UFC 301",
+		Status:   "scheduled",
+		StartsAt: time.Now().Add(72 * time.Hour).UTC(),
+		Venue:    "Rio de Janeiro",
+	}
+	if err := db.Create(&upcoming).Error; err != nil {
+		t.Fatalf("create event failed: %v", err)
+	}
+	articles := mysqlrepo.NewArticleRepository(db)
+	reviewSvc := review.NewService(articles)
+	for _, title := range []string{"pinned-title", "latest-title"} {
+		pending, err := articles.CreatePending(ctx, review.PendingArticle{
+			Title:     title,
+			Summary:   title + "-summary",
+			SourceURL: fmt.Sprintf("https://example.com/%s/%d", title, time.Now().UnixNano()),
+		})
+		if err != nil {
+			t.Fatalf("create pending failed: %v", err)
+		}
+		if err := reviewSvc.Approve(ctx, pending.ID, 9001, 0); err != nil {
+			t.Fatalf("approve failed: %v", err)
+		}
+	}
+	feed, err := articles.ListFeed(ctx, review.FeedQuery{Limit: 10})
+	if err != nil || len(feed) != 2 {
+		t.Fatalf("expected 2 published articles, got %+v %v", feed, err)
+	}
+	pinnedID := feed[1].ID
+
+	svc := featured.NewService(mysqlrepo.NewFeaturedSlotRepository(db), articles, event.NewService(mysqlrepo.NewEventRepository(db)))
+	ended := time.Now().Add(-time.Hour)
+	slot, err := svc.Create(ctx, featured.SlotInput{Kind: featured.KindCarousel, TargetType: featured.TargetEvent, TargetID: upcoming.ID, EndsAt: &ended})
+	if err != nil {
+		t.Fatalf("create carousel slot failed: %v", err)
+	}
+	if _, err := svc.Create(ctx, featured.SlotInput{Kind: featured.KindPinned, TargetType: featured.TargetArticle, TargetID: pinnedID}); err != nil {
+		t.Fatalf("create pinned slot failed: %v", err)
+	}
+	if _, err := svc.Create(ctx, featured.SlotInput{Kind: featured.KindPinned, TargetType: featured.TargetArticle, TargetID: 999}); !errors.Is(err, featured.ErrTargetNotFound) {
+		t.Fatalf("expected missing article rejected, got %v", err)
+	}
+
+	home, err := svc.Home(ctx)
+	if err != nil {
+		t.Fatalf("home failed: %v", err)
+	}
+	if len(home.Carousel) != 0 || len(home.Pinned) != 1 || home.Pinned[0].ID != pinnedID {
+		t.Fatalf("expected the ended slot hidden and the pinned article shown, got %+v", home)
+	}
+	if len(home.Latest) != 1 || home.Latest[0].Title != "latest-title" || len(home.NextEvents) != 1 {
+		t.Fatalf("expected latest news without the pinned article and the next event, got %+v", home)
+	}
+
+	if _, err := svc.Update(ctx, slot.ID, featured.SlotInput{Kind: featured.KindCarousel, TargetType: featured.TargetEvent, TargetID: upcoming.ID, Title: "下周 UFC 301"}); err != nil {
+		t.Fatalf("update slot failed: %v", err)
+	}
+	home, err = svc.Home(ctx)
+	if err != nil || len(home.Carousel) != 1 || home.Carousel[0].Title != "下周 UFC 301" {
+		t.Fatalf("expected the reopened slot in the carousel, got %+v %v", home.Carousel, err)
+	}
+	if err := svc.Delete(ctx, slot.ID); err != nil {
+		t.Fatalf("delete slot failed: %v", err)
+	}
+	if err := svc.Delete(ctx, slot.ID); !errors.Is(err, featured.ErrSlotNotFound) {
+		t.Fatalf("expected second delete to miss, got %v", err)
+	}
+}
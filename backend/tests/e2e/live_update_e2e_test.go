@@ -4,9 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"math/rand"
 	"net/http"
 	"net/http/httptest"
-	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -17,41 +17,82 @@ import (
 	"github.com/bajiaozhi/w-mma/backend/internal/live"
 )
 
-type fakeLiveClient struct {
-	mu     sync.Mutex
-	winner string
+type fakeLiveProvider struct {
+	mu         sync.Mutex
+	winnerSide string
 }
 
-func (c *fakeLiveClient) SetWinner(winner string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.winner = winner
+func (p *fakeLiveProvider) SetWinnerSide(side string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.winnerSide = side
 }
 
-func (c *fakeLiveClient) FetchEventResults(context.Context, int64) ([]live.BoutResult, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	if c.winner == "" {
-		return []live.BoutResult{}, nil
+func (p *fakeLiveProvider) FetchEventResults(context.Context, string) (live.EventResults, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.winnerSide == "" {
+		return live.EventResults{Status: "live", Bouts: []live.BoutResult{{}}}, nil
 	}
-	return []live.BoutResult{{
-		BoutID: 1001,
-		Winner: c.winner,
-		Method: "KO",
-		Round:  2,
-	}}, nil
+	return live.EventResults{
+		Status: "live",
+		Bouts: []live.BoutResult{{
+			WinnerSide: p.winnerSide,
+			Method:     "KO",
+			Round:      2,
+			Result:     "KO",
+		}},
+	}, nil
 }
 
 type liveEventRepoAdapter struct {
 	eventRepo *event.InMemoryRepository
 }
 
-func (a *liveEventRepoAdapter) UpsertBoutResult(ctx context.Context, result live.BoutResult) error {
-	winnerID, _ := strconv.ParseInt(result.Winner, 10, 64)
-	return a.eventRepo.UpsertBoutResult(ctx, 10, result.BoutID, winnerID, result.Method)
+func (a *liveEventRepoAdapter) ListTrackableEvents(ctx context.Context, _ []string) ([]live.UFCTrackableEvent, error) {
+	card, err := a.eventRepo.GetEventCard(ctx, 10)
+	if err != nil {
+		return nil, err
+	}
+	return []live.UFCTrackableEvent{{
+		ID:          card.ID,
+		Org:         card.Org,
+		Status:      card.Status,
+		ExternalURL: "https://www.ufc.com/event/ufc-fight-night-10",
+	}}, nil
+}
+
+func (a *liveEventRepoAdapter) ListBoutSnapshots(ctx context.Context, eventID int64) ([]live.UFCBoutSnapshot, error) {
+	card, err := a.eventRepo.GetEventCard(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]live.UFCBoutSnapshot, 0, len(card.Bouts))
+	for idx, bout := range card.Bouts {
+		items = append(items, live.UFCBoutSnapshot{
+			BoutID:        bout.ID,
+			SequenceNo:    idx + 1,
+			RedFighterID:  bout.RedFighterID,
+			BlueFighterID: bout.BlueFighterID,
+			WinnerID:      bout.WinnerID,
+		})
+	}
+	return items, nil
+}
+
+func (a *liveEventRepoAdapter) UpdateEventStatus(ctx context.Context, eventID int64, status string) error {
+	return a.eventRepo.UpdateEvent(ctx, eventID, event.UpdateEventInput{Status: status})
 }
 
-func TestE2E_LiveEventUpdatesEvery30Seconds(t *testing.T) {
+func (a *liveEventRepoAdapter) UpsertBoutResult(ctx context.Context, eventID int64, boutID int64, winnerID int64, _ string, _ int, _ int, result string) error {
+	return a.eventRepo.UpsertBoutResult(ctx, eventID, boutID, winnerID, result)
+}
+
+func (a *liveEventRepoAdapter) UpdateBoutLiveState(ctx context.Context, eventID int64, boutID int64, state string, currentRound int) error {
+	return a.eventRepo.UpdateBoutLiveState(ctx, eventID, boutID, state, currentRound)
+}
+
+func TestE2E_LiveEventUpdatesThroughProviderRegistry(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	eventRepo := event.NewInMemoryRepository()
@@ -62,19 +103,29 @@ func TestE2E_LiveEventUpdatesEvery30Seconds(t *testing.T) {
 	ts := httptest.NewServer(r)
 	defer ts.Close()
 
-	client := &fakeLiveClient{}
-	updater := live.NewUpdater(&liveEventRepoAdapter{eventRepo: eventRepo}, client)
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	go live.RunScheduler(ctx, updater, 10)
-
-	time.AfterFunc(1*time.Second, func() {
-		client.SetWinner("20")
+	provider := &fakeLiveProvider{}
+	monitor := live.NewUFCLiveMonitor(
+		&liveEventRepoAdapter{eventRepo: eventRepo},
+		live.NewProviderRegistry(map[string]live.ResultsProvider{"UFC": provider}),
+		nil,
+		live.UFCLiveMonitorConfig{
+			MinPollInterval: time.Millisecond,
+			MaxPollInterval: time.Millisecond,
+			Random:          rand.New(rand.NewSource(7)),
+		},
+	)
+
+	ctx := context.Background()
+	time.AfterFunc(200*time.Millisecond, func() {
+		provider.SetWinnerSide("red")
 	})
 
-	deadline := time.Now().Add(35 * time.Second)
+	deadline := time.Now().Add(5 * time.Second)
 	for time.Now().Before(deadline) {
+		if err := monitor.RunOnce(ctx); err != nil {
+			t.Fatalf("run live monitor failed: %v", err)
+		}
+
 		res, err := http.Get(ts.URL + "/api/events/10")
 		if err != nil {
 			t.Fatalf("request event card failed: %v", err)
@@ -95,8 +146,8 @@ func TestE2E_LiveEventUpdatesEvery30Seconds(t *testing.T) {
 		if len(card.Bouts) > 0 && card.Bouts[0].WinnerID == 20 {
 			return
 		}
-		time.Sleep(1 * time.Second)
+		time.Sleep(50 * time.Millisecond)
 	}
 
-	t.Fatalf("expected winner update within 35 seconds")
+	t.Fatalf("expected winner update within 5 seconds")
 }
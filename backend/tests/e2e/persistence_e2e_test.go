@@ -2,6 +2,7 @@ package e2e
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"testing"
@@ -36,7 +37,7 @@ func TestE2E_PersistedArticleSurvivesRepositoryRecreate(t *testing.T) {
 		t.Fatalf("create pending failed: %v", err)
 	}
 
-	if err := svc.Approve(context.Background(), pending.ID, 9001); err != nil {
+	if err := svc.Approve(context.Background(), pending.ID, 9001, 0); err != nil {
 		t.Fatalf("approve failed: %v", err)
 	}
 
@@ -53,3 +54,86 @@ func TestE2E_PersistedArticleSurvivesRepositoryRecreate(t *testing.T) {
 		t.Fatalf("expected 1 published article, got %d", len(items))
 	}
 }
+
+func TestE2E_TaggedArticleIsServedByItsTagFeeds(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	repo := mysqlrepo.NewArticleRepository(db)
+	svc := review.NewService(repo)
+	pending, err := repo.CreatePending(ctx, review.PendingArticle{
+		Title:     "tagged-title",
+		Summary:   "tagged-summary",
+		SourceURL: fmt.Sprintf("https://example.com/%d", time.Now().UnixNano()),
+		Tags:      []string{"ufc", "results", "missing"},
+	})
+	if err != nil {
+		t.Fatalf("create pending failed: %v", err)
+	}
+	items, err := svc.ListPending(ctx, review.PendingFilter{})
+	if err != nil || len(items) != 1 || len(items[0].Tags) != 2 {
+		t.Fatalf("expected seeded suggestions kept and unknown ones dropped, got %+v %v", items, err)
+	}
+
+	if _, err := svc.SetPendingTags(ctx, pending.ID, []string{"missing"}); !errors.Is(err, review.ErrUnknownTag) {
+		t.Fatalf("expected unknown tag rejected, got %v", err)
+	}
+	if _, err := svc.SetPendingTags(ctx, pending.ID, []string{"ufc", "interviews"}); err != nil {
+		t.Fatalf("set tags failed: %v", err)
+	}
+	if err := svc.Approve(ctx, pending.ID, 9001, 0); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+
+	for tag, want := range map[string]int{"interviews": 1, "ufc": 1, "results": 0} {
+		feed, err := repo.ListFeed(ctx, review.FeedQuery{Tag: tag, Limit: 10})
+		if err != nil || len(feed) != want {
+			t.Fatalf("expected %d articles tagged %s, got %+v %v", want, tag, feed, err)
+		}
+	}
+	feed, _ := repo.ListFeed(ctx, review.FeedQuery{Tag: "ufc", Limit: 10})
+	article, err := repo.GetPublished(ctx, feed[0].ID)
+	if err != nil || len(article.Tags) != 2 || article.Tags[0] != "ufc" {
+		t.Fatalf("expected published article to carry its tags, got %+v %v", article.Tags, err)
+	}
+}
+
+func TestE2E_HasSeenScopesGUIDsToTheirSource(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	repo := mysqlrepo.NewArticleRepository(db)
+	if _, err := repo.CreatePending(ctx, review.PendingArticle{
+		SourceID:  1,
+		GUID:      "1234",
+		Title:     "guid-title",
+		Summary:   "guid-summary",
+		SourceURL: "https://one.example.com/story",
+	}); err != nil {
+		t.Fatalf("create pending failed: %v", err)
+	}
+
+	if seen, err := repo.HasSeen(ctx, 1, "1234", ""); err != nil || !seen {
+		t.Fatalf("expected the GUID seen within its source, got %v %v", seen, err)
+	}
+	if seen, err := repo.HasSeen(ctx, 2, "1234", "https://two.example.com/story"); err != nil || seen {
+		t.Fatalf("expected the same GUID from another source treated as new, got %v %v", seen, err)
+	}
+	if seen, err := repo.HasSeen(ctx, 2, "other", "https://one.example.com/story"); err != nil || !seen {
+		t.Fatalf("expected the link seen from any source, got %v %v", seen, err)
+	}
+}
@@ -0,0 +1,77 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/bootstrap"
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+	mysqlrepo "github.com/bajiaozhi/w-mma/backend/internal/repository/mysql"
+	"github.com/bajiaozhi/w-mma/backend/internal/review"
+	"github.com/bajiaozhi/w-mma/backend/internal/search"
+)
+
+func TestE2E_SearchFindsFightersEventsAndArticles(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	nameZH := "亚历克斯 佩雷拉"
+	nickname := "Poatan"
+	if err := db.Create(&model.Fighter{Name: "Alex Pereira", NameZH: &nameZH, Nickname: &nickname}).Error; err != nil {
+		t.Fatalf("create fighter failed: %v", err)
+	}
+	if err := db.Create(&model.Event{
+		Org:      "UFC",
+		Name:     "UFC 300: Pereira vs Hill",
+		Status:   "completed",
+		StartsAt: time.Date(2024, 4, 13, 22, 0, 0, 0, time.UTC),
+		Venue:    "T-Mobile Arena",
+	}).Error; err != nil {
+		t.Fatalf("create event failed: %v", err)
+	}
+
+	repo := mysqlrepo.NewArticleRepository(db)
+	pending, err := repo.CreatePending(ctx, review.PendingArticle{
+		Title:     "佩雷拉首回合击倒希尔",
+		Summary:   "Pereira knocked out Hill in the first round.",
+		SourceURL: fmt.Sprintf("https://example.com/%d", time.Now().UnixNano()),
+	})
+	if err != nil {
+		t.Fatalf("create pending failed: %v", err)
+	}
+	if err := review.NewService(repo).Approve(ctx, pending.ID, 9001, 0); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+
+	svc := search.NewService(mysqlrepo.NewSearchRepository(db))
+	items, err := svc.Search(ctx, "pereira", nil, 0)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(items) != 3 || items[0].Type != search.TypeFighter || items[1].Type != search.TypeEvent || items[2].Type != search.TypeArticle {
+		t.Fatalf("expected fighter, event and article, got %+v", items)
+	}
+	if items[2].Snippet != "<em>Pereira</em> knocked out Hill in the first round." {
+		t.Fatalf("unexpected article snippet %q", items[2].Snippet)
+	}
+
+	items, err = svc.Search(ctx, "佩雷拉", nil, 0)
+	if err != nil || len(items) != 2 || items[0].Type != search.TypeArticle || items[0].Highlight != "<em>佩雷拉</em>首回合击倒希尔" {
+		t.Fatalf("expected the chinese title ranked above the fighter's chinese name, got %+v %v", items, err)
+	}
+
+	items, err = svc.Search(ctx, "pereira ankalaev", nil, 0)
+	if err != nil || len(items) != 0 {
+		t.Fatalf("expected every term required, got %+v %v", items, err)
+	}
+}
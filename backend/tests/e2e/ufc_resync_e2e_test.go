@@ -0,0 +1,292 @@
+package e2e
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bajiaozhi/w-mma/backend/internal/bootstrap"
+	"github.com/bajiaozhi/w-mma/backend/internal/event"
+	"github.com/bajiaozhi/w-mma/backend/internal/model"
+	mysqlrepo "github.com/bajiaozhi/w-mma/backend/internal/repository/mysql"
+	"github.com/bajiaozhi/w-mma/backend/internal/source"
+	"github.com/bajiaozhi/w-mma/backend/internal/ufc"
+)
+
+func TestE2E_UFCResyncUpdatesBoutsInPlace(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	card := model.Event{Org: "UFC", Name: "UFC 302", Status: "completed", StartsAt: time.Now().Add(-24 * time.Hour).UTC()}
+	if err := db.Create(&card).Error; err != nil {
+		t.Fatalf("create event failed: %v", err)
+	}
+	fighters := make([]model.Fighter, 4)
+	for idx, name := range []string{"Red One", "Blue One", "Red Two", "Blue Two"} {
+		record := "10-2"
+		fighters[idx] = model.Fighter{Name: name, Record: &record}
+		if err := db.Create(&fighters[idx]).Error; err != nil {
+			t.Fatalf("create fighter failed: %v", err)
+		}
+	}
+	redOne, blueOne, redTwo, blueTwo := fighters[0].ID, fighters[1].ID, fighters[2].ID, fighters[3].ID
+
+	syncRepo := mysqlrepo.NewUFCSyncRepository(db)
+	if err := syncRepo.ReplaceEventBouts(ctx, card.ID, []ufc.BoutRecord{
+		{RedFighterID: redOne, BlueFighterID: blueOne, WinnerID: redOne, Method: "KO/TKO", Round: 1, Result: "KO/TKO"},
+		{RedFighterID: redTwo, BlueFighterID: blueTwo},
+	}); err != nil {
+		t.Fatalf("first sync failed: %v", err)
+	}
+	eventRepo := mysqlrepo.NewEventRepository(db)
+	before, err := eventRepo.GetEventCard(ctx, card.ID)
+	if err != nil || len(before.Bouts) != 2 {
+		t.Fatalf("expected two bouts, got %+v %v", before.Bouts, err)
+	}
+
+	// The card is reordered and the first bout is overturned to a no contest.
+	if err := syncRepo.ReplaceEventBouts(ctx, card.ID, []ufc.BoutRecord{
+		{RedFighterID: redTwo, BlueFighterID: blueTwo},
+		{RedFighterID: redOne, BlueFighterID: blueOne, Method: "Overturned", Result: "No Contest"},
+	}); err != nil {
+		t.Fatalf("resync failed: %v", err)
+	}
+	after, err := eventRepo.GetEventCard(ctx, card.ID)
+	if err != nil || len(after.Bouts) != 2 {
+		t.Fatalf("expected two bouts, got %+v %v", after.Bouts, err)
+	}
+	overturned := after.Bouts[1]
+	if overturned.ID != before.Bouts[0].ID || overturned.WinnerID != 0 || overturned.Result != "No Contest" {
+		t.Fatalf("expected the first bout updated in place, got %+v", overturned)
+	}
+	var history []model.BoutResultHistory
+	if err := db.Where("event_id = ?", card.ID).Order("id ASC").Find(&history).Error; err != nil {
+		t.Fatalf("load history failed: %v", err)
+	}
+	if len(history) != 2 || history[0].BoutID != overturned.ID || history[1].BoutID != overturned.ID || history[1].SequenceNo != 2 {
+		t.Fatalf("expected both changes recorded against the moved bout, got %+v", history)
+	}
+
+	var red model.Fighter
+	if err := db.Where("id = ?", redOne).Take(&red).Error; err != nil {
+		t.Fatalf("load fighter failed: %v", err)
+	}
+	if red.Record == nil || *red.Record != "10-2" {
+		t.Fatalf("expected the record left to the profile sync, got %v", red.Record)
+	}
+}
+
+func TestE2E_UFCResyncKeepsLockedBoutsAsTheyAre(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	card := model.Event{Org: "UFC", Name: "UFC 303", Status: "live", StartsAt: time.Now().UTC()}
+	if err := db.Create(&card).Error; err != nil {
+		t.Fatalf("create event failed: %v", err)
+	}
+	fighters := make([]model.Fighter, 4)
+	for idx, name := range []string{"Red One", "Blue One", "Red Two", "Blue Two"} {
+		fighters[idx] = model.Fighter{Name: name}
+		if err := db.Create(&fighters[idx]).Error; err != nil {
+			t.Fatalf("create fighter failed: %v", err)
+		}
+	}
+	redOne, blueOne, redTwo, blueTwo := fighters[0].ID, fighters[1].ID, fighters[2].ID, fighters[3].ID
+
+	syncRepo := mysqlrepo.NewUFCSyncRepository(db)
+	bouts := []ufc.BoutRecord{
+		{RedFighterID: redOne, BlueFighterID: blueOne},
+		{RedFighterID: redTwo, BlueFighterID: blueTwo},
+	}
+	if err := syncRepo.ReplaceEventBouts(ctx, card.ID, bouts); err != nil {
+		t.Fatalf("first sync failed: %v", err)
+	}
+	eventRepo := mysqlrepo.NewEventRepository(db)
+	before, err := eventRepo.GetEventCard(ctx, card.ID)
+	if err != nil || len(before.Bouts) != 2 {
+		t.Fatalf("expected two bouts, got %+v %v", before.Bouts, err)
+	}
+	corrected, live := before.Bouts[0], before.Bouts[1]
+
+	if err := eventRepo.RecordCorrectedBoutResult(ctx, card.ID, corrected.ID, event.BoutResultInput{
+		WinnerID: blueOne, Method: "Submission", Round: 2, Result: "Submission",
+	}, 9001); err != nil {
+		t.Fatalf("correct result failed: %v", err)
+	}
+	if err := eventRepo.UpdateBoutLiveState(ctx, card.ID, live.ID, event.BoutStateInProgress, 2); err != nil {
+		t.Fatalf("update live state failed: %v", err)
+	}
+	if err := db.Model(&model.Bout{}).Where("id = ?", live.ID).Update("result_locked", true).Error; err != nil {
+		t.Fatalf("lock bout failed: %v", err)
+	}
+
+	bouts[0].WinnerID, bouts[0].Method, bouts[0].Round, bouts[0].Result = redOne, "KO/TKO", 1, "KO/TKO"
+	if err := syncRepo.ReplaceEventBouts(ctx, card.ID, bouts); err != nil {
+		t.Fatalf("resync failed: %v", err)
+	}
+	after, err := eventRepo.GetEventCard(ctx, card.ID)
+	if err != nil || len(after.Bouts) != 2 {
+		t.Fatalf("expected two bouts, got %+v %v", after.Bouts, err)
+	}
+	if got := after.Bouts[0]; got.WinnerID != blueOne || got.Method != "Submission" {
+		t.Fatalf("expected the correction kept over the scraped result, got %+v", got)
+	}
+	if got := after.Bouts[1]; got.ID != live.ID || got.LiveState != event.BoutStateInProgress || got.CurrentRound != 2 {
+		t.Fatalf("expected the locked bout's live state and round carried over, got %+v", got)
+	}
+}
+
+func TestE2E_ScraperWriteSkipsBoutLockedAfterItsSnapshot(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	card := model.Event{Org: "UFC", Name: "UFC 304", Status: "live", StartsAt: time.Now().UTC()}
+	if err := db.Create(&card).Error; err != nil {
+		t.Fatalf("create event failed: %v", err)
+	}
+	fighters := make([]model.Fighter, 2)
+	for idx, name := range []string{"Red One", "Blue One"} {
+		record := "10-2"
+		fighters[idx] = model.Fighter{Name: name, Record: &record}
+		if err := db.Create(&fighters[idx]).Error; err != nil {
+			t.Fatalf("create fighter failed: %v", err)
+		}
+	}
+	red, blue := fighters[0].ID, fighters[1].ID
+	if err := mysqlrepo.NewUFCSyncRepository(db).ReplaceEventBouts(ctx, card.ID, []ufc.BoutRecord{
+		{RedFighterID: red, BlueFighterID: blue},
+	}); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+
+	eventRepo := mysqlrepo.NewEventRepository(db)
+	snapshots, err := eventRepo.ListUFCLiveBoutSnapshots(ctx, card.ID)
+	if err != nil || len(snapshots) != 1 || snapshots[0].ResultLocked {
+		t.Fatalf("expected one unlocked bout in the snapshot, got %+v %v", snapshots, err)
+	}
+	boutID := snapshots[0].BoutID
+
+	// An editor scores the bout after the monitor took its snapshot.
+	if err := eventRepo.RecordManualBoutResult(ctx, card.ID, boutID, event.BoutResultInput{
+		WinnerID: blue, Method: "Submission", Round: 2, Result: "Submission",
+	}, 9001); err != nil {
+		t.Fatalf("manual result failed: %v", err)
+	}
+	if err := eventRepo.UpsertUFCLiveBoutResult(ctx, card.ID, boutID, red, "KO/TKO", 1, 60, "KO/TKO"); err != nil {
+		t.Fatalf("scraper write failed: %v", err)
+	}
+
+	after, err := eventRepo.GetEventCard(ctx, card.ID)
+	if err != nil || len(after.Bouts) != 1 {
+		t.Fatalf("expected one bout, got %+v %v", after.Bouts, err)
+	}
+	if got := after.Bouts[0]; got.WinnerID != blue || got.Method != "Submission" {
+		t.Fatalf("expected the manual result kept, got %+v", got)
+	}
+	var history int64
+	if err := db.Model(&model.BoutResultHistory{}).Where("bout_id = ?", boutID).Count(&history).Error; err != nil || history != 1 {
+		t.Fatalf("expected only the manual change in history, got %d %v", history, err)
+	}
+	var winner model.Fighter
+	if err := db.Where("id = ?", blue).Take(&winner).Error; err != nil || winner.Record == nil || *winner.Record != "11-2" {
+		t.Fatalf("expected the record moved once by the manual result, got %v %v", winner.Record, err)
+	}
+}
+
+type syncTestSources struct {
+	src source.DataSource
+}
+
+func (s syncTestSources) GetAny(context.Context, int64) (source.DataSource, error) {
+	return s.src, nil
+}
+
+func (s syncTestSources) List(context.Context, source.ListFilter) ([]source.DataSource, error) {
+	return []source.DataSource{s.src}, nil
+}
+
+// syncTestScraper serves one completed event whose athlete profiles already count the fight.
+type syncTestScraper struct{}
+
+func (syncTestScraper) ListEventLinks(context.Context, string) ([]ufc.EventLink, error) {
+	return []ufc.EventLink{{Name: "UFC 305", URL: "https://ufc.test/event/ufc-305", StartsAt: time.Now().Add(-48 * time.Hour).UTC()}}, nil
+}
+
+func (syncTestScraper) GetEventCard(context.Context, string) (ufc.EventCard, error) {
+	return ufc.EventCard{
+		Name:   "UFC 305",
+		Status: "completed",
+		Bouts: []ufc.EventBout{{
+			RedName: "Red One", RedURL: "https://ufc.test/athlete/red-one",
+			BlueName: "Blue One", BlueURL: "https://ufc.test/athlete/blue-one",
+			WinnerSide: "red", Method: "KO/TKO", Round: 1, TimeSec: 95, Result: "KO/TKO",
+		}},
+	}, nil
+}
+
+func (syncTestScraper) ListAthleteLinks(context.Context, string) ([]string, error) {
+	return nil, nil
+}
+
+func (syncTestScraper) GetAthleteProfile(_ context.Context, athleteURL string) (ufc.AthleteProfile, error) {
+	if athleteURL == "https://ufc.test/athlete/red-one" {
+		return ufc.AthleteProfile{Name: "Red One", URL: athleteURL, Record: "11-2-0"}, nil
+	}
+	return ufc.AthleteProfile{Name: "Blue One", URL: athleteURL, Record: "9-3-0"}, nil
+}
+
+func TestE2E_UFCScheduleSyncKeepsProfileRecords(t *testing.T) {
+	dsn := setupMySQLDSNForTest(t)
+	db, err := bootstrap.NewMySQL(bootstrap.Config{MySQLDSN: dsn})
+	if err != nil {
+		t.Fatalf("open mysql failed: %v", err)
+	}
+	if err := bootstrap.RunMigrations(db, filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("run migrations failed: %v", err)
+	}
+
+	ctx := context.Background()
+	sources := syncTestSources{src: source.DataSource{ID: 1, SourceURL: "https://ufc.test/events", ParserKind: "ufc_schedule"}}
+	svc := ufc.NewService(sources, mysqlrepo.NewUFCSyncRepository(db), syncTestScraper{})
+	for run := 0; run < 2; run++ {
+		if _, err := svc.SyncSource(ctx, 1); err != nil {
+			t.Fatalf("sync %d failed: %v", run, err)
+		}
+	}
+
+	for name, want := range map[string]string{"Red One": "11-2-0", "Blue One": "9-3-0"} {
+		var fighter model.Fighter
+		if err := db.Where("name = ?", name).Take(&fighter).Error; err != nil {
+			t.Fatalf("load %s failed: %v", name, err)
+		}
+		if fighter.Record == nil || *fighter.Record != want {
+			t.Fatalf("expected %s's profile record %s kept, got %v", name, want, fighter.Record)
+		}
+	}
+	var history int64
+	if err := db.Model(&model.BoutResultHistory{}).Count(&history).Error; err != nil || history != 1 {
+		t.Fatalf("expected the scraped result recorded once, got %d %v", history, err)
+	}
+}
@@ -152,6 +152,18 @@ function buildResultText(bout = {}, winnerName = '') {
   return '结果待更新'
 }
 
+function buildLiveStateText(bout = {}) {
+  const state = String(bout.live_state || '').toLowerCase()
+  if (state === 'walkouts') {
+    return '选手入场中'
+  }
+  if (state === 'in_progress') {
+    const currentRound = toNumber(bout.current_round)
+    return currentRound > 0 ? `正在进行 · 第${currentRound}回合` : '正在进行'
+  }
+  return ''
+}
+
 function normalizeFighter(fighter = {}) {
   return {
     id: fighter.id || 0,
@@ -196,8 +208,12 @@ function normalizeBout(bout = {}, eventStatus = '') {
     }
   }
 
+  const liveStateText = buildLiveStateText(bout)
+
   return {
     ...bout,
+    is_current: Boolean(liveStateText),
+    live_state_text: liveStateText,
     winner_name: winnerName || '--',
     weight_class_text: mapWeightClass(bout.weight_class),
     result_text: buildResultText(bout, winnerName),
@@ -260,12 +276,23 @@ function normalizeEvent(event = {}) {
   }
 }
 
+// Related news is optional: a failure leaves the section empty instead of failing the page.
+async function loadRelatedArticles(eventID) {
+  try {
+    const data = await api.listEventArticles(eventID)
+    return data && Array.isArray(data.items) ? data.items : []
+  } catch (err) {
+    return []
+  }
+}
+
 async function loadEventCardWithContext(ctx, eventID) {
   ctx.setData({ loading: true, error: '' })
 
   try {
     const event = await api.getEventCard(eventID)
     const normalized = normalizeEventPayload(event)
+    const relatedArticles = await loadRelatedArticles(eventID)
     ctx.setData({
       loading: false,
       error: '',
@@ -273,6 +300,7 @@ async function loadEventCardWithContext(ctx, eventID) {
       bouts: normalized.bouts,
       mainCard: normalized.mainCard,
       prelims: normalized.prelims,
+      relatedArticles,
     })
   } catch (err) {
     ctx.setData({
@@ -281,6 +309,7 @@ async function loadEventCardWithContext(ctx, eventID) {
       bouts: [],
       mainCard: [],
       prelims: [],
+      relatedArticles: [],
       error: (err && err.message) || '战卡加载失败',
     })
   }
@@ -295,6 +324,7 @@ const pageDef = {
     bouts: [],
     mainCard: [],
     prelims: [],
+    relatedArticles: [],
   },
 
   async onLoad(options = {}) {
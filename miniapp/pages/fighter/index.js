@@ -277,6 +277,21 @@ function toHistoryItems(updates) {
     })
 }
 
+// Related news is optional: a failure leaves the section empty instead of failing the page.
+async function loadRelatedArticles(fighterID) {
+  try {
+    const data = await api.listFighterArticles(fighterID)
+    return (data && Array.isArray(data.items) ? data.items : []).map((item) => ({
+      id: item.id,
+      title: sanitizeText(item.title),
+      summary: sanitizeText(item.summary),
+      source_url: sanitizeText(item.source_url),
+    }))
+  } catch (err) {
+    return []
+  }
+}
+
 async function loadFighterWithContext(ctx, fighterID) {
   ctx.setData({ loading: true, error: '' })
 
@@ -290,6 +305,7 @@ async function loadFighterWithContext(ctx, fighterID) {
     const pfpTag = pfpValue ? `${pfpValue} P4P` : ''
     const historyItems = fighter ? toHistoryItems(fighter.updates) : []
     const isChinaFighter = fighter ? isChinaFighterCountry(fighter.country_zh, fighter.country) : false
+    const relatedArticles = fighter ? await loadRelatedArticles(fighterID) : []
     ctx.setData({
       loading: false,
       error: '',
@@ -303,6 +319,7 @@ async function loadFighterWithContext(ctx, fighterID) {
       titleTag: translateTitleStatus(titleValue),
       historyItems,
       isChinaFighter,
+      relatedArticles,
     })
   } catch (err) {
     ctx.setData({
@@ -317,6 +334,7 @@ async function loadFighterWithContext(ctx, fighterID) {
       titleTag: '',
       historyItems: [],
       isChinaFighter: false,
+      relatedArticles: [],
       error: (err && err.message) || '选手信息加载失败',
     })
   }
@@ -337,6 +355,7 @@ const pageDef = {
     titleTag: '',
     historyItems: [],
     isChinaFighter: false,
+    relatedArticles: [],
   },
 
   async onLoad(options = {}) {
@@ -18,20 +18,72 @@ function normalizeArticle(raw) {
   }
 }
 
+const ALL_TAB = { slug: '', name: '全部' }
+
+// withoutPinned keeps pinned articles from showing twice on the full feed.
+function withoutPinned(items, pinned, activeTag) {
+  if (activeTag || !pinned.length) {
+    return items
+  }
+  const pinnedIDs = pinned.map((item) => item.id)
+  return items.filter((item) => !pinnedIDs.includes(item.id))
+}
+
 const pageDef = {
   data: {
     loading: false,
     error: '',
     items: [],
+    tabs: [ALL_TAB],
+    activeTag: '',
+    featured: [],
+    pinned: [],
+    highlightEvent: null,
     updatedAtText: '',
   },
 
   async onLoad() {
+    await Promise.all([this.loadTabs(), this.loadHome(), this.loadArticles()])
+  },
+
+  // loadHome fills the carousel, pinned articles and highlighted event; the feed works without them.
+  async loadHome() {
+    try {
+      const data = (await api.getHome()) || {}
+      const pinned = Array.isArray(data.pinned) ? data.pinned : []
+      this.setData({
+        featured: Array.isArray(data.carousel) ? data.carousel : [],
+        pinned,
+        highlightEvent: data.highlight_event || null,
+        items: withoutPinned(this.data.items, pinned, this.data.activeTag),
+      })
+    } catch (err) {
+      this.setData({ featured: [], pinned: [], highlightEvent: null })
+    }
+  },
+
+  // loadTabs shows one tab per category; without them the page falls back to the full feed.
+  async loadTabs() {
+    try {
+      const data = await api.listCategories()
+      const categories = Array.isArray(data && data.items) ? data.items : []
+      this.setData({ tabs: [ALL_TAB, ...categories.map((item) => ({ slug: item.slug, name: item.name }))] })
+    } catch (err) {
+      this.setData({ tabs: [ALL_TAB] })
+    }
+  },
+
+  async onTabTap(event) {
+    const tag = (event && event.currentTarget && event.currentTarget.dataset && event.currentTarget.dataset.tag) || ''
+    if (tag === this.data.activeTag) {
+      return
+    }
+    this.setData({ activeTag: tag })
     await this.loadArticles()
   },
 
   async onPullDownRefresh() {
-    await this.loadArticles()
+    await Promise.all([this.loadHome(), this.loadArticles()])
     if (typeof wx.stopPullDownRefresh === 'function') {
       wx.stopPullDownRefresh()
     }
@@ -45,12 +97,13 @@ const pageDef = {
     this.setData({ loading: true, error: '' })
 
     try {
-      const data = await api.listArticles()
+      const data = await api.listArticles({ tag: this.data.activeTag })
       const items = Array.isArray(data && data.items) ? data.items.map(normalizeArticle) : []
+      const visible = withoutPinned(items, this.data.pinned, this.data.activeTag)
       this.setData({
         loading: false,
         error: '',
-        items,
+        items: visible,
         updatedAtText: formatUpdatedAt(),
       })
     } catch (err) {
@@ -62,6 +115,17 @@ const pageDef = {
     }
   },
 
+  onFeatureTap(event) {
+    const dataset = (event && event.currentTarget && event.currentTarget.dataset) || {}
+    if (dataset.type !== 'event' || !dataset.id) {
+      return
+    }
+
+    wx.navigateTo({
+      url: `/pages/event-detail/index?id=${dataset.id}`,
+    })
+  },
+
   onCopySourceTap(event) {
     const url = event && event.currentTarget && event.currentTarget.dataset && event.currentTarget.dataset.url
     if (!url || typeof wx.setClipboardData !== 'function') {
@@ -33,8 +33,27 @@ function request(path, options = {}) {
   })
 }
 
-function listArticles() {
-  return request('/api/articles')
+function listArticles(options = {}) {
+  const params = []
+  if (options.tag) {
+    params.push(`tag=${encodeURIComponent(options.tag)}`)
+  }
+  if (options.cursor) {
+    params.push(`cursor=${encodeURIComponent(options.cursor)}`)
+  }
+  return request(params.length ? `/api/articles?${params.join('&')}` : '/api/articles')
+}
+
+function listCategories() {
+  return request('/api/tags?kind=category')
+}
+
+function getArticleDetail(articleId) {
+  return request(`/api/articles/${articleId}`)
+}
+
+function getHome() {
+  return request('/api/home')
 }
 
 function listEvents() {
@@ -49,16 +68,38 @@ function searchFighters(keyword) {
   return request(`/api/fighters/search?q=${encodeURIComponent(keyword || '')}`)
 }
 
+function search(keyword, options = {}) {
+  const params = [`q=${encodeURIComponent(keyword || '')}`]
+  if (options.type) {
+    params.push(`type=${encodeURIComponent(options.type)}`)
+  }
+  return request(`/api/search?${params.join('&')}`)
+}
+
 function getFighterDetail(fighterId) {
   return request(`/api/fighters/${fighterId}`)
 }
 
+function listFighterArticles(fighterId) {
+  return request(`/api/fighters/${fighterId}/articles`)
+}
+
+function listEventArticles(eventId) {
+  return request(`/api/events/${eventId}/articles`)
+}
+
 module.exports = {
   request,
   setApiBaseUrl,
   listArticles,
+  getArticleDetail,
+  listCategories,
+  getHome,
   listEvents,
   getEventCard,
   searchFighters,
+  search,
   getFighterDetail,
+  listFighterArticles,
+  listEventArticles,
 }
@@ -21,6 +21,42 @@ describe('miniapp api client', () => {
     )
   })
 
+  test('article helpers pass the tag and feed cursor and request article detail', async () => {
+    const { listArticles, listCategories, getArticleDetail } = loadApi()
+    global.wx = {
+      request: jest.fn(({ success }) => success({ data: { items: [] } })),
+    }
+
+    await listArticles({ tag: 'results', cursor: 'MjAyNi0wNS0wMVQxMjowMDowMFp8NQ' })
+    await listCategories()
+    await getArticleDetail(5)
+
+    expect(global.wx.request).toHaveBeenCalledWith(
+      expect.objectContaining({
+        url: 'https://localhost:8443/api/articles?tag=results&cursor=MjAyNi0wNS0wMVQxMjowMDowMFp8NQ',
+      }),
+    )
+    expect(global.wx.request).toHaveBeenCalledWith(
+      expect.objectContaining({ url: 'https://localhost:8443/api/tags?kind=category' }),
+    )
+    expect(global.wx.request).toHaveBeenCalledWith(
+      expect.objectContaining({ url: 'https://localhost:8443/api/articles/5' }),
+    )
+  })
+
+  test('getHome requests the home screen', async () => {
+    const { getHome } = loadApi()
+    global.wx = {
+      request: jest.fn(({ success }) => success({ data: { carousel: [] } })),
+    }
+
+    await getHome()
+
+    expect(global.wx.request).toHaveBeenCalledWith(
+      expect.objectContaining({ url: 'https://localhost:8443/api/home' }),
+    )
+  })
+
   test('searchFighters encodes query string', async () => {
     const { searchFighters } = loadApi()
     global.wx = {
@@ -36,6 +72,40 @@ describe('miniapp api client', () => {
     )
   })
 
+  test('search encodes keyword and optional type filter', async () => {
+    const { search } = loadApi()
+    global.wx = {
+      request: jest.fn(({ success }) => success({ data: { items: [] } })),
+    }
+
+    await search('佩雷拉')
+    await search('Alex Pereira', { type: 'fighter,event' })
+
+    expect(global.wx.request).toHaveBeenCalledWith(
+      expect.objectContaining({ url: 'https://localhost:8443/api/search?q=%E4%BD%A9%E9%9B%B7%E6%8B%89' }),
+    )
+    expect(global.wx.request).toHaveBeenCalledWith(
+      expect.objectContaining({ url: 'https://localhost:8443/api/search?q=Alex%20Pereira&type=fighter%2Cevent' }),
+    )
+  })
+
+  test('entity article helpers request related news endpoints', async () => {
+    const { listFighterArticles, listEventArticles } = loadApi()
+    global.wx = {
+      request: jest.fn(({ success }) => success({ data: { items: [] } })),
+    }
+
+    await listFighterArticles(20)
+    await listEventArticles(7)
+
+    expect(global.wx.request).toHaveBeenCalledWith(
+      expect.objectContaining({ url: 'https://localhost:8443/api/fighters/20/articles' }),
+    )
+    expect(global.wx.request).toHaveBeenCalledWith(
+      expect.objectContaining({ url: 'https://localhost:8443/api/events/7/articles' }),
+    )
+  })
+
   test('release build uses production api base url', async () => {
     global.wx = {
       getAccountInfoSync: jest.fn(() => ({ miniProgram: { envVersion: 'release' } })),
@@ -22,6 +22,29 @@ describe('fighter pages', () => {
     expect(ctx.data.loading).toBe(false)
   })
 
+  test('fighter page loads related news without failing on errors', async () => {
+    const ctx = createPageContext(fighterPage)
+    const listFighterArticles = jest.fn().mockResolvedValue({
+      items: [{ id: 5, title: '佩雷拉卫冕成功', summary: '第二回合 KO', source_url: 'https://example.com/a' }],
+    })
+    fighterPage.__setApi({
+      getFighterDetail: jest.fn().mockResolvedValue({ id: 20, name: 'Alex Pereira' }),
+      listFighterArticles,
+    })
+
+    await fighterPage.onLoad.call(ctx, { id: '20' })
+
+    expect(listFighterArticles).toHaveBeenCalledWith(20)
+    expect(ctx.data.relatedArticles).toEqual([
+      { id: 5, title: '佩雷拉卫冕成功', summary: '第二回合 KO', source_url: 'https://example.com/a' },
+    ])
+
+    listFighterArticles.mockRejectedValue(new Error('request failed: 500'))
+    await fighterPage.onRetryTap.call(ctx)
+    expect(ctx.data.fighter.name).toBe('Alex Pereira')
+    expect(ctx.data.relatedArticles).toEqual([])
+  })
+
   test('fighter page formats bilingual country and sanitizes nickname', async () => {
     const ctx = createPageContext(fighterPage)
     fighterPage.__setApi({
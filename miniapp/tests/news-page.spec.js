@@ -18,6 +18,54 @@ describe('news page', () => {
     expect(ctx.data.loading).toBe(false)
   })
 
+  test('tabs list categories and filter the feed by tag', async () => {
+    const ctx = createPageContext(newsPage)
+    const api = {
+      listCategories: jest.fn().mockResolvedValue({
+        items: [
+          { slug: 'results', name: '赛果', kind: 'category' },
+          { slug: 'videos', name: '视频', kind: 'category' },
+        ],
+      }),
+      listArticles: jest.fn().mockResolvedValue({ items: [{ id: 2, title: 'results-a' }] }),
+    }
+    newsPage.__setApi(api)
+    ctx.loadArticles = newsPage.loadArticles
+
+    await newsPage.loadTabs.call(ctx)
+    expect(ctx.data.tabs.map((tab) => tab.name)).toEqual(['全部', '赛果', '视频'])
+
+    await newsPage.onTabTap.call(ctx, { currentTarget: { dataset: { tag: 'results' } } })
+    expect(ctx.data.activeTag).toBe('results')
+    expect(api.listArticles).toHaveBeenCalledWith({ tag: 'results' })
+    expect(ctx.data.items).toHaveLength(1)
+  })
+
+  test('home fills the carousel and keeps pinned articles out of the full feed', async () => {
+    const ctx = createPageContext(newsPage)
+    newsPage.__setApi({
+      getHome: jest.fn().mockResolvedValue({
+        carousel: [{ slot_id: 1, target_type: 'event', target_id: 11, title: 'UFC 301' }],
+        pinned: [{ id: 2, title: 'pinned' }],
+        highlight_event: { id: 12, name: 'ONE 170' },
+      }),
+      listArticles: jest.fn().mockResolvedValue({ items: [{ id: 3, title: 'latest' }, { id: 2, title: 'pinned' }] }),
+    })
+    global.wx = { navigateTo: jest.fn() }
+
+    await newsPage.loadHome.call(ctx)
+    await newsPage.loadArticles.call(ctx)
+
+    expect(ctx.data.featured).toHaveLength(1)
+    expect(ctx.data.highlightEvent.id).toBe(12)
+    expect(ctx.data.items.map((item) => item.id)).toEqual([3])
+
+    newsPage.onFeatureTap.call(ctx, { currentTarget: { dataset: { type: 'event', id: 11 } } })
+    newsPage.onFeatureTap.call(ctx, { currentTarget: { dataset: { type: 'article', id: 2 } } })
+    expect(global.wx.navigateTo).toHaveBeenCalledTimes(1)
+    expect(global.wx.navigateTo).toHaveBeenCalledWith({ url: '/pages/event-detail/index?id=11' })
+  })
+
   test('loadArticles writes error on failure', async () => {
     const ctx = createPageContext(newsPage)
     newsPage.__setApi({
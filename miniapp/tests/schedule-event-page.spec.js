@@ -233,4 +233,37 @@ describe('schedule and event detail pages', () => {
 
     expect(ctx.data.mainCard[0].result_text).toBe('特殊情况：无结果（No Contest）')
   })
+
+  test('event detail highlights the bout in progress', async () => {
+    const ctx = createPageContext(eventDetailPage)
+    eventDetailPage.__setApi({
+      getEventCard: jest.fn().mockResolvedValue({
+        id: 10,
+        status: 'live',
+        current_bout_id: 1002,
+        main_card: [
+          {
+            id: 1001,
+            live_state: 'upcoming',
+            red_fighter: { id: 20, name: 'Fighter A' },
+            blue_fighter: { id: 21, name: 'Fighter B' },
+          },
+          {
+            id: 1002,
+            live_state: 'in_progress',
+            current_round: 2,
+            red_fighter: { id: 22, name: 'Fighter C' },
+            blue_fighter: { id: 23, name: 'Fighter D' },
+          },
+        ],
+        prelims: [],
+      }),
+    })
+
+    await eventDetailPage.onLoad.call(ctx, { id: '10' })
+
+    expect(ctx.data.mainCard[0].is_current).toBe(false)
+    expect(ctx.data.mainCard[1].is_current).toBe(true)
+    expect(ctx.data.mainCard[1].live_state_text).toBe('正在进行 · 第2回合')
+  })
 })